@@ -49,6 +49,12 @@ func (l *Link) Link() string {
 	return format((*big.Int)(l), 18)
 }
 
+// LinkTotalSupply returns the fixed total supply of LINK, 1 billion LINK, in
+// juels.
+func LinkTotalSupply() *Link {
+	return (*Link)(new(big.Int).Mul(big.NewInt(1e9), getDenominator(18)))
+}
+
 // SetInt64 delegates to *big.Int.SetInt64
 func (l *Link) SetInt64(w int64) *Link {
 	return (*Link)((*big.Int)(l).SetInt64(w))
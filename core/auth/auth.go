@@ -15,6 +15,10 @@ var (
 	// some system failure on our behalf (i.e. HTTP 5xx), more detail is not
 	// given
 	ErrorAuthFailed = errors.New("Authentication failed")
+
+	// ErrorForbidden is returned when the request is authenticated, but not
+	// allowed to perform the requested operation
+	ErrorForbidden = errors.New("Forbidden")
 )
 
 // Token is used for API authentication.
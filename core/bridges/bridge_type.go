@@ -6,10 +6,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"net/url"
 	"regexp"
 	"strings"
 	"time"
 
+	"github.com/pkg/errors"
+
 	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/utils"
@@ -62,6 +65,7 @@ type BridgeType struct {
 	MinimumContractPayment *assets.Link
 	CreatedAt              time.Time
 	UpdatedAt              time.Time
+	DeletedAt              *time.Time
 }
 
 // NewBridgeType returns a bridge bridge type authentication (with plaintext
@@ -95,6 +99,37 @@ func NewBridgeType(btr *BridgeTypeRequest) (*BridgeTypeAuthentication,
 		}, nil
 }
 
+// ValidateURL checks that u is an absolute http or https URL, which is what
+// the node needs to be able to call a bridge.
+func ValidateURL(u *url.URL) error {
+	if u.Scheme == "" {
+		return errors.New("URL must be absolute and start with http:// or https://")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("URL scheme must be http or https, got %s", u.Scheme)
+	}
+	if u.Host == "" {
+		return errors.New("URL must include a host")
+	}
+	return nil
+}
+
+// ValidateMinimumContractPayment checks that p, if set, is neither negative
+// nor larger than max, which is usually the configured
+// BridgeMaxMinimumContractPayment.
+func ValidateMinimumContractPayment(p *assets.Link, max *assets.Link) error {
+	if p == nil {
+		return nil
+	}
+	if p.Cmp(assets.NewLinkFromJuels(0)) < 0 {
+		return errors.New("MinimumContractPayment must be positive")
+	}
+	if p.Cmp(max) > 0 {
+		return fmt.Errorf("MinimumContractPayment must not exceed %s juels, got %s", max, p)
+	}
+	return nil
+}
+
 // AuthenticateBridgeType returns true if the passed token matches its
 // IncomingToken, or returns false with an error.
 func AuthenticateBridgeType(bt *BridgeType, token string) (bool, error) {
@@ -1,8 +1,11 @@
 package bridges_test
 
 import (
+	"math/big"
+	"net/url"
 	"testing"
 
+	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/bridges"
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 
@@ -36,3 +39,67 @@ func TestBridgeType_Authenticate(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		url     string
+		wantErr string
+	}{
+		{"http://adapter:8080/path", ""},
+		{"https://adapter.example.com", ""},
+		{"ftp://adapter.example.com", "URL scheme must be http or https, got ftp"},
+		{"//adapter.example.com", "URL must be absolute and start with http:// or https://"},
+		{"adapter/path", "URL must be absolute and start with http:// or https://"},
+		{"http:///path", "URL must include a host"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.url, func(t *testing.T) {
+			u, err := url.Parse(test.url)
+			require.NoError(t, err)
+
+			err = bridges.ValidateURL(u)
+			if test.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateMinimumContractPayment(t *testing.T) {
+	t.Parallel()
+
+	tooLarge := (*assets.Link)(new(big.Int).Mul(assets.LinkTotalSupply().ToInt(), big.NewInt(1000)))
+
+	tests := []struct {
+		name    string
+		payment *assets.Link
+		max     *assets.Link
+		wantErr string
+	}{
+		{"unset", nil, assets.LinkTotalSupply(), ""},
+		{"zero", assets.NewLinkFromJuels(0), assets.LinkTotalSupply(), ""},
+		{"reasonable", assets.NewLinkFromJuels(1e18), assets.LinkTotalSupply(), ""},
+		{"total supply", assets.LinkTotalSupply(), assets.LinkTotalSupply(), ""},
+		{"negative", assets.NewLinkFromJuels(-1), assets.LinkTotalSupply(), "MinimumContractPayment must be positive"},
+		{"absurdly large", tooLarge, assets.LinkTotalSupply(), "MinimumContractPayment must not exceed 1000000000000000000000000000 juels, got 1000000000000000000000000000000"},
+		{"above a lower max", assets.NewLinkFromJuels(101), assets.NewLinkFromJuels(100), "MinimumContractPayment must not exceed 100 juels, got 101"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			err := bridges.ValidateMinimumContractPayment(test.payment, test.max)
+			if test.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.wantErr)
+			}
+		})
+	}
+}
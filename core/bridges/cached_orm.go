@@ -0,0 +1,119 @@
+package bridges
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type cachedBridge struct {
+	bt        BridgeType
+	expiresAt time.Time
+}
+
+// cachedORM wraps an ORM and memoizes FindBridge results for a fixed TTL.
+// Writes to a bridge through this ORM evict its cached entry; all other
+// methods pass straight through to the wrapped ORM.
+type cachedORM struct {
+	ORM
+	ttl time.Duration
+
+	mu    sync.RWMutex
+	cache map[TaskType]cachedBridge
+	// gen counts invalidations, so that a lookup racing with a write does not
+	// cache the bridge as it was before the write. It is shared by all bridges
+	// so that it takes no memory per name; a write at worst stops concurrent
+	// lookups of other bridges from being cached.
+	gen uint64
+}
+
+var _ ORM = (*cachedORM)(nil)
+
+// NewCachedORM returns an ORM which caches FindBridge lookups on top of orm
+// for the given ttl.
+func NewCachedORM(orm ORM, ttl time.Duration) ORM {
+	return &cachedORM{
+		ORM:   orm,
+		ttl:   ttl,
+		cache: make(map[TaskType]cachedBridge),
+	}
+}
+
+// FindBridge returns the cached bridge if present and not expired, otherwise
+// it falls through to the wrapped ORM. Errors are never cached.
+func (o *cachedORM) FindBridge(name TaskType) (BridgeType, error) {
+	return o.findBridge(name, func() (BridgeType, error) {
+		return o.ORM.FindBridge(name)
+	})
+}
+
+// FindBridgeCtx is FindBridge, with ctx passed to the wrapped ORM on a cache
+// miss.
+func (o *cachedORM) FindBridgeCtx(ctx context.Context, name TaskType) (BridgeType, error) {
+	return o.findBridge(name, func() (BridgeType, error) {
+		return o.ORM.FindBridgeCtx(ctx, name)
+	})
+}
+
+func (o *cachedORM) findBridge(name TaskType, load func() (BridgeType, error)) (BridgeType, error) {
+	o.mu.RLock()
+	entry, ok := o.cache[name]
+	gen := o.gen
+	o.mu.RUnlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.bt, nil
+	}
+
+	bt, err := load()
+	if err != nil {
+		return bt, err
+	}
+
+	o.mu.Lock()
+	// Skip caching if the bridge was invalidated while loading, as bt may
+	// predate the write
+	if o.gen == gen {
+		now := time.Now()
+		// Drop expired entries, e.g. of bridges deleted through another ORM
+		for n, e := range o.cache {
+			if now.After(e.expiresAt) {
+				delete(o.cache, n)
+			}
+		}
+		o.cache[name] = cachedBridge{bt: bt, expiresAt: now.Add(o.ttl)}
+	}
+	o.mu.Unlock()
+	return bt, nil
+}
+
+func (o *cachedORM) CreateBridgeType(bt *BridgeType) error {
+	defer o.invalidate(bt.Name)
+	return o.ORM.CreateBridgeType(bt)
+}
+
+func (o *cachedORM) UpdateBridgeType(bt *BridgeType, btr *BridgeTypeRequest) error {
+	defer o.invalidate(bt.Name)
+	return o.ORM.UpdateBridgeType(bt, btr)
+}
+
+func (o *cachedORM) DeleteBridgeType(bt *BridgeType, force bool) error {
+	defer o.invalidate(bt.Name)
+	return o.ORM.DeleteBridgeType(bt, force)
+}
+
+func (o *cachedORM) ArchiveBridgeType(name TaskType) error {
+	defer o.invalidate(name)
+	return o.ORM.ArchiveBridgeType(name)
+}
+
+func (o *cachedORM) RestoreBridgeType(name TaskType) error {
+	defer o.invalidate(name)
+	return o.ORM.RestoreBridgeType(name)
+}
+
+func (o *cachedORM) invalidate(name TaskType) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	delete(o.cache, name)
+	o.gen++
+}
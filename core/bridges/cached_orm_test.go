@@ -0,0 +1,168 @@
+package bridges_test
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/bridges"
+	"github.com/smartcontractkit/chainlink/core/bridges/mocks"
+)
+
+func TestCachedORM_FindBridge(t *testing.T) {
+	t.Parallel()
+
+	orm := new(mocks.ORM)
+	cached := bridges.NewCachedORM(orm, time.Hour)
+
+	bt := bridges.BridgeType{Name: "cachedbridge", Confirmations: 1}
+	orm.On("FindBridge", bt.Name).Return(bt, nil).Once()
+
+	found, err := cached.FindBridge(bt.Name)
+	require.NoError(t, err)
+	assert.Equal(t, bt, found)
+
+	// served from the cache; a second call to the mock would fail the Once
+	found, err = cached.FindBridge(bt.Name)
+	require.NoError(t, err)
+	assert.Equal(t, bt, found)
+
+	orm.AssertExpectations(t)
+}
+
+func TestCachedORM_FindBridgeCtx(t *testing.T) {
+	t.Parallel()
+
+	orm := new(mocks.ORM)
+	cached := bridges.NewCachedORM(orm, time.Hour)
+
+	ctx := context.Background()
+	bt := bridges.BridgeType{Name: "cachedbridge", Confirmations: 1}
+	orm.On("FindBridgeCtx", ctx, bt.Name).Return(bt, nil).Once()
+
+	found, err := cached.FindBridgeCtx(ctx, bt.Name)
+	require.NoError(t, err)
+	assert.Equal(t, bt, found)
+
+	// both variants share the cache
+	found, err = cached.FindBridge(bt.Name)
+	require.NoError(t, err)
+	assert.Equal(t, bt, found)
+
+	orm.AssertExpectations(t)
+}
+
+func TestCachedORM_FindBridge_Errors(t *testing.T) {
+	t.Parallel()
+
+	orm := new(mocks.ORM)
+	cached := bridges.NewCachedORM(orm, time.Hour)
+
+	orm.On("FindBridge", bridges.TaskType("missing")).Return(bridges.BridgeType{}, sql.ErrNoRows).Twice()
+
+	_, err := cached.FindBridge("missing")
+	require.Equal(t, sql.ErrNoRows, err)
+	_, err = cached.FindBridge("missing")
+	require.Equal(t, sql.ErrNoRows, err)
+
+	orm.AssertExpectations(t)
+}
+
+func TestCachedORM_FindBridge_Expiry(t *testing.T) {
+	t.Parallel()
+
+	orm := new(mocks.ORM)
+	cached := bridges.NewCachedORM(orm, time.Nanosecond)
+
+	bt := bridges.BridgeType{Name: "cachedbridge"}
+	orm.On("FindBridge", bt.Name).Return(bt, nil).Twice()
+
+	_, err := cached.FindBridge(bt.Name)
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond)
+	_, err = cached.FindBridge(bt.Name)
+	require.NoError(t, err)
+
+	orm.AssertExpectations(t)
+}
+
+func TestCachedORM_Invalidation(t *testing.T) {
+	t.Parallel()
+
+	orm := new(mocks.ORM)
+	cached := bridges.NewCachedORM(orm, time.Hour)
+
+	bt := bridges.BridgeType{Name: "cachedbridge", Confirmations: 1}
+	updated := bridges.BridgeType{Name: "cachedbridge", Confirmations: 2}
+	btr := &bridges.BridgeTypeRequest{Confirmations: 2}
+
+	orm.On("FindBridge", bt.Name).Return(bt, nil).Once()
+	orm.On("UpdateBridgeType", &bt, btr).Return(nil).Once()
+	orm.On("FindBridge", bt.Name).Return(updated, nil).Once()
+	orm.On("DeleteBridgeType", &updated, false).Return(nil).Once()
+	orm.On("FindBridge", bt.Name).Return(bridges.BridgeType{}, sql.ErrNoRows).Once()
+
+	found, err := cached.FindBridge(bt.Name)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), found.Confirmations)
+
+	require.NoError(t, cached.UpdateBridgeType(&bt, btr))
+
+	found, err = cached.FindBridge(bt.Name)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), found.Confirmations)
+
+	require.NoError(t, cached.DeleteBridgeType(&updated, false))
+
+	_, err = cached.FindBridge(bt.Name)
+	require.Equal(t, sql.ErrNoRows, err)
+
+	orm.AssertExpectations(t)
+}
+
+func TestCachedORM_InvalidationDuringLookup(t *testing.T) {
+	t.Parallel()
+
+	orm := new(mocks.ORM)
+	cached := bridges.NewCachedORM(orm, time.Hour)
+
+	bt := bridges.BridgeType{Name: "cachedbridge", Confirmations: 1}
+	updated := bridges.BridgeType{Name: "cachedbridge", Confirmations: 2}
+	btr := &bridges.BridgeTypeRequest{Confirmations: 2}
+
+	loading := make(chan struct{})
+	release := make(chan struct{})
+	orm.On("FindBridge", bt.Name).Return(bt, nil).Run(func(mock.Arguments) {
+		close(loading)
+		<-release
+	}).Once()
+	orm.On("UpdateBridgeType", &bt, btr).Return(nil).Once()
+	orm.On("FindBridge", bt.Name).Return(updated, nil).Once()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		found, err := cached.FindBridge(bt.Name)
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(1), found.Confirmations)
+	}()
+
+	// the update lands while the first lookup holds the stale bridge
+	<-loading
+	require.NoError(t, cached.UpdateBridgeType(&bt, btr))
+	close(release)
+	wg.Wait()
+
+	found, err := cached.FindBridge(bt.Name)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), found.Confirmations, "stale bridge should not have been cached")
+
+	orm.AssertExpectations(t)
+}
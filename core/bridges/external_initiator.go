@@ -5,6 +5,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/lib/pq"
+
 	"github.com/smartcontractkit/chainlink/core/auth"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/utils"
@@ -33,6 +35,14 @@ type ExternalInitiator struct {
 	UpdatedAt time.Time
 }
 
+// ExternalInitiatorWithJobs is an ExternalInitiator along with the webhook
+// specs it is linked to and the jobs they belong to
+type ExternalInitiatorWithJobs struct {
+	ExternalInitiator
+	JobIDs         pq.Int32Array `db:"job_ids"`
+	WebhookSpecIDs pq.Int32Array `db:"webhook_spec_ids"`
+}
+
 // NewExternalInitiator generates an ExternalInitiator from an
 // auth.Token, hashing the password for storage
 func NewExternalInitiator(
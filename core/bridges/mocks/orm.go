@@ -6,6 +6,8 @@ import (
 	auth "github.com/smartcontractkit/chainlink/core/auth"
 	bridges "github.com/smartcontractkit/chainlink/core/bridges"
 
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -14,13 +16,50 @@ type ORM struct {
 	mock.Mock
 }
 
-// BridgeTypes provides a mock function with given fields: offset, limit
-func (_m *ORM) BridgeTypes(offset int, limit int) ([]bridges.BridgeType, int, error) {
-	ret := _m.Called(offset, limit)
+// ArchiveBridgeType provides a mock function with given fields: name
+func (_m *ORM) ArchiveBridgeType(name bridges.TaskType) error {
+	ret := _m.Called(name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(bridges.TaskType) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthenticateExternalInitiator provides a mock function with given fields: eia
+func (_m *ORM) AuthenticateExternalInitiator(eia *auth.Token) (*bridges.ExternalInitiator, error) {
+	ret := _m.Called(eia)
+
+	var r0 *bridges.ExternalInitiator
+	if rf, ok := ret.Get(0).(func(*auth.Token) *bridges.ExternalInitiator); ok {
+		r0 = rf(eia)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bridges.ExternalInitiator)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*auth.Token) error); ok {
+		r1 = rf(eia)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BridgeTypes provides a mock function with given fields: offset, limit, sort
+func (_m *ORM) BridgeTypes(offset int, limit int, sort bridges.BridgeTypesSort) ([]bridges.BridgeType, int, error) {
+	ret := _m.Called(offset, limit, sort)
 
 	var r0 []bridges.BridgeType
-	if rf, ok := ret.Get(0).(func(int, int) []bridges.BridgeType); ok {
-		r0 = rf(offset, limit)
+	if rf, ok := ret.Get(0).(func(int, int, bridges.BridgeTypesSort) []bridges.BridgeType); ok {
+		r0 = rf(offset, limit, sort)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]bridges.BridgeType)
@@ -28,15 +67,75 @@ func (_m *ORM) BridgeTypes(offset int, limit int) ([]bridges.BridgeType, int, er
 	}
 
 	var r1 int
-	if rf, ok := ret.Get(1).(func(int, int) int); ok {
-		r1 = rf(offset, limit)
+	if rf, ok := ret.Get(1).(func(int, int, bridges.BridgeTypesSort) int); ok {
+		r1 = rf(offset, limit, sort)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(int, int) error); ok {
-		r2 = rf(offset, limit)
+	if rf, ok := ret.Get(2).(func(int, int, bridges.BridgeTypesSort) error); ok {
+		r2 = rf(offset, limit, sort)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// BridgeTypesAfter provides a mock function with given fields: after, limit
+func (_m *ORM) BridgeTypesAfter(after bridges.TaskType, limit int) ([]bridges.BridgeType, int, error) {
+	ret := _m.Called(after, limit)
+
+	var r0 []bridges.BridgeType
+	if rf, ok := ret.Get(0).(func(bridges.TaskType, int) []bridges.BridgeType); ok {
+		r0 = rf(after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bridges.BridgeType)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(bridges.TaskType, int) int); ok {
+		r1 = rf(after, limit)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(bridges.TaskType, int) error); ok {
+		r2 = rf(after, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// BridgeTypesCtx provides a mock function with given fields: ctx, offset, limit, sort
+func (_m *ORM) BridgeTypesCtx(ctx context.Context, offset int, limit int, sort bridges.BridgeTypesSort) ([]bridges.BridgeType, int, error) {
+	ret := _m.Called(ctx, offset, limit, sort)
+
+	var r0 []bridges.BridgeType
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, bridges.BridgeTypesSort) []bridges.BridgeType); ok {
+		r0 = rf(ctx, offset, limit, sort)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bridges.BridgeType)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, bridges.BridgeTypesSort) int); ok {
+		r1 = rf(ctx, offset, limit, sort)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, int, int, bridges.BridgeTypesSort) error); ok {
+		r2 = rf(ctx, offset, limit, sort)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -44,6 +143,48 @@ func (_m *ORM) BridgeTypes(offset int, limit int) ([]bridges.BridgeType, int, er
 	return r0, r1, r2
 }
 
+// CountBridgeTypes provides a mock function with given fields:
+func (_m *ORM) CountBridgeTypes() (int, error) {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountExternalInitiators provides a mock function with given fields:
+func (_m *ORM) CountExternalInitiators() (int, error) {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateBridgeType provides a mock function with given fields: bt
 func (_m *ORM) CreateBridgeType(bt *bridges.BridgeType) error {
 	ret := _m.Called(bt)
@@ -72,13 +213,13 @@ func (_m *ORM) CreateExternalInitiator(externalInitiator *bridges.ExternalInitia
 	return r0
 }
 
-// DeleteBridgeType provides a mock function with given fields: bt
-func (_m *ORM) DeleteBridgeType(bt *bridges.BridgeType) error {
-	ret := _m.Called(bt)
+// DeleteBridgeType provides a mock function with given fields: bt, force
+func (_m *ORM) DeleteBridgeType(bt *bridges.BridgeType, force bool) error {
+	ret := _m.Called(bt, force)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*bridges.BridgeType) error); ok {
-		r0 = rf(bt)
+	if rf, ok := ret.Get(0).(func(*bridges.BridgeType, bool) error); ok {
+		r0 = rf(bt, force)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -130,6 +271,36 @@ func (_m *ORM) ExternalInitiators(offset int, limit int) ([]bridges.ExternalInit
 	return r0, r1, r2
 }
 
+// ExternalInitiatorsWithLinks provides a mock function with given fields: offset, limit
+func (_m *ORM) ExternalInitiatorsWithLinks(offset int, limit int) ([]bridges.ExternalInitiatorWithJobs, int, error) {
+	ret := _m.Called(offset, limit)
+
+	var r0 []bridges.ExternalInitiatorWithJobs
+	if rf, ok := ret.Get(0).(func(int, int) []bridges.ExternalInitiatorWithJobs); ok {
+		r0 = rf(offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bridges.ExternalInitiatorWithJobs)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(int, int) int); ok {
+		r1 = rf(offset, limit)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int, int) error); ok {
+		r2 = rf(offset, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // FindBridge provides a mock function with given fields: name
 func (_m *ORM) FindBridge(name bridges.TaskType) (bridges.BridgeType, error) {
 	ret := _m.Called(name)
@@ -151,6 +322,50 @@ func (_m *ORM) FindBridge(name bridges.TaskType) (bridges.BridgeType, error) {
 	return r0, r1
 }
 
+// FindBridgeCtx provides a mock function with given fields: ctx, name
+func (_m *ORM) FindBridgeCtx(ctx context.Context, name bridges.TaskType) (bridges.BridgeType, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 bridges.BridgeType
+	if rf, ok := ret.Get(0).(func(context.Context, bridges.TaskType) bridges.BridgeType); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(bridges.BridgeType)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, bridges.TaskType) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindBridges provides a mock function with given fields: names
+func (_m *ORM) FindBridges(names []bridges.TaskType) (map[bridges.TaskType]bridges.BridgeType, error) {
+	ret := _m.Called(names)
+
+	var r0 map[bridges.TaskType]bridges.BridgeType
+	if rf, ok := ret.Get(0).(func([]bridges.TaskType) map[bridges.TaskType]bridges.BridgeType); ok {
+		r0 = rf(names)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[bridges.TaskType]bridges.BridgeType)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]bridges.TaskType) error); ok {
+		r1 = rf(names)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindExternalInitiator provides a mock function with given fields: eia
 func (_m *ORM) FindExternalInitiator(eia *auth.Token) (*bridges.ExternalInitiator, error) {
 	ret := _m.Called(eia)
@@ -195,6 +410,73 @@ func (_m *ORM) FindExternalInitiatorByName(iname string) (bridges.ExternalInitia
 	return r0, r1
 }
 
+// FindExternalInitiatorByNameCtx provides a mock function with given fields: ctx, iname
+func (_m *ORM) FindExternalInitiatorByNameCtx(ctx context.Context, iname string) (bridges.ExternalInitiator, error) {
+	ret := _m.Called(ctx, iname)
+
+	var r0 bridges.ExternalInitiator
+	if rf, ok := ret.Get(0).(func(context.Context, string) bridges.ExternalInitiator); ok {
+		r0 = rf(ctx, iname)
+	} else {
+		r0 = ret.Get(0).(bridges.ExternalInitiator)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, iname)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreBridgeType provides a mock function with given fields: name
+func (_m *ORM) RestoreBridgeType(name bridges.TaskType) error {
+	ret := _m.Called(name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(bridges.TaskType) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RotateExternalInitiatorSecrets provides a mock function with given fields: name
+func (_m *ORM) RotateExternalInitiatorSecrets(name string) (*bridges.ExternalInitiator, *auth.Token, error) {
+	ret := _m.Called(name)
+
+	var r0 *bridges.ExternalInitiator
+	if rf, ok := ret.Get(0).(func(string) *bridges.ExternalInitiator); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bridges.ExternalInitiator)
+		}
+	}
+
+	var r1 *auth.Token
+	if rf, ok := ret.Get(1).(func(string) *auth.Token); ok {
+		r1 = rf(name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*auth.Token)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(name)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // UpdateBridgeType provides a mock function with given fields: bt, btr
 func (_m *ORM) UpdateBridgeType(bt *bridges.BridgeType, btr *bridges.BridgeTypeRequest) error {
 	ret := _m.Called(bt, btr)
@@ -208,3 +490,24 @@ func (_m *ORM) UpdateBridgeType(bt *bridges.BridgeType, btr *bridges.BridgeTypeR
 
 	return r0
 }
+
+// UpsertExternalInitiator provides a mock function with given fields: externalInitiator
+func (_m *ORM) UpsertExternalInitiator(externalInitiator *bridges.ExternalInitiator) (bool, error) {
+	ret := _m.Called(externalInitiator)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(*bridges.ExternalInitiator) bool); ok {
+		r0 = rf(externalInitiator)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*bridges.ExternalInitiator) error); ok {
+		r1 = rf(externalInitiator)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -1,9 +1,13 @@
 package bridges
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
 
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
+
 	"github.com/smartcontractkit/chainlink/core/auth"
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/services/postgres"
@@ -14,40 +18,140 @@ import (
 
 type ORM interface {
 	FindBridge(name TaskType) (bt BridgeType, err error)
-	DeleteBridgeType(bt *BridgeType) error
-	BridgeTypes(offset int, limit int) ([]BridgeType, int, error)
+	FindBridgeCtx(ctx context.Context, name TaskType) (bt BridgeType, err error)
+	FindBridges(names []TaskType) (bts map[TaskType]BridgeType, err error)
+	DeleteBridgeType(bt *BridgeType, force bool) error
+	ArchiveBridgeType(name TaskType) error
+	RestoreBridgeType(name TaskType) error
+	BridgeTypes(offset int, limit int, sort BridgeTypesSort) ([]BridgeType, int, error)
+	BridgeTypesCtx(ctx context.Context, offset int, limit int, sort BridgeTypesSort) ([]BridgeType, int, error)
+	BridgeTypesAfter(after TaskType, limit int) ([]BridgeType, int, error)
+	CountBridgeTypes() (int, error)
 	CreateBridgeType(bt *BridgeType) error
 	UpdateBridgeType(bt *BridgeType, btr *BridgeTypeRequest) error
 
 	ExternalInitiators(offset int, limit int) ([]ExternalInitiator, int, error)
+	ExternalInitiatorsWithLinks(offset int, limit int) ([]ExternalInitiatorWithJobs, int, error)
+	CountExternalInitiators() (int, error)
 	CreateExternalInitiator(externalInitiator *ExternalInitiator) error
+	UpsertExternalInitiator(externalInitiator *ExternalInitiator) (created bool, err error)
 	DeleteExternalInitiator(name string) error
 	FindExternalInitiator(eia *auth.Token) (*ExternalInitiator, error)
+	AuthenticateExternalInitiator(eia *auth.Token) (*ExternalInitiator, error)
 	FindExternalInitiatorByName(iname string) (exi ExternalInitiator, err error)
+	FindExternalInitiatorByNameCtx(ctx context.Context, iname string) (exi ExternalInitiator, err error)
+	// RotateExternalInitiatorSecrets also returns the new incoming credentials
+	// as a token, since ExternalInitiator only holds the hashed secret
+	RotateExternalInitiatorSecrets(name string) (*ExternalInitiator, *auth.Token, error)
+}
+
+var (
+	// ErrBridgeNotFound is returned when no bridge exists with the requested
+	// name. It wraps sql.ErrNoRows.
+	ErrBridgeNotFound error = notFoundError("bridge not found")
+	// ErrExternalInitiatorNotFound is returned when no external initiator
+	// exists with the requested name. It wraps sql.ErrNoRows.
+	ErrExternalInitiatorNotFound error = notFoundError("external initiator not found")
+	// ErrBridgeExists is returned by CreateBridgeType when a bridge already has
+	// the requested name. Archived bridges keep their name so that they can be
+	// restored, so this includes names that FindBridge does not find.
+	ErrBridgeExists = errors.New("bridge already exists")
+)
+
+type notFoundError string
+
+func (e notFoundError) Error() string { return string(e) }
+func (e notFoundError) Unwrap() error { return sql.ErrNoRows }
+func (e notFoundError) Cause() error  { return sql.ErrNoRows }
+
+// JobFinder finds the jobs whose pipelines call a bridge. It is implemented by
+// the job ORM, which parses the pipelines and can't be imported here.
+type JobFinder interface {
+	FindJobIDsWithBridge(name string) ([]int32, error)
 }
 
 type orm struct {
 	db     *sqlx.DB
+	jobs   JobFinder
 	logger logger.Logger
 }
 
 var _ ORM = (*orm)(nil)
 
-func NewORM(db *sqlx.DB, lggr logger.Logger) ORM {
-	return &orm{db, lggr.Named("BridgeORM")}
+// NewORM returns a bridge ORM which checks with jobs whether a bridge is still
+// in use before deleting it. jobs may be nil if bridges are only ever force
+// deleted.
+func NewORM(db *sqlx.DB, jobs JobFinder, lggr logger.Logger) ORM {
+	return &orm{db, jobs, lggr.Named("BridgeORM")}
 }
 
-// FindBridge looks up a Bridge by its Name.
+// FindBridge looks up a Bridge by its Name. Archived bridges are ignored.
+// ErrBridgeNotFound is returned if there is no such bridge.
 func (o *orm) FindBridge(name TaskType) (bt BridgeType, err error) {
-	sql := "SELECT * FROM bridge_types WHERE name = $1"
-	err = postgres.NewQ(o.db).Get(&bt, sql, name.String())
+	return o.FindBridgeCtx(context.Background(), name)
+}
+
+// FindBridgeCtx is FindBridge, cancelled along with ctx.
+func (o *orm) FindBridgeCtx(ctx context.Context, name TaskType) (bt BridgeType, err error) {
+	stmt := "SELECT * FROM bridge_types WHERE name = $1 AND deleted_at IS NULL"
+	err = postgres.NewQ(o.db, postgres.WithParentCtx(ctx)).Get(&bt, stmt, name.String())
+	if errors.Is(err, sql.ErrNoRows) {
+		err = ErrBridgeNotFound
+	}
 	return
 }
 
-// DeleteBridgeType removes the bridge type
-func (o *orm) DeleteBridgeType(bt *BridgeType) error {
-	query := "DELETE FROM bridge_types WHERE name = $1"
-	result, err := postgres.NewQ(o.db).Exec(query, bt.Name)
+// FindBridges looks up multiple bridges in a single query, returning them
+// keyed by name. Names which do not match any bridge are omitted from the
+// result rather than causing an error.
+func (o *orm) FindBridges(names []TaskType) (bts map[TaskType]BridgeType, err error) {
+	strs := make([]string, len(names))
+	for i, name := range names {
+		strs[i] = name.String()
+	}
+
+	var bridges []BridgeType
+	sql := "SELECT * FROM bridge_types WHERE name = ANY($1) AND deleted_at IS NULL"
+	if err = postgres.NewQ(o.db).Select(&bridges, sql, pq.Array(strs)); err != nil {
+		return nil, errors.Wrap(err, "FindBridges failed")
+	}
+
+	bts = make(map[TaskType]BridgeType, len(bridges))
+	for _, bt := range bridges {
+		bts[bt.Name] = bt
+	}
+	return bts, nil
+}
+
+// BridgeInUseError is returned when deleting a bridge that is still
+// referenced by the pipeline of one or more jobs.
+type BridgeInUseError struct {
+	Name   TaskType
+	JobIDs []int32
+}
+
+func (e BridgeInUseError) Error() string {
+	return fmt.Sprintf("bridge %s is referenced by jobs %v", e.Name, e.JobIDs)
+}
+
+// DeleteBridgeType removes the bridge type. Unless force is set, it refuses
+// with a BridgeInUseError if any job pipeline still has a bridge task using
+// it, since deleting it would break those jobs.
+func (o *orm) DeleteBridgeType(bt *BridgeType, force bool) error {
+	if !force {
+		if o.jobs == nil {
+			return errors.Errorf("cannot check whether bridge %s is in use", bt.Name)
+		}
+		jobIDs, err := o.jobs.FindJobIDsWithBridge(bt.Name.String())
+		if err != nil {
+			return err
+		}
+		if len(jobIDs) > 0 {
+			return BridgeInUseError{Name: bt.Name, JobIDs: jobIDs}
+		}
+	}
+
+	result, err := postgres.NewQ(o.db).Exec("DELETE FROM bridge_types WHERE name = $1", bt.Name)
 	if err != nil {
 		return err
 	}
@@ -58,25 +162,136 @@ func (o *orm) DeleteBridgeType(bt *BridgeType) error {
 	if rowsAffected == 0 {
 		return sql.ErrNoRows
 	}
-	return err
+	return nil
+}
+
+// ArchiveBridgeType marks the bridge type as deleted without removing it, so
+// that it can later be brought back with RestoreBridgeType. It returns
+// ErrBridgeNotFound if there is no such bridge left to archive.
+func (o *orm) ArchiveBridgeType(name TaskType) error {
+	query := "UPDATE bridge_types SET deleted_at = now() WHERE name = $1 AND deleted_at IS NULL"
+	return o.setBridgeTypeDeletedAt(query, name)
 }
 
-// BridgeTypes returns bridge types ordered by name filtered limited by the
-// passed params.
-func (o *orm) BridgeTypes(offset int, limit int) (bridges []BridgeType, count int, err error) {
-	if err = postgres.NewQ(o.db).Get(&count, "SELECT COUNT(*) FROM bridge_types"); err != nil {
+// RestoreBridgeType unarchives a bridge type previously archived with
+// ArchiveBridgeType. It returns ErrBridgeNotFound if there is no such
+// archived bridge.
+func (o *orm) RestoreBridgeType(name TaskType) error {
+	query := "UPDATE bridge_types SET deleted_at = NULL WHERE name = $1 AND deleted_at IS NOT NULL"
+	return o.setBridgeTypeDeletedAt(query, name)
+}
+
+func (o *orm) setBridgeTypeDeletedAt(query string, name TaskType) error {
+	result, err := postgres.NewQ(o.db).Exec(query, name.String())
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrBridgeNotFound
+	}
+	return nil
+}
+
+// BridgeTypesSortColumn is a column that bridge types can be sorted by
+type BridgeTypesSortColumn string
+
+const (
+	BridgeTypesSortName                   BridgeTypesSortColumn = "name"
+	BridgeTypesSortCreatedAt              BridgeTypesSortColumn = "created_at"
+	BridgeTypesSortMinimumContractPayment BridgeTypesSortColumn = "minimum_contract_payment"
+)
+
+// bridgeTypesSortExprs maps the allowed sort columns to the expressions they
+// are sorted by. minimum_contract_payment is stored as a string, so it must
+// be cast to sort numerically.
+var bridgeTypesSortExprs = map[BridgeTypesSortColumn]string{
+	BridgeTypesSortName:                   "name",
+	BridgeTypesSortCreatedAt:              "created_at",
+	BridgeTypesSortMinimumContractPayment: "minimum_contract_payment::numeric",
+}
+
+// BridgeTypesSort describes the order of a BridgeTypes listing. The zero
+// value sorts by name, ascending.
+type BridgeTypesSort struct {
+	Column     BridgeTypesSortColumn
+	Descending bool
+}
+
+// orderBy returns the ORDER BY clause for the sort, falling back to the name
+// to keep the order stable when the sort column has duplicates.
+func (s BridgeTypesSort) orderBy() (string, error) {
+	column := s.Column
+	if column == "" {
+		column = BridgeTypesSortName
+	}
+	expr, ok := bridgeTypesSortExprs[column]
+	if !ok {
+		return "", errors.Errorf("cannot sort bridges by %q", column)
+	}
+	direction := "ASC"
+	if s.Descending {
+		direction = "DESC"
+	}
+	if column == BridgeTypesSortName {
+		return fmt.Sprintf("ORDER BY %s %s", expr, direction), nil
+	}
+	return fmt.Sprintf("ORDER BY %s %s, name ASC", expr, direction), nil
+}
+
+// BridgeTypes returns bridge types in the given order, filtered and limited by
+// the passed params. Archived bridges are excluded.
+func (o *orm) BridgeTypes(offset int, limit int, sort BridgeTypesSort) (bridges []BridgeType, count int, err error) {
+	return o.BridgeTypesCtx(context.Background(), offset, limit, sort)
+}
+
+// BridgeTypesCtx is BridgeTypes, cancelled along with ctx.
+func (o *orm) BridgeTypesCtx(ctx context.Context, offset int, limit int, sort BridgeTypesSort) (bridges []BridgeType, count int, err error) {
+	orderBy, err := sort.orderBy()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	q := postgres.NewQ(o.db, postgres.WithParentCtx(ctx))
+	if err = q.Get(&count, "SELECT COUNT(*) FROM bridge_types WHERE deleted_at IS NULL"); err != nil {
+		return
+	}
+
+	/* #nosec G201 */
+	sql := fmt.Sprintf(`SELECT * FROM bridge_types WHERE deleted_at IS NULL %s LIMIT $1 OFFSET $2;`, orderBy)
+	if err = q.Select(&bridges, sql, limit, offset); err != nil {
 		return
 	}
 
-	sql := `SELECT * FROM bridge_types ORDER BY name asc LIMIT $1 OFFSET $2;`
-	if err = o.db.Select(&bridges, sql, limit, offset); err != nil {
+	return
+}
+
+// BridgeTypesAfter returns up to limit bridge types ordered by name, starting
+// after the bridge with the given name, along with the total number of bridge
+// types. An empty name starts from the first bridge. Unlike BridgeTypes, pages
+// do not shift when bridges are created or deleted while paging. Archived
+// bridges are excluded.
+func (o *orm) BridgeTypesAfter(after TaskType, limit int) (bridges []BridgeType, count int, err error) {
+	if err = postgres.NewQ(o.db).Get(&count, "SELECT COUNT(*) FROM bridge_types WHERE deleted_at IS NULL"); err != nil {
 		return
 	}
 
+	sql := `SELECT * FROM bridge_types WHERE deleted_at IS NULL AND name > $1 ORDER BY name asc LIMIT $2;`
+	err = postgres.NewQ(o.db).Select(&bridges, sql, after.String(), limit)
 	return
 }
 
-// CreateBridgeType saves the bridge type.
+// CountBridgeTypes returns the number of bridge types, excluding archived ones.
+func (o *orm) CountBridgeTypes() (count int, err error) {
+	err = postgres.NewQ(o.db).Get(&count, "SELECT COUNT(*) FROM bridge_types WHERE deleted_at IS NULL")
+	return
+}
+
+// CreateBridgeType saves the bridge type. It returns ErrBridgeExists if the
+// name is taken, even by an archived bridge.
 func (o *orm) CreateBridgeType(bt *BridgeType) error {
 	stmt := `INSERT INTO bridge_types (name, url, confirmations, incoming_token_hash, salt, outgoing_token, minimum_contract_payment, created_at, updated_at)
 	VALUES (:name, :url, :confirmations, :incoming_token_hash, :salt, :outgoing_token, :minimum_contract_payment, now(), now())
@@ -88,6 +303,9 @@ func (o *orm) CreateBridgeType(bt *BridgeType) error {
 		}
 		return stmt.Get(bt, bt)
 	})
+	if postgres.ClassifyError(err) == postgres.UniqueViolation {
+		err = ErrBridgeExists
+	}
 	return errors.Wrap(err, "CreateBridgeType failed")
 }
 
@@ -115,6 +333,43 @@ func (o *orm) ExternalInitiators(offset int, limit int) (exis []ExternalInitiato
 	return
 }
 
+// ExternalInitiatorsWithLinks returns a page of external initiators, ordered
+// by name, each with the IDs of the webhook specs it is linked to and of the
+// jobs using those specs. Pagination applies to the external initiators.
+func (o *orm) ExternalInitiatorsWithLinks(offset int, limit int) (exis []ExternalInitiatorWithJobs, count int, err error) {
+	err = postgres.NewQ(o.db).Transaction(o.logger, func(q postgres.Queryer) error {
+		if err = q.Get(&count, "SELECT COUNT(*) FROM external_initiators"); err != nil {
+			return errors.Wrap(err, "ExternalInitiatorsWithLinks failed to get count")
+		}
+
+		query := `SELECT external_initiators.*,
+			ARRAY(
+				SELECT jobs.id FROM external_initiator_webhook_specs eiws
+				JOIN jobs ON jobs.webhook_spec_id = eiws.webhook_spec_id
+				WHERE eiws.external_initiator_id = external_initiators.id
+				ORDER BY jobs.id
+			) AS job_ids,
+			ARRAY(
+				SELECT eiws.webhook_spec_id FROM external_initiator_webhook_specs eiws
+				WHERE eiws.external_initiator_id = external_initiators.id
+				ORDER BY eiws.webhook_spec_id
+			) AS webhook_spec_ids
+		FROM external_initiators
+		ORDER BY name ASC LIMIT $1 OFFSET $2`
+		if err = q.Select(&exis, query, limit, offset); err != nil {
+			return errors.Wrap(err, "ExternalInitiatorsWithLinks failed to load external_initiators")
+		}
+		return nil
+	}, postgres.OptReadOnlyTx())
+	return
+}
+
+// CountExternalInitiators returns the number of external initiators
+func (o *orm) CountExternalInitiators() (count int, err error) {
+	err = postgres.NewQ(o.db).Get(&count, "SELECT COUNT(*) FROM external_initiators")
+	return
+}
+
 // CreateExternalInitiator inserts a new external initiator
 func (o *orm) CreateExternalInitiator(externalInitiator *ExternalInitiator) (err error) {
 	query := `INSERT INTO external_initiators (name, url, access_key, salt, hashed_secret, outgoing_secret, outgoing_token, created_at, updated_at)
@@ -132,6 +387,27 @@ func (o *orm) CreateExternalInitiator(externalInitiator *ExternalInitiator) (err
 	return errors.Wrap(err, "CreateExternalInitiator failed")
 }
 
+// UpsertExternalInitiator creates the external initiator, or if one with the
+// same (case-insensitive) name already exists, updates its URL. The secrets of
+// an existing external initiator are left untouched. externalInitiator is
+// updated with the stored row, and created reports whether it was inserted.
+func (o *orm) UpsertExternalInitiator(externalInitiator *ExternalInitiator) (created bool, err error) {
+	query := `INSERT INTO external_initiators (name, url, access_key, salt, hashed_secret, outgoing_secret, outgoing_token, created_at, updated_at)
+	VALUES (:name, :url, :access_key, :salt, :hashed_secret, :outgoing_secret, :outgoing_token, now(), now())
+	ON CONFLICT (lower(name)) DO UPDATE SET url = EXCLUDED.url, updated_at = now()
+	RETURNING *, xmax = 0 AS created
+	`
+	var row struct {
+		ExternalInitiator
+		Created bool
+	}
+	if err = postgres.NewQ(o.db).GetNamed(query, &row, externalInitiator); err != nil {
+		return false, errors.Wrap(err, "UpsertExternalInitiator failed")
+	}
+	*externalInitiator = row.ExternalInitiator
+	return row.Created, nil
+}
+
 // DeleteExternalInitiator removes an external initiator
 func (o *orm) DeleteExternalInitiator(name string) error {
 	query := "DELETE FROM external_initiators WHERE name = $1"
@@ -161,8 +437,65 @@ func (o *orm) FindExternalInitiator(
 	return exi, err
 }
 
-// FindExternalInitiatorByName finds an external initiator given an authentication request
+// AuthenticateExternalInitiator finds the external initiator with the token's
+// access key and checks the token's secret against it. An unknown access key
+// and a wrong secret both return auth.ErrorAuthFailed, so that valid access
+// keys can't be enumerated.
+func (o *orm) AuthenticateExternalInitiator(eia *auth.Token) (*ExternalInitiator, error) {
+	exi, err := o.FindExternalInitiator(eia)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, auth.ErrorAuthFailed
+	} else if err != nil {
+		return nil, errors.Wrap(err, "AuthenticateExternalInitiator failed")
+	}
+	ok, err := AuthenticateExternalInitiator(eia, exi)
+	if err != nil {
+		return nil, errors.Wrap(err, "AuthenticateExternalInitiator failed")
+	}
+	if !ok {
+		return nil, auth.ErrorAuthFailed
+	}
+	return exi, nil
+}
+
+// FindExternalInitiatorByName finds an external initiator by its
+// case-insensitive name, returning ErrExternalInitiatorNotFound if there is
+// no such external initiator.
 func (o *orm) FindExternalInitiatorByName(iname string) (exi ExternalInitiator, err error) {
-	err = postgres.NewQ(o.db).Get(&exi, `SELECT * FROM external_initiators WHERE lower(name) = lower($1)`, iname)
+	return o.FindExternalInitiatorByNameCtx(context.Background(), iname)
+}
+
+// FindExternalInitiatorByNameCtx is FindExternalInitiatorByName, cancelled
+// along with ctx.
+func (o *orm) FindExternalInitiatorByNameCtx(ctx context.Context, iname string) (exi ExternalInitiator, err error) {
+	err = postgres.NewQ(o.db, postgres.WithParentCtx(ctx)).Get(&exi, `SELECT * FROM external_initiators WHERE lower(name) = lower($1)`, iname)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = ErrExternalInitiatorNotFound
+	}
 	return
 }
+
+// RotateExternalInitiatorSecrets regenerates the access key, secret and
+// outgoing credentials of the named external initiator, leaving its name and
+// URL untouched. The returned token holds the new plaintext incoming
+// credentials; it is not persisted and cannot be recovered later. It returns
+// ErrExternalInitiatorNotFound if there is no such external initiator.
+func (o *orm) RotateExternalInitiatorSecrets(name string) (*ExternalInitiator, *auth.Token, error) {
+	eia := auth.NewToken()
+	exi, err := NewExternalInitiator(eia, &ExternalInitiatorRequest{Name: name})
+	if err != nil {
+		return nil, nil, err
+	}
+	query := `UPDATE external_initiators
+	SET access_key = $1, salt = $2, hashed_secret = $3, outgoing_secret = $4, outgoing_token = $5, updated_at = now()
+	WHERE lower(name) = lower($6)
+	RETURNING *`
+	err = postgres.NewQ(o.db).Get(exi, query, exi.AccessKey, exi.Salt, exi.HashedSecret, exi.OutgoingSecret, exi.OutgoingToken, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = ErrExternalInitiatorNotFound
+	}
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "RotateExternalInitiatorSecrets failed")
+	}
+	return exi, eia, nil
+}
@@ -1,24 +1,31 @@
 package bridges_test
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/smartcontractkit/sqlx"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/auth"
 	"github.com/smartcontractkit/chainlink/core/bridges"
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/internal/testutils/pgtest"
 	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/job"
 )
 
 func setupORM(t *testing.T) (*sqlx.DB, bridges.ORM) {
 	t.Helper()
 
 	db := pgtest.NewSqlxDB(t)
-	orm := bridges.NewORM(db, logger.TestLogger(t))
+	lggr := logger.TestLogger(t)
+	orm := bridges.NewORM(db, job.NewORM(db, nil, nil, nil, lggr), lggr)
 
 	return db, orm
 }
@@ -58,6 +65,136 @@ func TestORM_FindBridge(t *testing.T) {
 		})
 	}
 }
+
+func TestORM_FindBridge_NotFound(t *testing.T) {
+	t.Parallel()
+
+	_, orm := setupORM(t)
+
+	_, err := orm.FindBridge("nonexistent")
+	require.True(t, errors.Is(err, bridges.ErrBridgeNotFound))
+	require.True(t, errors.Is(err, sql.ErrNoRows))
+}
+
+func TestORM_FindBridges(t *testing.T) {
+	t.Parallel()
+
+	_, orm := setupORM(t)
+
+	bt1 := bridges.BridgeType{Name: bridges.MustNewTaskType("bridgea"), URL: cltest.WebURL(t, "https://bridgea.com")}
+	require.NoError(t, orm.CreateBridgeType(&bt1))
+	bt2 := bridges.BridgeType{Name: bridges.MustNewTaskType("bridgeb"), URL: cltest.WebURL(t, "https://bridgeb.com")}
+	require.NoError(t, orm.CreateBridgeType(&bt2))
+
+	found, err := orm.FindBridges([]bridges.TaskType{bt1.Name, "nonexistent", bt2.Name, "ethtx"})
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	assert.Equal(t, bt1.URL, found[bt1.Name].URL)
+	assert.Equal(t, bt2.URL, found[bt2.Name].URL)
+	assert.NotContains(t, found, bridges.TaskType("nonexistent"))
+
+	found, err = orm.FindBridges(nil)
+	require.NoError(t, err)
+	assert.Len(t, found, 0)
+}
+
+func TestORM_ArchiveBridgeType(t *testing.T) {
+	t.Parallel()
+
+	_, orm := setupORM(t)
+
+	bt := bridges.BridgeType{Name: bridges.MustNewTaskType("archiveme"), URL: cltest.WebURL(t, "https://archive.me")}
+	require.NoError(t, orm.CreateBridgeType(&bt))
+
+	require.NoError(t, orm.ArchiveBridgeType(bt.Name))
+
+	_, err := orm.FindBridge(bt.Name)
+	require.Equal(t, sql.ErrNoRows, errors.Cause(err))
+	bts, count, err := orm.BridgeTypes(0, 10, bridges.BridgeTypesSort{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.Len(t, bts, 0)
+
+	// archiving twice finds nothing left to archive
+	require.Equal(t, bridges.ErrBridgeNotFound, orm.ArchiveBridgeType(bt.Name))
+
+	require.NoError(t, orm.RestoreBridgeType(bt.Name))
+
+	found, err := orm.FindBridge(bt.Name)
+	require.NoError(t, err)
+	assert.Nil(t, found.DeletedAt)
+	bts, count, err = orm.BridgeTypes(0, 10, bridges.BridgeTypesSort{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	require.Len(t, bts, 1)
+	assert.Equal(t, bt.Name, bts[0].Name)
+
+	require.Equal(t, bridges.ErrBridgeNotFound, orm.ArchiveBridgeType("nonexistent"))
+	require.Equal(t, bridges.ErrBridgeNotFound, orm.RestoreBridgeType("nonexistent"))
+	require.Equal(t, bridges.ErrBridgeNotFound, orm.RestoreBridgeType(bt.Name))
+
+	// archived bridges keep their name. The failed insert aborts the test
+	// transaction, so this must come last.
+	require.NoError(t, orm.ArchiveBridgeType(bt.Name))
+	err = orm.CreateBridgeType(&bridges.BridgeType{Name: bt.Name, URL: cltest.WebURL(t, "https://archive.me")})
+	require.True(t, errors.Is(err, bridges.ErrBridgeExists))
+}
+
+func TestORM_DeleteBridgeType_Referenced(t *testing.T) {
+	t.Parallel()
+
+	db, orm := setupORM(t)
+
+	referenced := bridges.BridgeType{Name: bridges.MustNewTaskType("referenced"), URL: cltest.WebURL(t, "https://referenced.com")}
+	require.NoError(t, orm.CreateBridgeType(&referenced))
+	unreferenced := bridges.BridgeType{Name: bridges.MustNewTaskType("unreferenced"), URL: cltest.WebURL(t, "https://unreferenced.com")}
+	require.NoError(t, orm.CreateBridgeType(&unreferenced))
+
+	// The second job only mentions the bridge name outside of a bridge task,
+	// and the fourth capitalizes the task type, which pipeline parsing accepts
+	jb1 := cltest.MustInsertV2JobSpec(t, db, cltest.NewAddress())
+	jb2 := cltest.MustInsertV2JobSpec(t, db, cltest.NewAddress())
+	jb3 := cltest.MustInsertV2JobSpec(t, db, cltest.NewAddress())
+	jb4 := cltest.MustInsertV2JobSpec(t, db, cltest.NewAddress())
+	setDAG := func(pipelineSpecID int32, dag string) {
+		_, err := db.Exec(`UPDATE pipeline_specs SET dot_dag_source = $1 WHERE id = $2`, dag, pipelineSpecID)
+		require.NoError(t, err)
+	}
+	setDAG(jb1.PipelineSpecID, `fetch [type=bridge name="referenced" requestData="{}"]`)
+	setDAG(jb2.PipelineSpecID, `fetch [type=http method=GET url="https://referenced.com"]`)
+	setDAG(jb3.PipelineSpecID, `
+fetch [type=http method=GET url="https://example.com"]
+submit [type=bridge name=referenced]
+fetch -> submit
+`)
+	setDAG(jb4.PipelineSpecID, `fetch [type=Bridge name="referenced"]`)
+
+	t.Run("deletes an unreferenced bridge", func(t *testing.T) {
+		require.NoError(t, orm.DeleteBridgeType(&unreferenced, false))
+		_, err := orm.FindBridge(unreferenced.Name)
+		require.True(t, errors.Is(err, bridges.ErrBridgeNotFound))
+	})
+
+	t.Run("refuses to delete a referenced bridge", func(t *testing.T) {
+		err := orm.DeleteBridgeType(&referenced, false)
+		require.Error(t, err)
+		var inUse bridges.BridgeInUseError
+		require.True(t, errors.As(err, &inUse))
+		assert.Equal(t, referenced.Name, inUse.Name)
+		assert.Equal(t, []int32{jb1.ID, jb3.ID, jb4.ID}, inUse.JobIDs)
+		assert.Contains(t, err.Error(), fmt.Sprintf("%v", inUse.JobIDs))
+
+		_, err = orm.FindBridge(referenced.Name)
+		require.NoError(t, err)
+	})
+
+	t.Run("force deletes a referenced bridge", func(t *testing.T) {
+		require.NoError(t, orm.DeleteBridgeType(&referenced, true))
+		_, err := orm.FindBridge(referenced.Name)
+		require.True(t, errors.Is(err, bridges.ErrBridgeNotFound))
+	})
+}
+
 func TestORM_UpdateBridgeType(t *testing.T) {
 	_, orm := setupORM(t)
 
@@ -79,6 +216,197 @@ func TestORM_UpdateBridgeType(t *testing.T) {
 	require.Equal(t, updateBridge.URL, foundbridge.URL)
 }
 
+func TestORM_CountBridgeTypes(t *testing.T) {
+	t.Parallel()
+
+	_, orm := setupORM(t)
+
+	count, err := orm.CountBridgeTypes()
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	var bts []bridges.BridgeType
+	for _, name := range []string{"bridgea", "bridgeb", "bridgec"} {
+		bt := bridges.BridgeType{Name: bridges.MustNewTaskType(name), URL: cltest.WebURL(t, "https://"+name+".com")}
+		require.NoError(t, orm.CreateBridgeType(&bt))
+		bts = append(bts, bt)
+	}
+
+	count, err = orm.CountBridgeTypes()
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	require.NoError(t, orm.DeleteBridgeType(&bts[0], false))
+	require.NoError(t, orm.ArchiveBridgeType(bts[1].Name))
+
+	count, err = orm.CountBridgeTypes()
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestORM_BridgeTypes_Sort(t *testing.T) {
+	t.Parallel()
+
+	db, orm := setupORM(t)
+
+	// created_at and minimum_contract_payment are deliberately not in name order
+	for _, b := range []struct {
+		name      string
+		createdAt string
+		payment   int64
+	}{
+		{"bridgea", "2021-01-02", 100},
+		{"bridgeb", "2021-01-03", 9},
+		{"bridgec", "2021-01-01", 20},
+	} {
+		bt := bridges.BridgeType{
+			Name:                   bridges.MustNewTaskType(b.name),
+			URL:                    cltest.WebURL(t, "https://"+b.name+".com"),
+			MinimumContractPayment: assets.NewLinkFromJuels(b.payment),
+		}
+		require.NoError(t, orm.CreateBridgeType(&bt))
+		_, err := db.Exec(`UPDATE bridge_types SET created_at = $1 WHERE name = $2`, b.createdAt, b.name)
+		require.NoError(t, err)
+	}
+
+	names := func(bts []bridges.BridgeType) (names []string) {
+		for _, bt := range bts {
+			names = append(names, bt.Name.String())
+		}
+		return
+	}
+
+	cases := []struct {
+		sort bridges.BridgeTypesSort
+		want []string
+	}{
+		{bridges.BridgeTypesSort{}, []string{"bridgea", "bridgeb", "bridgec"}},
+		{bridges.BridgeTypesSort{Column: bridges.BridgeTypesSortName}, []string{"bridgea", "bridgeb", "bridgec"}},
+		{bridges.BridgeTypesSort{Column: bridges.BridgeTypesSortName, Descending: true}, []string{"bridgec", "bridgeb", "bridgea"}},
+		{bridges.BridgeTypesSort{Column: bridges.BridgeTypesSortCreatedAt}, []string{"bridgec", "bridgea", "bridgeb"}},
+		{bridges.BridgeTypesSort{Column: bridges.BridgeTypesSortCreatedAt, Descending: true}, []string{"bridgeb", "bridgea", "bridgec"}},
+		{bridges.BridgeTypesSort{Column: bridges.BridgeTypesSortMinimumContractPayment}, []string{"bridgeb", "bridgec", "bridgea"}},
+		{bridges.BridgeTypesSort{Column: bridges.BridgeTypesSortMinimumContractPayment, Descending: true}, []string{"bridgea", "bridgec", "bridgeb"}},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(fmt.Sprintf("%s descending=%t", tc.sort.Column, tc.sort.Descending), func(t *testing.T) {
+			bts, count, err := orm.BridgeTypes(0, 10, tc.sort)
+			require.NoError(t, err)
+			assert.Equal(t, 3, count)
+			assert.Equal(t, tc.want, names(bts))
+		})
+	}
+
+	t.Run("rejects unknown columns", func(t *testing.T) {
+		_, _, err := orm.BridgeTypes(0, 10, bridges.BridgeTypesSort{Column: "incoming_token_hash; DROP TABLE bridge_types"})
+		require.EqualError(t, err, `cannot sort bridges by "incoming_token_hash; DROP TABLE bridge_types"`)
+	})
+}
+
+func TestORM_BridgeTypesAfter(t *testing.T) {
+	t.Parallel()
+
+	_, orm := setupORM(t)
+
+	for _, name := range []string{"bridgec", "bridgea", "bridgeb", "bridged"} {
+		bt := bridges.BridgeType{Name: bridges.MustNewTaskType(name), URL: cltest.WebURL(t, "https://"+name+".com")}
+		require.NoError(t, orm.CreateBridgeType(&bt))
+	}
+	require.NoError(t, orm.ArchiveBridgeType("bridged"))
+
+	bts, count, err := orm.BridgeTypesAfter("", 2)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	require.Len(t, bts, 2)
+	assert.Equal(t, bridges.TaskType("bridgea"), bts[0].Name)
+	assert.Equal(t, bridges.TaskType("bridgeb"), bts[1].Name)
+
+	bts, count, err = orm.BridgeTypesAfter(bts[1].Name, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	require.Len(t, bts, 1)
+	assert.Equal(t, bridges.TaskType("bridgec"), bts[0].Name)
+
+	bts, _, err = orm.BridgeTypesAfter(bts[0].Name, 2)
+	require.NoError(t, err)
+	assert.Len(t, bts, 0)
+}
+
+func TestORM_CountExternalInitiators(t *testing.T) {
+	t.Parallel()
+
+	_, orm := setupORM(t)
+
+	count, err := orm.CountExternalInitiators()
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	for _, name := range []string{"eia", "eib", "eic"} {
+		exi, err := bridges.NewExternalInitiator(auth.NewToken(), &bridges.ExternalInitiatorRequest{Name: name})
+		require.NoError(t, err)
+		require.NoError(t, orm.CreateExternalInitiator(exi))
+	}
+
+	count, err = orm.CountExternalInitiators()
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	require.NoError(t, orm.DeleteExternalInitiator("eib"))
+
+	count, err = orm.CountExternalInitiators()
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func TestORM_ExternalInitiatorsWithLinks(t *testing.T) {
+	t.Parallel()
+
+	db, orm := setupORM(t)
+
+	eiFoo := cltest.MustInsertExternalInitiatorWithOpts(t, orm, cltest.ExternalInitiatorOpts{NamePrefix: "a-foo"})
+	eiBar := cltest.MustInsertExternalInitiatorWithOpts(t, orm, cltest.ExternalInitiatorOpts{NamePrefix: "b-bar"})
+	eiNone := cltest.MustInsertExternalInitiatorWithOpts(t, orm, cltest.ExternalInitiatorOpts{NamePrefix: "c-none"})
+
+	jobWithFooAndBarEI, webhookSpecWithFooAndBarEI := cltest.MustInsertWebhookSpec(t, db)
+	jobWithBarEI, webhookSpecWithBarEI := cltest.MustInsertWebhookSpec(t, db)
+	cltest.MustInsertWebhookSpec(t, db)
+
+	link := func(ei bridges.ExternalInitiator, webhookSpecID int32) {
+		_, err := db.Exec(`INSERT INTO external_initiator_webhook_specs (external_initiator_id, webhook_spec_id, spec) VALUES ($1,$2,'{}')`, ei.ID, webhookSpecID)
+		require.NoError(t, err)
+	}
+	link(eiFoo, webhookSpecWithFooAndBarEI.ID)
+	link(eiBar, webhookSpecWithFooAndBarEI.ID)
+	link(eiBar, webhookSpecWithBarEI.ID)
+
+	exis, count, err := orm.ExternalInitiatorsWithLinks(0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	require.Len(t, exis, 3)
+
+	assert.Equal(t, eiFoo.ID, exis[0].ID)
+	assert.Equal(t, []int32{jobWithFooAndBarEI.ID}, []int32(exis[0].JobIDs))
+	assert.Equal(t, []int32{webhookSpecWithFooAndBarEI.ID}, []int32(exis[0].WebhookSpecIDs))
+
+	assert.Equal(t, eiBar.ID, exis[1].ID)
+	assert.ElementsMatch(t, []int32{jobWithFooAndBarEI.ID, jobWithBarEI.ID}, []int32(exis[1].JobIDs))
+	assert.ElementsMatch(t, []int32{webhookSpecWithFooAndBarEI.ID, webhookSpecWithBarEI.ID}, []int32(exis[1].WebhookSpecIDs))
+
+	assert.Equal(t, eiNone.ID, exis[2].ID)
+	assert.Empty(t, exis[2].JobIDs)
+	assert.Empty(t, exis[2].WebhookSpecIDs)
+
+	// Pages are made up of external initiators, regardless of their number of links
+	page, count, err := orm.ExternalInitiatorsWithLinks(1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	require.Len(t, page, 1)
+	assert.Equal(t, eiBar.ID, page[0].ID)
+	assert.Len(t, page[0].JobIDs, 2)
+}
+
 func TestORM_CreateExternalInitiator(t *testing.T) {
 	_, orm := setupORM(t)
 
@@ -95,6 +423,147 @@ func TestORM_CreateExternalInitiator(t *testing.T) {
 	require.Contains(t, orm.CreateExternalInitiator(exi2).Error(), `ERROR: duplicate key value violates unique constraint "external_initiators_name_key" (SQLSTATE 23505)`)
 }
 
+func TestORM_UpsertExternalInitiator(t *testing.T) {
+	t.Parallel()
+
+	_, orm := setupORM(t)
+
+	firstURL := cltest.WebURL(t, "https://first.example.com")
+	secondURL := cltest.WebURL(t, "https://second.example.com")
+
+	token := auth.NewToken()
+	exi, err := bridges.NewExternalInitiator(token, &bridges.ExternalInitiatorRequest{
+		Name: "upsertme",
+		URL:  &firstURL,
+	})
+	require.NoError(t, err)
+
+	t.Run("inserts a new external initiator", func(t *testing.T) {
+		created, err := orm.UpsertExternalInitiator(exi)
+		require.NoError(t, err)
+		assert.True(t, created)
+		assert.NotZero(t, exi.ID)
+
+		found, err := orm.FindExternalInitiator(token)
+		require.NoError(t, err)
+		assert.Equal(t, "https://first.example.com", found.URL.String())
+	})
+
+	t.Run("updates the url of an existing external initiator without rotating secrets", func(t *testing.T) {
+		before, err := orm.FindExternalInitiatorByName("upsertme")
+		require.NoError(t, err)
+
+		exi2, err := bridges.NewExternalInitiator(auth.NewToken(), &bridges.ExternalInitiatorRequest{
+			Name: "UpsertMe",
+			URL:  &secondURL,
+		})
+		require.NoError(t, err)
+		created, err := orm.UpsertExternalInitiator(exi2)
+		require.NoError(t, err)
+		assert.False(t, created)
+		assert.Equal(t, before.ID, exi2.ID)
+
+		after, err := orm.FindExternalInitiatorByName("upsertme")
+		require.NoError(t, err)
+		assert.Equal(t, "https://second.example.com", after.URL.String())
+		assert.Equal(t, before.Name, after.Name)
+		assert.Equal(t, before.AccessKey, after.AccessKey)
+		assert.Equal(t, before.Salt, after.Salt)
+		assert.Equal(t, before.HashedSecret, after.HashedSecret)
+		assert.Equal(t, before.OutgoingSecret, after.OutgoingSecret)
+		assert.Equal(t, before.OutgoingToken, after.OutgoingToken)
+
+		// The original credentials still authenticate
+		_, err = orm.FindExternalInitiator(token)
+		require.NoError(t, err)
+
+		count, err := orm.CountExternalInitiators()
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("CreateExternalInitiator still rejects duplicates", func(t *testing.T) {
+		dup, err := bridges.NewExternalInitiator(auth.NewToken(), &bridges.ExternalInitiatorRequest{Name: "upsertme"})
+		require.NoError(t, err)
+		require.Error(t, orm.CreateExternalInitiator(dup))
+	})
+}
+
+func TestORM_AuthenticateExternalInitiator(t *testing.T) {
+	t.Parallel()
+
+	_, orm := setupORM(t)
+
+	token := auth.NewToken()
+	exi, err := bridges.NewExternalInitiator(token, &bridges.ExternalInitiatorRequest{Name: "authme"})
+	require.NoError(t, err)
+	require.NoError(t, orm.CreateExternalInitiator(exi))
+
+	t.Run("correct credentials", func(t *testing.T) {
+		found, err := orm.AuthenticateExternalInitiator(token)
+		require.NoError(t, err)
+		assert.Equal(t, exi.ID, found.ID)
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		_, err := orm.AuthenticateExternalInitiator(&auth.Token{AccessKey: token.AccessKey, Secret: "wrong"})
+		require.Equal(t, auth.ErrorAuthFailed, err)
+	})
+
+	t.Run("unknown access key", func(t *testing.T) {
+		_, err := orm.AuthenticateExternalInitiator(&auth.Token{AccessKey: "unknown", Secret: token.Secret})
+		require.Equal(t, auth.ErrorAuthFailed, err)
+	})
+}
+
+func TestORM_FindExternalInitiatorByName(t *testing.T) {
+	t.Parallel()
+
+	_, orm := setupORM(t)
+	exi, err := bridges.NewExternalInitiator(auth.NewToken(), &bridges.ExternalInitiatorRequest{Name: "findme"})
+	require.NoError(t, err)
+	require.NoError(t, orm.CreateExternalInitiator(exi))
+
+	found, err := orm.FindExternalInitiatorByName("FindMe")
+	require.NoError(t, err)
+	assert.Equal(t, exi.ID, found.ID)
+
+	_, err = orm.FindExternalInitiatorByName("nonexistent")
+	require.True(t, errors.Is(err, bridges.ErrExternalInitiatorNotFound))
+	require.True(t, errors.Is(err, sql.ErrNoRows))
+}
+
+func TestORM_CancelledContext(t *testing.T) {
+	t.Parallel()
+
+	db, orm := setupORM(t)
+	_, bt := cltest.MustCreateBridge(t, db, cltest.BridgeOpts{})
+	exi, err := bridges.NewExternalInitiator(auth.NewToken(), &bridges.ExternalInitiatorRequest{Name: "cancelled"})
+	require.NoError(t, err)
+	require.NoError(t, orm.CreateExternalInitiator(exi))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	found, err := orm.FindBridgeCtx(ctx, bt.Name)
+	require.True(t, errors.Is(err, context.Canceled), "expected context.Canceled, got %v", err)
+	assert.Equal(t, bridges.BridgeType{}, found)
+
+	bts, _, err := orm.BridgeTypesCtx(ctx, 0, 10, bridges.BridgeTypesSort{})
+	require.True(t, errors.Is(err, context.Canceled), "expected context.Canceled, got %v", err)
+	assert.Empty(t, bts)
+
+	foundExi, err := orm.FindExternalInitiatorByNameCtx(ctx, exi.Name)
+	require.True(t, errors.Is(err, context.Canceled), "expected context.Canceled, got %v", err)
+	assert.Equal(t, bridges.ExternalInitiator{}, foundExi)
+
+	// the same lookups succeed with a live context
+	_, err = orm.FindBridgeCtx(context.Background(), bt.Name)
+	require.NoError(t, err)
+	_, err = orm.FindExternalInitiatorByNameCtx(context.Background(), exi.Name)
+	require.NoError(t, err)
+}
+
 func TestORM_DeleteExternalInitiator(t *testing.T) {
 	_, orm := setupORM(t)
 
@@ -117,3 +586,39 @@ func TestORM_DeleteExternalInitiator(t *testing.T) {
 
 	require.NoError(t, orm.CreateExternalInitiator(exi))
 }
+
+func TestORM_RotateExternalInitiatorSecrets(t *testing.T) {
+	_, orm := setupORM(t)
+
+	oldToken := auth.NewToken()
+	req := bridges.ExternalInitiatorRequest{
+		Name: "externalinitiator",
+		URL:  cltest.MustWebURL(t, "http://example.com/ei"),
+	}
+	exi, err := bridges.NewExternalInitiator(oldToken, &req)
+	require.NoError(t, err)
+	require.NoError(t, orm.CreateExternalInitiator(exi))
+
+	rotated, newToken, err := orm.RotateExternalInitiatorSecrets(exi.Name)
+	require.NoError(t, err)
+	assert.Equal(t, exi.ID, rotated.ID)
+	assert.Equal(t, exi.Name, rotated.Name)
+	assert.Equal(t, exi.URL, rotated.URL)
+	assert.Equal(t, newToken.AccessKey, rotated.AccessKey)
+	assert.NotEqual(t, exi.AccessKey, rotated.AccessKey)
+	assert.NotEqual(t, exi.OutgoingToken, rotated.OutgoingToken)
+	assert.NotEqual(t, exi.OutgoingSecret, rotated.OutgoingSecret)
+
+	_, err = orm.FindExternalInitiator(oldToken)
+	require.Error(t, err)
+
+	found, err := orm.FindExternalInitiator(newToken)
+	require.NoError(t, err)
+	ok, err := bridges.AuthenticateExternalInitiator(newToken, found)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	_, _, err = orm.RotateExternalInitiatorSecrets("nonexistent")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, bridges.ErrExternalInitiatorNotFound))
+}
@@ -0,0 +1,45 @@
+package bridges
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+)
+
+// URLReachabilityTimeout bounds how long CheckURLReachable waits for a
+// response
+const URLReachabilityTimeout = 5 * time.Second
+
+// CheckURLReachable sends a HEAD request to u and returns an error if no
+// response arrives. Any response counts as reachable whatever its status,
+// since bridges are not required to handle HEAD requests.
+func CheckURLReachable(ctx context.Context, u *url.URL) error {
+	ctx, cancel := context.WithTimeout(ctx, URLReachabilityTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "bridge URL %s is not reachable", u)
+	}
+	return resp.Body.Close()
+}
+
+// WarnIfURLUnreachable checks in the background whether u is reachable,
+// logging a warning if it is not. It never fails the operation that called
+// it, as the bridge may simply not be running yet.
+func WarnIfURLUnreachable(lggr logger.Logger, name TaskType, u *url.URL) {
+	go func() {
+		if err := CheckURLReachable(context.Background(), u); err != nil {
+			lggr.Warnw("Bridge URL is not reachable from this node, bridge tasks using it will fail until it is", "bridge", name, "url", u.String(), "err", err)
+		}
+	}()
+}
@@ -0,0 +1,50 @@
+package bridges_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/bridges"
+)
+
+func TestCheckURLReachable(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reachable", func(t *testing.T) {
+		var method string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			method = r.Method
+			// Bridges need not support HEAD, so any response counts
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}))
+		defer server.Close()
+
+		u, err := url.Parse(server.URL)
+		require.NoError(t, err)
+
+		require.NoError(t, bridges.CheckURLReachable(context.Background(), u))
+		assert.Equal(t, http.MethodHead, method)
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		// Grab a free port and close it again so nothing is listening there
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := l.Addr().String()
+		require.NoError(t, l.Close())
+
+		u, err := url.Parse("http://" + addr)
+		require.NoError(t, err)
+
+		err = bridges.CheckURLReachable(context.Background(), u)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is not reachable")
+	})
+}
@@ -213,6 +213,22 @@ func (_m *ChainScopedConfig) BlockHistoryEstimatorTransactionPercentile() uint16
 	return r0
 }
 
+// BridgeMaxMinimumContractPayment provides a mock function with given fields:
+func (_m *ChainScopedConfig) BridgeMaxMinimumContractPayment() *assets.Link {
+	ret := _m.Called()
+
+	var r0 *assets.Link
+	if rf, ok := ret.Get(0).(func() *assets.Link); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*assets.Link)
+		}
+	}
+
+	return r0
+}
+
 // BridgeResponseURL provides a mock function with given fields:
 func (_m *ChainScopedConfig) BridgeResponseURL() *url.URL {
 	ret := _m.Called()
@@ -229,6 +245,20 @@ func (_m *ChainScopedConfig) BridgeResponseURL() *url.URL {
 	return r0
 }
 
+// BridgeURLReachabilityCheck provides a mock function with given fields:
+func (_m *ChainScopedConfig) BridgeURLReachabilityCheck() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // CertFile provides a mock function with given fields:
 func (_m *ChainScopedConfig) CertFile() string {
 	ret := _m.Called()
@@ -2061,6 +2091,34 @@ func (_m *ChainScopedConfig) GlobalOCRContractConfirmations() (uint16, bool) {
 	return r0, r1
 }
 
+// GraphQLPageDefaultLimit provides a mock function with given fields:
+func (_m *ChainScopedConfig) GraphQLPageDefaultLimit() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// GraphQLPageMaxLimit provides a mock function with given fields:
+func (_m *ChainScopedConfig) GraphQLPageMaxLimit() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
 // HTTPServerWriteTimeout provides a mock function with given fields:
 func (_m *ChainScopedConfig) HTTPServerWriteTimeout() time.Duration {
 	ret := _m.Called()
@@ -2371,6 +2429,34 @@ func (_m *ChainScopedConfig) LogSQLStatements() bool {
 	return r0
 }
 
+// LogSamplingInitial provides a mock function with given fields:
+func (_m *ChainScopedConfig) LogSamplingInitial() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// LogSamplingThereafter provides a mock function with given fields:
+func (_m *ChainScopedConfig) LogSamplingThereafter() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
 // LogToDisk provides a mock function with given fields:
 func (_m *ChainScopedConfig) LogToDisk() bool {
 	ret := _m.Called()
@@ -2737,6 +2823,34 @@ func (_m *ChainScopedConfig) OCRTransmitterAddress() (ethkey.EIP55Address, error
 	return r0, r1
 }
 
+// ORMConnMaxIdleTime provides a mock function with given fields:
+func (_m *ChainScopedConfig) ORMConnMaxIdleTime() time.Duration {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
+// ORMConnMaxLifetime provides a mock function with given fields:
+func (_m *ChainScopedConfig) ORMConnMaxLifetime() time.Duration {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
 // ORMMaxIdleConns provides a mock function with given fields:
 func (_m *ChainScopedConfig) ORMMaxIdleConns() int {
 	ret := _m.Called()
@@ -2932,6 +3046,34 @@ func (_m *ChainScopedConfig) P2PPeerIDRaw() string {
 	return r0
 }
 
+// P2PPeerstoreAddrTTL provides a mock function with given fields:
+func (_m *ChainScopedConfig) P2PPeerstoreAddrTTL() time.Duration {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
+// P2PPeerstoreRetention provides a mock function with given fields:
+func (_m *ChainScopedConfig) P2PPeerstoreRetention() time.Duration {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
 // P2PPeerstoreWriteInterval provides a mock function with given fields:
 func (_m *ChainScopedConfig) P2PPeerstoreWriteInterval() time.Duration {
 	ret := _m.Called()
@@ -3124,6 +3266,27 @@ func (_m *ChainScopedConfig) ReaperExpiration() models.Duration {
 	return r0
 }
 
+// ReloadLogLevel provides a mock function with given fields:
+func (_m *ChainScopedConfig) ReloadLogLevel() (zapcore.Level, error) {
+	ret := _m.Called()
+
+	var r0 zapcore.Level
+	if rf, ok := ret.Get(0).(func() zapcore.Level); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(zapcore.Level)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ReplayFromBlock provides a mock function with given fields:
 func (_m *ChainScopedConfig) ReplayFromBlock() int64 {
 	ret := _m.Called()
@@ -3166,6 +3329,20 @@ func (_m *ChainScopedConfig) SecureCookies() bool {
 	return r0
 }
 
+// SessionAbsoluteTimeout provides a mock function with given fields:
+func (_m *ChainScopedConfig) SessionAbsoluteTimeout() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // SessionOptions provides a mock function with given fields:
 func (_m *ChainScopedConfig) SessionOptions() sessions.Options {
 	ret := _m.Called()
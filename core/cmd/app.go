@@ -1,9 +1,11 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"regexp"
+	"strings"
 
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/static"
@@ -26,15 +28,45 @@ func NewApp(client *Client) *cli.App {
 	app := cli.NewApp()
 	app.Usage = "CLI for Chainlink"
 	app.Version = fmt.Sprintf("%v@%v", static.Version, static.Sha)
+	// Used by the bash and zsh scripts from the completion command
+	app.EnableBashCompletion = true
 	app.Flags = []cli.Flag{
 		cli.BoolFlag{
 			Name:  "json, j",
 			Usage: "json output as opposed to table",
 		},
+		cli.BoolFlag{
+			Name:  "yaml",
+			Usage: "yaml output as opposed to table",
+		},
+		cli.StringFlag{
+			Name:   "profile",
+			Usage:  "name of the remote node profile in $ROOT/" + ProfilesFileName + " to use",
+			EnvVar: EnvProfile,
+		},
+		cli.DurationFlag{
+			Name:  "timeout",
+			Usage: "timeout for each request to the remote node, including re-authentication; 0 disables it",
+			Value: DefaultHTTPTimeout,
+		},
 	}
 	app.Before = func(c *cli.Context) error {
+		if c.Bool("json") && c.Bool("yaml") {
+			return errors.New("--json and --yaml cannot be used together")
+		}
 		if c.Bool("json") {
 			client.Renderer = RendererJSON{Writer: os.Stdout}
+		} else if c.Bool("yaml") {
+			client.Renderer = RendererYAML{Writer: os.Stdout}
+		}
+		timeout := c.Duration("timeout")
+		if timeout < 0 {
+			return fmt.Errorf("--timeout must not be negative, got %s", timeout)
+		}
+		for _, v := range []interface{}{client.HTTP, client.CookieAuthenticator} {
+			if setter, ok := v.(HTTPTimeoutSetter); ok {
+				setter.SetTimeout(timeout)
+			}
 		}
 		logger.InitLogger(client.Logger)
 		return nil
@@ -134,6 +166,13 @@ func NewApp(client *Client) *cli.App {
 			},
 		},
 
+		{
+			Name:      "completion",
+			Usage:     "Print the shell completion script for " + strings.Join(CompletionShells(), ", "),
+			ArgsUsage: "SHELL",
+			Action:    client.Completion,
+		},
+
 		{
 			Name:  "config",
 			Usage: "Commands for the node's configuration",
@@ -143,6 +182,11 @@ func NewApp(client *Client) *cli.App {
 					Usage:  "Show the node's environment variables",
 					Action: client.GetConfiguration,
 				},
+				{
+					Name:   "validate",
+					Usage:  "Validate the local configuration and report any errors, without starting the node",
+					Action: client.ValidateConfig,
+				},
 				{
 					Name:   "setgasprice",
 					Usage:  "Set the default gas price to use for outgoing transactions",
@@ -20,6 +20,7 @@ import (
 	"github.com/Depado/ginprom"
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
+	promclient "github.com/prometheus/client_golang/prometheus"
 	uuid "github.com/satori/go.uuid"
 	clipkg "github.com/urfave/cli"
 	"go.uber.org/multierr"
@@ -36,7 +37,6 @@ import (
 	"github.com/smartcontractkit/chainlink/core/services/versioning"
 	"github.com/smartcontractkit/chainlink/core/services/webhook"
 	"github.com/smartcontractkit/chainlink/core/sessions"
-	"github.com/smartcontractkit/chainlink/core/shutdown"
 	"github.com/smartcontractkit/chainlink/core/static"
 	"github.com/smartcontractkit/chainlink/core/store/migrate"
 	"github.com/smartcontractkit/chainlink/core/utils"
@@ -72,6 +72,18 @@ type Client struct {
 	PromptingSessionRequestBuilder SessionRequestBuilder
 	ChangePasswordPrompter         ChangePasswordPrompter
 	PasswordPrompter               PasswordPrompter
+
+	// RootCtx is cancelled when the CLI is asked to shut down. Long running
+	// commands should return once it is done.
+	RootCtx context.Context
+}
+
+// rootCtx returns RootCtx, or a context that is never cancelled if unset.
+func (cli *Client) rootCtx() context.Context {
+	if cli.RootCtx == nil {
+		return context.Background()
+	}
+	return cli.RootCtx
 }
 
 func (cli *Client) errorOut(err error) error {
@@ -145,11 +157,12 @@ func AdvisoryLock(ctx context.Context, lggr logger.Logger, db *sql.DB, timeout t
 }
 
 // NewApplication returns a new instance of the node with the given config.
+// The application does not listen for OS signals itself; commands stop it
+// once Client.RootCtx is cancelled.
 func (n ChainlinkAppFactory) NewApplication(cfg config.GeneralConfig) (chainlink.Application, error) {
 	appLggr := logger.NewLogger(cfg)
 	appID := uuid.NewV4()
 
-	shutdownSignal := shutdown.NewSignal()
 	uri := cfg.DatabaseURL()
 	static.SetConsumerName(&uri, "App", &appID)
 	dialect := cfg.GetDatabaseDialectConfiguredOrDefault()
@@ -158,11 +171,17 @@ func (n ChainlinkAppFactory) NewApplication(cfg config.GeneralConfig) (chainlink
 		LogSQLStatements: cfg.LogSQLStatements(),
 		MaxOpenConns:     cfg.ORMMaxOpenConns(),
 		MaxIdleConns:     cfg.ORMMaxIdleConns(),
+		ConnMaxLifetime:  cfg.ORMConnMaxLifetime(),
+		ConnMaxIdleTime:  cfg.ORMConnMaxIdleTime(),
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	if err = postgres.ExportPoolStats(db, promclient.DefaultRegisterer); err != nil {
+		appLggr.Warnw("Failed to export database pool stats", "err", err)
+	}
+
 	appLggr.Debugf("Using database locking mode: %s", cfg.DatabaseLockingMode())
 
 	// Lease will be explicitly released on application stop
@@ -186,7 +205,13 @@ func (n ChainlinkAppFactory) NewApplication(cfg config.GeneralConfig) (chainlink
 		}
 	}
 
-	keyStore := keystore.New(db, utils.GetScryptParams(cfg), appLggr)
+	keyStore, err := keystore.New(db, utils.GetScryptParams(cfg), appLggr)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating keystore")
+	}
+	if err = keyStore.ExportLockWaits(promclient.DefaultRegisterer); err != nil {
+		appLggr.Warnw("Failed to export keystore lock metrics", "err", err)
+	}
 
 	// Set up the versioning ORM
 	verORM := versioning.NewORM(db, appLggr)
@@ -260,7 +285,6 @@ func (n ChainlinkAppFactory) NewApplication(cfg config.GeneralConfig) (chainlink
 	externalInitiatorManager := webhook.NewExternalInitiatorManager(db, utils.UnrestrictedClient, appLggr)
 	return chainlink.NewApplication(chainlink.ApplicationOpts{
 		Config:                   cfg,
-		ShutdownSignal:           shutdownSignal,
 		SqlxDB:                   db,
 		KeyStore:                 keyStore,
 		ChainSet:                 chainSet,
@@ -369,6 +393,16 @@ type HTTPClientConfig interface {
 	SessionCookieAuthenticatorConfig
 }
 
+// DefaultHTTPTimeout bounds each request the CLI makes to the node, unless
+// overridden with the --timeout flag.
+const DefaultHTTPTimeout = time.Minute
+
+// HTTPTimeoutSetter is implemented by clients whose request timeout can be
+// changed after they are constructed. A zero timeout means no timeout.
+type HTTPTimeoutSetter interface {
+	SetTimeout(time.Duration)
+}
+
 type authenticatedHTTPClient struct {
 	config         HTTPClientConfig
 	client         *http.Client
@@ -396,7 +430,17 @@ func newHttpClient(config SessionCookieAuthenticatorConfig) *http.Client {
 	if config.InsecureSkipVerify() {
 		fmt.Println("WARNING: INSECURE_SKIP_VERIFY is set to true, skipping SSL certificate verification.")
 	}
-	return &http.Client{Transport: tr}
+	return &http.Client{Transport: NewRetryingTransport(tr), Timeout: DefaultHTTPTimeout}
+}
+
+// SetTimeout sets the deadline for each request, including retries and
+// reading the response body. It also applies to re-authenticating the session
+// if the cookie authenticator supports it.
+func (h *authenticatedHTTPClient) SetTimeout(timeout time.Duration) {
+	h.client.Timeout = timeout
+	if setter, ok := h.cookieAuth.(HTTPTimeoutSetter); ok {
+		setter.SetTimeout(timeout)
+	}
 }
 
 // Get performs an HTTP Get using the authenticated HTTP client's cookie.
@@ -456,7 +500,8 @@ func (h *authenticatedHTTPClient) doRequest(verb, path string, body io.Reader, h
 		var cookieerr error
 		cookie, cookieerr = h.cookieAuth.Authenticate(h.sessionRequest)
 		if cookieerr != nil {
-			return response, err
+			response.Body.Close()
+			return nil, errors.Wrap(cookieerr, "failed to re-authenticate session")
 		}
 		request.Header.Set("Cookie", "")
 		request.AddCookie(cookie)
@@ -483,15 +528,21 @@ type SessionCookieAuthenticatorConfig interface {
 // SessionCookieAuthenticator is a concrete implementation of CookieAuthenticator
 // that retrieves a session id for the user with credentials from the session request.
 type SessionCookieAuthenticator struct {
-	config SessionCookieAuthenticatorConfig
-	store  CookieStore
-	lggr   logger.Logger
+	config  SessionCookieAuthenticatorConfig
+	store   CookieStore
+	lggr    logger.Logger
+	timeout time.Duration
 }
 
 // NewSessionCookieAuthenticator creates a SessionCookieAuthenticator using the passed config
 // and builder.
 func NewSessionCookieAuthenticator(config SessionCookieAuthenticatorConfig, store CookieStore, lggr logger.Logger) CookieAuthenticator {
-	return &SessionCookieAuthenticator{config: config, store: store, lggr: lggr}
+	return &SessionCookieAuthenticator{config: config, store: store, lggr: lggr, timeout: DefaultHTTPTimeout}
+}
+
+// SetTimeout sets the deadline for the authentication request.
+func (t *SessionCookieAuthenticator) SetTimeout(timeout time.Duration) {
+	t.timeout = timeout
 }
 
 // Cookie Returns the previously saved authentication cookie.
@@ -514,6 +565,7 @@ func (t *SessionCookieAuthenticator) Authenticate(sessionRequest sessions.Sessio
 	req.Header.Set("Content-Type", "application/json")
 
 	client := newHttpClient(t.config)
+	client.Timeout = t.timeout
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
@@ -562,6 +614,8 @@ type DiskCookieConfig interface {
 // DiskCookieStore saves a single cookie in the local cli working directory.
 type DiskCookieStore struct {
 	Config DiskCookieConfig
+	// File overrides the default cookie path of $ROOT/cookie
+	File string
 }
 
 // Save stores a cookie.
@@ -589,6 +643,9 @@ func (d DiskCookieStore) Retrieve() (*http.Cookie, error) {
 }
 
 func (d DiskCookieStore) cookiePath() string {
+	if d.File != "" {
+		return d.File
+	}
 	return path.Join(d.Config.RootDir(), "cookie")
 }
 
@@ -628,6 +685,38 @@ func (f *fileSessionRequestBuilder) Build(file string) (sessions.SessionRequest,
 	return credentialsFromFile(file, f.lggr.With("file", file))
 }
 
+const (
+	// EnvAPIEmail is the environment variable holding the API user's email
+	EnvAPIEmail = "CHAINLINK_EMAIL"
+	// EnvAPIPassword is the environment variable holding the API user's password
+	EnvAPIPassword = "CHAINLINK_PASSWORD"
+)
+
+type envSessionRequestBuilder struct {
+	fallback SessionRequestBuilder
+	lggr     logger.Logger
+}
+
+// NewEnvSessionRequestBuilder reads credentials from the CHAINLINK_EMAIL and
+// CHAINLINK_PASSWORD environment variables to generate a SessionRequest. If
+// neither is set, the request is built by fallback instead.
+func NewEnvSessionRequestBuilder(fallback SessionRequestBuilder, lggr logger.Logger) SessionRequestBuilder {
+	return &envSessionRequestBuilder{fallback: fallback, lggr: lggr}
+}
+
+func (e *envSessionRequestBuilder) Build(flag string) (sessions.SessionRequest, error) {
+	email, pwd := os.Getenv(EnvAPIEmail), os.Getenv(EnvAPIPassword)
+	if email == "" && pwd == "" {
+		return e.fallback.Build(flag)
+	}
+	if email == "" || pwd == "" {
+		return sessions.SessionRequest{}, fmt.Errorf("both %s and %s must be set to use API credentials from the environment", EnvAPIEmail, EnvAPIPassword)
+	}
+
+	e.lggr.Debugw("Using API credentials from environment", "email", email)
+	return sessions.SessionRequest{Email: email, Password: pwd}, nil
+}
+
 // APIInitializer is the interface used to create the API User credentials
 // needed to access the API. Does nothing if API user already exists.
 type APIInitializer interface {
@@ -1,6 +1,8 @@
 package cmd_test
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -12,6 +14,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	clipkg "github.com/urfave/cli"
 )
 
 type cfg struct{}
@@ -139,7 +142,7 @@ func TestTerminalAPIInitializer_InitializeWithoutAPIUser(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			db := pgtest.NewSqlxDB(t)
-			orm := sessions.NewORM(db, time.Minute, logger.TestLogger(t))
+			orm := sessions.NewORM(db, time.Minute, false, logger.TestLogger(t))
 
 			mock := &cltest.MockCountingPrompter{EnteredStrings: test.enteredStrings, NotTerminal: !test.isTerminal}
 			tai := cmd.NewPromptingAPIInitializer(mock)
@@ -169,7 +172,7 @@ func TestTerminalAPIInitializer_InitializeWithExistingAPIUser(t *testing.T) {
 	t.Parallel()
 
 	db := pgtest.NewSqlxDB(t)
-	orm := sessions.NewORM(db, time.Minute, logger.TestLogger(t))
+	orm := sessions.NewORM(db, time.Minute, false, logger.TestLogger(t))
 
 	initialUser := cltest.MustRandomUser(t)
 	require.NoError(t, orm.CreateUser(&initialUser))
@@ -198,7 +201,7 @@ func TestFileAPIInitializer_InitializeWithoutAPIUser(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			db := pgtest.NewSqlxDB(t)
-			orm := sessions.NewORM(db, time.Minute, logger.TestLogger(t))
+			orm := sessions.NewORM(db, time.Minute, false, logger.TestLogger(t))
 			// Clear out fixture user
 			orm.DeleteUser()
 
@@ -221,7 +224,7 @@ func TestFileAPIInitializer_InitializeWithExistingAPIUser(t *testing.T) {
 	t.Parallel()
 
 	db := pgtest.NewSqlxDB(t)
-	orm := sessions.NewORM(db, time.Minute, logger.TestLogger(t))
+	orm := sessions.NewORM(db, time.Minute, false, logger.TestLogger(t))
 
 	tests := []struct {
 		name      string
@@ -290,3 +293,107 @@ func TestFileSessionRequestBuilder(t *testing.T) {
 		})
 	}
 }
+
+func TestEnvSessionRequestBuilder(t *testing.T) {
+	const fixtureFile = "../internal/fixtures/apicredentials"
+
+	tests := []struct {
+		name, email, pwd, file string
+		wantEmail, wantPwd     string
+		wantError              error
+	}{
+		{"env only", "env@chainlink.test", "envpwd", "", "env@chainlink.test", "envpwd", nil},
+		{"file only", "", "", fixtureFile, cltest.APIEmail, cltest.Password, nil},
+		{"env takes precedence over file", "env@chainlink.test", "envpwd", fixtureFile, "env@chainlink.test", "envpwd", nil},
+		{"neither", "", "", "", "", "", cmd.ErrNoCredentialFile},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv(cmd.EnvAPIEmail, test.email)
+			t.Setenv(cmd.EnvAPIPassword, test.pwd)
+
+			lggr := logger.TestLogger(t)
+			builder := cmd.NewEnvSessionRequestBuilder(cmd.NewFileSessionRequestBuilder(lggr), lggr)
+
+			sr, err := builder.Build(test.file)
+			assert.Equal(t, test.wantError, err)
+			assert.Equal(t, test.wantEmail, sr.Email)
+			assert.Equal(t, test.wantPwd, sr.Password)
+		})
+	}
+
+	t.Run("only one variable set", func(t *testing.T) {
+		t.Setenv(cmd.EnvAPIEmail, "env@chainlink.test")
+		t.Setenv(cmd.EnvAPIPassword, "")
+
+		lggr := logger.TestLogger(t)
+		builder := cmd.NewEnvSessionRequestBuilder(cmd.NewFileSessionRequestBuilder(lggr), lggr)
+
+		_, err := builder.Build(fixtureFile)
+		require.EqualError(t, err, "both CHAINLINK_EMAIL and CHAINLINK_PASSWORD must be set to use API credentials from the environment")
+	})
+
+	t.Run("does not log the password", func(t *testing.T) {
+		t.Setenv(cmd.EnvAPIEmail, "env@chainlink.test")
+		t.Setenv(cmd.EnvAPIPassword, "supersecretenvpwd")
+
+		lggr := logger.TestLogger(t)
+		builder := cmd.NewEnvSessionRequestBuilder(cmd.NewFileSessionRequestBuilder(lggr), lggr)
+
+		_, err := builder.Build("")
+		require.NoError(t, err)
+		assert.NotContains(t, logger.MemoryLogTestingOnly().String(), "supersecretenvpwd")
+	})
+}
+
+func TestNewApp_Timeout(t *testing.T) {
+	// Not parallel: cli.OsExiter is global
+	defer func(exiter func(int)) { clipkg.OsExiter = exiter }(clipkg.OsExiter)
+	clipkg.OsExiter = func(int) {}
+
+	tests := []struct {
+		name     string
+		slowPath string
+	}{
+		{"slow response", "/v2/bridge_types"},
+		{"slow re-authentication", "/sessions"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			release := make(chan struct{})
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == test.slowPath {
+					select {
+					case <-release:
+					case <-r.Context().Done():
+					}
+					return
+				}
+				w.WriteHeader(http.StatusUnauthorized)
+			}))
+			t.Cleanup(srv.Close)
+			t.Cleanup(func() { close(release) })
+
+			lggr := logger.TestLogger(t)
+			config := retryTestConfig{srv.URL}
+			cookieAuth := cmd.NewSessionCookieAuthenticator(config, &cmd.MemoryCookieStore{}, lggr)
+			sr := sessions.SessionRequest{Email: cltest.APIEmail, Password: cltest.Password}
+			client := &cmd.Client{
+				Renderer:            &cltest.RendererMock{},
+				Config:              cltest.NewTestGeneralConfig(t),
+				Logger:              lggr,
+				HTTP:                cmd.NewAuthenticatedHTTPClient(config, cookieAuth, sr),
+				CookieAuthenticator: cookieAuth,
+			}
+
+			start := time.Now()
+			err := cmd.NewApp(client).Run([]string{"chainlink", "--timeout", "100ms", "bridges", "list"})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "Client.Timeout exceeded")
+			assert.Less(t, time.Since(start), 5*time.Second)
+		})
+	}
+}
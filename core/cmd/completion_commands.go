@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	clipkg "github.com/urfave/cli"
+)
+
+// Bash and zsh complete by re-invoking the CLI with --generate-bash-completion,
+// which lists the subcommands and flags valid at that point in the command tree.
+const bashCompletionTemplate = `#! /bin/bash
+
+_%[1]s_bash_autocomplete() {
+  if [[ "${COMP_WORDS[0]}" != "source" ]]; then
+    local cur opts
+    COMPREPLY=()
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    if [[ "$cur" == "-"* ]]; then
+      opts=$( ${COMP_WORDS[@]:0:$COMP_CWORD} ${cur} --generate-bash-completion )
+    else
+      opts=$( ${COMP_WORDS[@]:0:$COMP_CWORD} --generate-bash-completion )
+    fi
+    COMPREPLY=( $(compgen -W "${opts}" -- ${cur}) )
+    return 0
+  fi
+}
+
+complete -o bashdefault -o default -o nospace -F _%[1]s_bash_autocomplete %[1]s
+`
+
+const zshCompletionTemplate = `#compdef %[1]s
+
+_%[1]s_zsh_autocomplete() {
+  local -a opts
+  local cur
+  cur=${words[-1]}
+  if [[ "$cur" == "-"* ]]; then
+    opts=("${(@f)$(_CLI_ZSH_AUTOCOMPLETE_HACK=1 ${words[@]:0:#words[@]-1} ${cur} --generate-bash-completion)}")
+  else
+    opts=("${(@f)$(_CLI_ZSH_AUTOCOMPLETE_HACK=1 ${words[@]:0:#words[@]-1} --generate-bash-completion)}")
+  fi
+
+  if [[ "${opts[1]}" != "" ]]; then
+    _describe 'values' opts
+  else
+    _files
+  fi
+}
+
+compdef _%[1]s_zsh_autocomplete %[1]s
+`
+
+var completionGenerators = map[string]func(app *clipkg.App) (string, error){
+	"bash": func(app *clipkg.App) (string, error) {
+		return fmt.Sprintf(bashCompletionTemplate, app.Name), nil
+	},
+	"zsh": func(app *clipkg.App) (string, error) {
+		return fmt.Sprintf(zshCompletionTemplate, app.Name), nil
+	},
+	"fish": func(app *clipkg.App) (string, error) {
+		return app.ToFishCompletion()
+	},
+}
+
+// CompletionShells returns the shells a completion script can be generated for.
+func CompletionShells() []string {
+	var shells []string
+	for shell := range completionGenerators {
+		shells = append(shells, shell)
+	}
+	sort.Strings(shells)
+	return shells
+}
+
+// Completion writes the completion script for the given shell to stdout.
+func (cli *Client) Completion(c *clipkg.Context) error {
+	if !c.Args().Present() {
+		return cli.errorOut(fmt.Errorf("must pass the shell to generate completions for, one of: %s", strings.Join(CompletionShells(), ", ")))
+	}
+	shell := c.Args().First()
+	generate, ok := completionGenerators[shell]
+	if !ok {
+		return cli.errorOut(fmt.Errorf("unsupported shell %q, must be one of: %s", shell, strings.Join(CompletionShells(), ", ")))
+	}
+	script, err := generate(c.App)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	_, err = fmt.Fprint(c.App.Writer, script)
+	return cli.errorOut(err)
+}
@@ -0,0 +1,63 @@
+package cmd_test
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/cmd"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+)
+
+func TestClient_Completion(t *testing.T) {
+	t.Parallel()
+
+	client := &cmd.Client{Config: cltest.NewTestGeneralConfig(t), Logger: logger.TestLogger(t)}
+
+	tests := []struct {
+		shell    string
+		contains []string
+	}{
+		{"bash", []string{"#! /bin/bash", "--generate-bash-completion", "complete -o bashdefault -o default -o nospace -F _chainlink_bash_autocomplete chainlink"}},
+		{"zsh", []string{"#compdef chainlink", "--generate-bash-completion", "compdef _chainlink_zsh_autocomplete chainlink"}},
+		// fish completions are static, so the command tree must be in the script
+		{"fish", []string{"complete -c chainlink", "'bridges'", "'completion'", "-l timeout"}},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.shell, func(t *testing.T) {
+			t.Parallel()
+
+			app := cmd.NewApp(client)
+			app.Name = "chainlink"
+			var buf bytes.Buffer
+			app.Writer = &buf
+
+			set := flag.NewFlagSet("test", 0)
+			require.NoError(t, set.Parse([]string{test.shell}))
+			c := cli.NewContext(app, set, nil)
+
+			require.NoError(t, client.Completion(c))
+			require.NotEmpty(t, buf.String())
+			for _, s := range test.contains {
+				assert.Contains(t, buf.String(), s)
+			}
+		})
+	}
+
+	t.Run("unsupported shell", func(t *testing.T) {
+		t.Parallel()
+
+		set := flag.NewFlagSet("test", 0)
+		require.NoError(t, set.Parse([]string{"powershell"}))
+		c := cli.NewContext(cmd.NewApp(client), set, nil)
+
+		err := client.Completion(c)
+		assert.EqualError(t, err, `unsupported shell "powershell", must be one of: bash, fish, zsh`)
+	})
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/pkg/errors"
+	clipkg "github.com/urfave/cli"
+
+	"github.com/smartcontractkit/chainlink/core/config"
+)
+
+// Categories of configuration errors reported by ValidateConfig
+const (
+	ConfigCategoryGeneral  = "General"
+	ConfigCategoryDatabase = "Database"
+	ConfigCategoryKeystore = "Keystore"
+	ConfigCategoryChains   = "Chains"
+)
+
+// ConfigIssue is an error found while validating the node's configuration.
+type ConfigIssue struct {
+	Category string `json:"category"`
+	Message  string `json:"message"`
+}
+
+// ConfigIssues is the report produced by ValidateConfig, sorted by category.
+type ConfigIssues []ConfigIssue
+
+// RenderTable implements TableRenderer
+func (ps ConfigIssues) RenderTable(rt RendererTable) error {
+	if len(ps) == 0 {
+		_, err := rt.Write([]byte("Configuration is valid\n"))
+		return err
+	}
+
+	rows := [][]string{}
+	for _, p := range ps {
+		rows = append(rows, []string{p.Category, p.Message})
+	}
+	renderList([]string{"Category", "Error"}, rows, rt.Writer)
+	_, err := rt.Write([]byte("\n"))
+	return err
+}
+
+// ValidateConfig checks the node's configuration for errors that would
+// otherwise only surface once the node is running, and reports them by
+// category. It fails if any are found.
+func (cli *Client) ValidateConfig(c *clipkg.Context) error {
+	issues := validateConfig(cli.Config)
+	if err := cli.Render(&issues); err != nil {
+		return cli.errorOut(err)
+	}
+	if len(issues) > 0 {
+		return cli.errorOut(fmt.Errorf("found %d configuration error(s)", len(issues)))
+	}
+	return nil
+}
+
+func validateConfig(cfg config.GeneralConfig) ConfigIssues {
+	var issues ConfigIssues
+	add := func(category string, err error) {
+		if err != nil {
+			issues = append(issues, ConfigIssue{Category: category, Message: err.Error()})
+		}
+	}
+
+	add(ConfigCategoryGeneral, cfg.Validate())
+	add(ConfigCategoryDatabase, validateDatabaseURL(cfg.DatabaseURL()))
+	if cfg.InsecureFastScrypt() && !cfg.Dev() {
+		add(ConfigCategoryKeystore, errors.New("INSECURE_FAST_SCRYPT weakens the encryption of all keys and must only be used in dev mode"))
+	}
+	// The node refuses to start without these when it creates the chain and
+	// its nodes from the legacy env vars
+	if cfg.UseLegacyEthEnvVars() {
+		if cfg.DefaultChainID() == nil {
+			add(ConfigCategoryChains, errors.New("ETH_CHAIN_ID must be set (or set USE_LEGACY_ETH_ENV_VARS=false)"))
+		}
+		add(ConfigCategoryChains, validateEthereumURL(cfg.EthereumURL()))
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Category < issues[j].Category
+	})
+	return issues
+}
+
+func validateDatabaseURL(uri url.URL) error {
+	switch {
+	case uri.String() == "":
+		return errors.New("DATABASE_URL is missing or could not be parsed")
+	case uri.Scheme != "postgres" && uri.Scheme != "postgresql":
+		return errors.Errorf("DATABASE_URL must be a postgres:// URL, got scheme %q", uri.Scheme)
+	case uri.Host == "" && uri.Query().Get("host") == "":
+		return errors.New("DATABASE_URL has no host")
+	}
+	return nil
+}
+
+func validateEthereumURL(s string) error {
+	if s == "" {
+		return errors.New("ETH_URL must be set (or set USE_LEGACY_ETH_ENV_VARS=false)")
+	}
+	uri, err := url.Parse(s)
+	if err != nil {
+		return errors.Wrap(err, "ETH_URL is invalid")
+	}
+	if uri.Scheme != "ws" && uri.Scheme != "wss" {
+		return errors.Errorf("ETH_URL must be a ws:// or wss:// URL, got scheme %q", uri.Scheme)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package cmd_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/cmd"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+	"gopkg.in/guregu/null.v4"
+)
+
+func TestClient_ValidateConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg := cltest.NewTestGeneralConfig(t)
+	cfg.Overrides.Dev = null.BoolFrom(false)
+	cfg.Overrides.DatabaseURL = null.StringFrom("mysql://localhost/chainlink")
+	r := &cltest.RendererMock{}
+	client := &cmd.Client{Config: cfg, Renderer: r, Logger: logger.TestLogger(t)}
+
+	err := client.ValidateConfig(cltest.EmptyCLIContext())
+	require.Error(t, err)
+	var exitErr *cli.ExitError
+	require.True(t, errors.As(err, &exitErr))
+	assert.Equal(t, 1, exitErr.ExitCode())
+
+	require.Len(t, r.Renders, 1)
+	issues := *r.Renders[0].(*cmd.ConfigIssues)
+	assert.Contains(t, issues, cmd.ConfigIssue{
+		Category: cmd.ConfigCategoryDatabase,
+		Message:  `DATABASE_URL must be a postgres:// URL, got scheme "mysql"`,
+	})
+	assert.Contains(t, issues, cmd.ConfigIssue{
+		Category: cmd.ConfigCategoryKeystore,
+		Message:  "INSECURE_FAST_SCRYPT weakens the encryption of all keys and must only be used in dev mode",
+	})
+	assert.EqualError(t, err, fmt.Sprintf("found %d configuration error(s)", len(issues)))
+}
+
+func TestConfigIssues_RenderTable(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+	require.NoError(t, cmd.ConfigIssues{}.RenderTable(cmd.RendererTable{Writer: &b}))
+	assert.Equal(t, "Configuration is valid\n", b.String())
+
+	b.Reset()
+	issues := cmd.ConfigIssues{{Category: cmd.ConfigCategoryDatabase, Message: "DATABASE_URL has no host"}}
+	require.NoError(t, issues.RenderTable(cmd.RendererTable{Writer: &b}))
+	assert.Contains(t, b.String(), cmd.ConfigCategoryDatabase)
+	assert.Contains(t, b.String(), "DATABASE_URL has no host")
+}
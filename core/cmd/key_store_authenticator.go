@@ -22,14 +22,10 @@ func (auth TerminalKeyStoreAuthenticator) authenticate(c *clipkg.Context, keySto
 	if err != nil {
 		return errors.Wrap(err, "error determining if keystore is empty")
 	}
-	password, err := passwordFromFile(c.String("password"))
-	if err != nil {
-		return errors.Wrap(err, "error reading password from file")
-	}
-	passwordProvided := len(password) != 0
-	if passwordProvided {
-		return keyStore.Unlock(password)
+	if path := c.String("password"); path != "" {
+		return keyStore.UnlockFromFile(path)
 	}
+	var password string
 	interactive := auth.Prompter.IsTerminal()
 	if !interactive {
 		return errors.New("no password provided")
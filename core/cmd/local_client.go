@@ -30,6 +30,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/services/bulletprooftxmanager"
 	"github.com/smartcontractkit/chainlink/core/services/health"
+	"github.com/smartcontractkit/chainlink/core/services/keystore"
 	"github.com/smartcontractkit/chainlink/core/services/postgres"
 	"github.com/smartcontractkit/chainlink/core/sessions"
 	"github.com/smartcontractkit/chainlink/core/static"
@@ -74,7 +75,7 @@ func (cli *Client) RunNode(c *clipkg.Context) error {
 	var vrfpwd string
 	var fileErr error
 	if len(c.String("vrfpassword")) != 0 {
-		vrfpwd, fileErr = passwordFromFile(c.String("vrfpassword"))
+		vrfpwd, fileErr = keystore.PasswordFromFile(c.String("vrfpassword"))
 		if fileErr != nil {
 			return cli.errorOut(errors.Wrapf(fileErr,
 				"error reading VRF password from vrfpassword file \"%s\"",
@@ -140,13 +141,32 @@ func (cli *Client) RunNode(c *clipkg.Context) error {
 		return cli.errorOut(fmt.Errorf("error starting app: %+v", e))
 	}
 	defer func() { lggr.ErrorIf(app.Stop(), "Error stopping app") }()
+
+	// Allow switching to e.g. debug logs while running, by changing LOG_LEVEL
+	// in the config file and sending SIGHUP
+	reloadCtx, cancelReload := context.WithCancel(cli.rootCtx())
+	defer cancelReload()
+	logger.ReloadLevelOnSIGHUP(reloadCtx, cli.Config.ReloadLogLevel, cli.Logger, app.GetLogger())
+
 	err = logConfigVariables(lggr, cli.Config)
 	if err != nil {
 		return cli.errorOut(err)
 	}
 
 	lggr.Infow(fmt.Sprintf("Chainlink booted in %.2fs", time.Since(static.InitTime).Seconds()), "appID", app.ID())
-	return cli.errorOut(cli.Runner.Run(app))
+
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- cli.Runner.Run(app)
+	}()
+	select {
+	case err = <-runErr:
+		return cli.errorOut(err)
+	case <-cli.rootCtx().Done():
+		// The deferred stop gives services the chance to flush
+		lggr.Info("Shutdown requested, stopping app")
+		return nil
+	}
 }
 
 func checkFilePermissions(lggr logger.Logger, rootDir string) error {
@@ -208,14 +228,6 @@ func checkFilePermissions(lggr logger.Logger, rootDir string) error {
 	return nil
 }
 
-func passwordFromFile(pwdFile string) (string, error) {
-	if len(pwdFile) == 0 {
-		return "", nil
-	}
-	dat, err := ioutil.ReadFile(pwdFile)
-	return strings.TrimSpace(string(dat)), err
-}
-
 func logConfigVariables(lggr logger.Logger, cfg config.GeneralConfig) error {
 	wlc, err := config.NewConfigPrinter(cfg)
 	if err != nil {
@@ -260,10 +272,6 @@ func (cli *Client) RebroadcastTransactions(c *clipkg.Context) (err error) {
 			err = multierr.Append(err, serr)
 		}
 	}()
-	pwd, err := passwordFromFile(c.String("password"))
-	if err != nil {
-		return cli.errorOut(fmt.Errorf("error reading password: %+v", err))
-	}
 	chain, err := app.GetChainSet().Get(chainID)
 	if err != nil {
 		return cli.errorOut(err)
@@ -277,7 +285,7 @@ func (cli *Client) RebroadcastTransactions(c *clipkg.Context) (err error) {
 		return err
 	}
 
-	err = keyStore.Unlock(pwd)
+	err = keyStore.UnlockFromFile(c.String("password"))
 	if err != nil {
 		return cli.errorOut(errors.Wrap(err, "error authenticating keystore"))
 	}
@@ -517,6 +525,8 @@ func newConnection(cfg config.GeneralConfig, lggr logger.Logger) (*sqlx.DB, erro
 		LogSQLStatements: cfg.LogSQLStatements(),
 		MaxOpenConns:     cfg.ORMMaxOpenConns(),
 		MaxIdleConns:     cfg.ORMMaxIdleConns(),
+		ConnMaxLifetime:  cfg.ORMConnMaxLifetime(),
+		ConnMaxIdleTime:  cfg.ORMConnMaxIdleTime(),
 	}
 	db, err := postgres.NewConnection(parsed.String(), string(cfg.GetDatabaseDialectConfiguredOrDefault()), config)
 	return db, err
@@ -41,7 +41,7 @@ func TestClient_RunNodeShowsEnv(t *testing.T) {
 	cfg.Overrides.LogLevel = &debug
 	cfg.Overrides.LogToDisk = null.BoolFrom(true)
 	db := pgtest.NewSqlxDB(t)
-	sessionORM := sessions.NewORM(db, time.Minute, logger.TestLogger(t))
+	sessionORM := sessions.NewORM(db, time.Minute, false, logger.TestLogger(t))
 	keyStore := cltest.NewKeyStore(t, db)
 	_, err := keyStore.Eth().Create(&cltest.FixtureChainID)
 	require.NoError(t, err)
@@ -55,6 +55,7 @@ func TestClient_RunNodeShowsEnv(t *testing.T) {
 	app.On("GetKeyStore").Return(keyStore)
 	app.On("GetChainSet").Return(cltest.NewChainSetMockWithOneChain(t, ethClient, evmtest.NewChainScopedConfig(t, cfg))).Maybe()
 	app.On("Start").Return(nil)
+	app.On("GetLogger").Maybe().Return(logger.TestLogger(t))
 	app.On("Stop").Return(nil)
 	app.On("ID").Return(uuid.NewV4())
 
@@ -70,7 +71,7 @@ func TestClient_RunNodeShowsEnv(t *testing.T) {
 	// Start RunNode in a goroutine, it will block until we resume the runner
 	go func() {
 		assert.NoError(t, cmd.NewApp(&client).
-			Run([]string{"", "node", "start", "-debug", "-password", "../internal/fixtures/correct_password.txt"}))
+			Run([]string{"", "node", "start", "-debug", "-password", cltest.MustPasswordFile(t, "../internal/fixtures/correct_password.txt")}))
 	}()
 
 	// Unlock the runner to the client can begin shutdown
@@ -157,6 +158,7 @@ REAPER_EXPIRATION: 240h0m0s
 REPLAY_FROM_BLOCK: -1
 ROOT: %s
 SECURE_COOKIES: true
+SESSION_ABSOLUTE_TIMEOUT: false
 SESSION_TIMEOUT: 2m0s
 TELEMETRY_INGRESS_LOGGING: false
 TELEMETRY_INGRESS_SERVER_PUB_KEY: 
@@ -180,8 +182,8 @@ func TestClient_RunNodeWithPasswords(t *testing.T) {
 		pwdfile      string
 		wantUnlocked bool
 	}{
-		{"correct", "../internal/fixtures/correct_password.txt", true},
-		{"incorrect", "../internal/fixtures/incorrect_password.txt", false},
+		{"correct", cltest.MustPasswordFile(t, "../internal/fixtures/correct_password.txt"), true},
+		{"incorrect", cltest.MustPasswordFile(t, "../internal/fixtures/incorrect_password.txt"), false},
 		{"wrongfile", "doesntexist.txt", false},
 	}
 
@@ -190,7 +192,7 @@ func TestClient_RunNodeWithPasswords(t *testing.T) {
 			cfg := cltest.NewTestGeneralConfig(t)
 			db := pgtest.NewSqlxDB(t)
 			keyStore := cltest.NewKeyStore(t, db)
-			sessionORM := sessions.NewORM(db, time.Minute, logger.TestLogger(t))
+			sessionORM := sessions.NewORM(db, time.Minute, false, logger.TestLogger(t))
 			// Clear out fixture
 			err := sessionORM.DeleteUser()
 			require.NoError(t, err)
@@ -200,6 +202,7 @@ func TestClient_RunNodeWithPasswords(t *testing.T) {
 			app.On("GetKeyStore").Return(keyStore)
 			app.On("GetChainSet").Return(cltest.NewChainSetMockWithOneChain(t, cltest.NewEthClientMock(t), evmtest.NewChainScopedConfig(t, cfg))).Maybe()
 			app.On("Start").Maybe().Return(nil)
+			app.On("GetLogger").Maybe().Return(logger.TestLogger(t))
 			app.On("Stop").Maybe().Return(nil)
 			app.On("ID").Maybe().Return(uuid.NewV4())
 
@@ -238,7 +241,7 @@ func TestClient_RunNode_CreateFundingKeyIfNotExists(t *testing.T) {
 
 	cfg := cltest.NewTestGeneralConfig(t)
 	db := pgtest.NewSqlxDB(t)
-	sessionORM := sessions.NewORM(db, time.Minute, logger.TestLogger(t))
+	sessionORM := sessions.NewORM(db, time.Minute, false, logger.TestLogger(t))
 	keyStore := cltest.NewKeyStore(t, db)
 	_, err := keyStore.Eth().Create(&cltest.FixtureChainID)
 	require.NoError(t, err)
@@ -248,6 +251,7 @@ func TestClient_RunNode_CreateFundingKeyIfNotExists(t *testing.T) {
 	app.On("GetKeyStore").Return(keyStore)
 	app.On("GetChainSet").Return(cltest.NewChainSetMockWithOneChain(t, cltest.NewEthClientMock(t), evmtest.NewChainScopedConfig(t, cfg))).Maybe()
 	app.On("Start").Maybe().Return(nil)
+	app.On("GetLogger").Maybe().Return(logger.TestLogger(t))
 	app.On("Stop").Maybe().Return(nil)
 	app.On("ID").Maybe().Return(uuid.NewV4())
 
@@ -271,7 +275,7 @@ func TestClient_RunNode_CreateFundingKeyIfNotExists(t *testing.T) {
 	assert.EqualError(t, err, sql.ErrNoRows.Error())
 
 	set := flag.NewFlagSet("test", 0)
-	set.String("password", "../internal/fixtures/correct_password.txt", "")
+	set.String("password", cltest.MustPasswordFile(t, "../internal/fixtures/correct_password.txt"), "")
 	ctx := cli.NewContext(nil, set, nil)
 
 	assert.NoError(t, client.RunNode(ctx))
@@ -297,7 +301,7 @@ func TestClient_RunNodeWithAPICredentialsFile(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			cfg := cltest.NewTestGeneralConfig(t)
 			db := pgtest.NewSqlxDB(t)
-			sessionORM := sessions.NewORM(db, time.Minute, logger.TestLogger(t))
+			sessionORM := sessions.NewORM(db, time.Minute, false, logger.TestLogger(t))
 			// Clear out fixture
 			err := sessionORM.DeleteUser()
 			require.NoError(t, err)
@@ -314,6 +318,7 @@ func TestClient_RunNodeWithAPICredentialsFile(t *testing.T) {
 			app.On("GetKeyStore").Return(keyStore)
 			app.On("GetChainSet").Return(cltest.NewChainSetMockWithOneChain(t, ethClient, evmtest.NewChainScopedConfig(t, cfg))).Maybe()
 			app.On("Start").Maybe().Return(nil)
+			app.On("GetLogger").Maybe().Return(logger.TestLogger(t))
 			app.On("Stop").Maybe().Return(nil)
 			app.On("ID").Maybe().Return(uuid.NewV4())
 
@@ -332,7 +337,7 @@ func TestClient_RunNodeWithAPICredentialsFile(t *testing.T) {
 
 			set := flag.NewFlagSet("test", 0)
 			set.String("api", test.apiFile, "")
-			set.String("password", "../internal/fixtures/correct_password.txt", "")
+			set.String("password", cltest.MustPasswordFile(t, "../internal/fixtures/correct_password.txt"), "")
 			c := cli.NewContext(nil, set, nil)
 
 			if test.wantError {
@@ -391,7 +396,7 @@ func TestClient_RebroadcastTransactions_BPTXM(t *testing.T) {
 	set.Uint64("gasPriceWei", gasPrice.Uint64(), "")
 	set.Uint64("gasLimit", gasLimit, "")
 	set.String("address", fromAddress.Hex(), "")
-	set.String("password", "../internal/fixtures/correct_password.txt", "")
+	set.String("password", cltest.MustPasswordFile(t, "../internal/fixtures/correct_password.txt"), "")
 	c := cli.NewContext(nil, set, nil)
 
 	borm := cltest.NewBulletproofTxManagerORM(t, sqlxDB)
@@ -463,7 +468,7 @@ func TestClient_RebroadcastTransactions_OutsideRange_BPTXM(t *testing.T) {
 			set.Uint64("gasPriceWei", gasPrice.Uint64(), "")
 			set.Uint64("gasLimit", gasLimit, "")
 			set.String("address", fromAddress.Hex(), "")
-			set.String("password", "../internal/fixtures/correct_password.txt", "")
+			set.String("password", cltest.MustPasswordFile(t, "../internal/fixtures/correct_password.txt"), "")
 			c := cli.NewContext(nil, set, nil)
 
 			borm := cltest.NewBulletproofTxManagerORM(t, sqlxDB)
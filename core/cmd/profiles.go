@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/config"
+)
+
+const (
+	// ProfilesFileName is the name of the file in the root directory that
+	// holds the CLI profiles
+	ProfilesFileName = "profiles.toml"
+	// EnvProfile is the environment variable selecting the CLI profile when
+	// the --profile flag is not given
+	EnvProfile = "CHAINLINK_PROFILE"
+)
+
+// Profile is a named set of remote node settings, letting a single CLI talk
+// to several nodes. Profiles are read from $ROOT/profiles.toml, e.g.
+//
+//   [prod-eth]
+//   url = "https://prod-eth.example.com:6688"
+//   credentials = "/secrets/prod-eth/apicredentials"
+type Profile struct {
+	Name string `toml:"-"`
+	// URL overrides CLIENT_NODE_URL
+	URL string `toml:"url"`
+	// CredentialsFile overrides ADMIN_CREDENTIALS_FILE
+	CredentialsFile string `toml:"credentials"`
+	// CookieFile is where the profile's session cookie is stored. Defaults to
+	// $ROOT/cookie.<name> so that sessions of different profiles do not clash.
+	CookieFile string `toml:"cookie"`
+}
+
+// LoadProfile reads the profile called name from the profiles file in rootDir.
+func LoadProfile(rootDir, name string) (Profile, error) {
+	file := filepath.Join(rootDir, ProfilesFileName)
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return Profile{}, errors.Wrap(err, "failed to read CLI profiles")
+	}
+
+	var profiles map[string]Profile
+	if err = toml.Unmarshal(b, &profiles); err != nil {
+		return Profile{}, errors.Wrapf(err, "failed to parse CLI profiles in %s", file)
+	}
+	profile, ok := profiles[name]
+	if !ok {
+		return Profile{}, errors.Errorf("profile %q not found in %s", name, file)
+	}
+
+	profile.Name = name
+	if profile.CookieFile == "" {
+		profile.CookieFile = filepath.Join(rootDir, fmt.Sprintf("cookie.%s", name))
+	}
+	return profile, nil
+}
+
+// Apply returns cfg with the profile's settings layered on top.
+func (p Profile) Apply(cfg config.GeneralConfig) config.GeneralConfig {
+	return profileConfig{GeneralConfig: cfg, profile: p}
+}
+
+type profileConfig struct {
+	config.GeneralConfig
+	profile Profile
+}
+
+func (c profileConfig) ClientNodeURL() string {
+	if c.profile.URL != "" {
+		return c.profile.URL
+	}
+	return c.GeneralConfig.ClientNodeURL()
+}
+
+func (c profileConfig) AdminCredentialsFile() string {
+	if c.profile.CredentialsFile != "" {
+		return c.profile.CredentialsFile
+	}
+	return c.GeneralConfig.AdminCredentialsFile()
+}
+
+// globalValueFlags are the global flags of NewApp that take a separate value,
+// which ProfileFromArgs must skip over to find the command.
+var globalValueFlags = map[string]bool{
+	"profile": true,
+	"timeout": true,
+}
+
+// ProfileFromArgs returns the profile selected by the global --profile flag
+// in args, falling back to CHAINLINK_PROFILE. The client has to be
+// constructed before the CLI app parses its flags, hence the lookup here.
+func ProfileFromArgs(args []string) string {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			// Global flags precede the command
+			break
+		}
+		name := strings.TrimLeft(arg, "-")
+		if strings.HasPrefix(name, "profile=") {
+			return strings.TrimPrefix(name, "profile=")
+		}
+		if !globalValueFlags[name] {
+			continue
+		}
+		if i+1 >= len(args) {
+			break
+		}
+		if name == "profile" {
+			return args[i+1]
+		}
+		// Skip the flag's value, which may not start with "-"
+		i++
+	}
+	return os.Getenv(EnvProfile)
+}
@@ -0,0 +1,99 @@
+package cmd_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/cmd"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+)
+
+const testProfiles = `
+[prod-eth]
+url = "https://prod-eth.example.com:6688"
+credentials = "/secrets/prod-eth/apicredentials"
+
+[staging]
+url = "https://staging.example.com:6688"
+cookie = "/tmp/staging-cookie"
+`
+
+func TestLoadProfile(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(rootDir, cmd.ProfilesFileName), []byte(testProfiles), 0600))
+
+	t.Run("defaults the cookie file", func(t *testing.T) {
+		p, err := cmd.LoadProfile(rootDir, "prod-eth")
+		require.NoError(t, err)
+		assert.Equal(t, cmd.Profile{
+			Name:            "prod-eth",
+			URL:             "https://prod-eth.example.com:6688",
+			CredentialsFile: "/secrets/prod-eth/apicredentials",
+			CookieFile:      filepath.Join(rootDir, "cookie.prod-eth"),
+		}, p)
+	})
+
+	t.Run("explicit cookie file", func(t *testing.T) {
+		p, err := cmd.LoadProfile(rootDir, "staging")
+		require.NoError(t, err)
+		assert.Equal(t, "/tmp/staging-cookie", p.CookieFile)
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		_, err := cmd.LoadProfile(rootDir, "nope")
+		require.EqualError(t, err, `profile "nope" not found in `+filepath.Join(rootDir, cmd.ProfilesFileName))
+	})
+
+	t.Run("missing profiles file", func(t *testing.T) {
+		_, err := cmd.LoadProfile(t.TempDir(), "prod-eth")
+		require.Error(t, err)
+	})
+}
+
+func TestProfile_Apply(t *testing.T) {
+	t.Parallel()
+
+	cfg := cltest.NewTestGeneralConfig(t)
+
+	p := cmd.Profile{URL: "https://prod-eth.example.com:6688", CredentialsFile: "/secrets/apicredentials"}
+	applied := p.Apply(cfg)
+	assert.Equal(t, "https://prod-eth.example.com:6688", applied.ClientNodeURL())
+	assert.Equal(t, "/secrets/apicredentials", applied.AdminCredentialsFile())
+	assert.Equal(t, cfg.RootDir(), applied.RootDir())
+
+	applied = cmd.Profile{}.Apply(cfg)
+	assert.Equal(t, cfg.ClientNodeURL(), applied.ClientNodeURL())
+	assert.Equal(t, cfg.AdminCredentialsFile(), applied.AdminCredentialsFile())
+}
+
+func TestProfileFromArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		env  string
+		want string
+	}{
+		{"none", []string{"jobs", "list"}, "", ""},
+		{"flag", []string{"--profile", "prod-eth", "jobs", "list"}, "", "prod-eth"},
+		{"flag with equals", []string{"--json", "--profile=prod-eth", "jobs", "list"}, "", "prod-eth"},
+		{"flag takes precedence over env", []string{"--profile", "prod-eth", "jobs"}, "staging", "prod-eth"},
+		{"env", []string{"jobs", "list"}, "staging", "staging"},
+		{"command flag is ignored", []string{"jobs", "--profile", "prod-eth"}, "", ""},
+		{"after flag with value", []string{"--timeout", "10s", "--profile", "prod", "jobs", "list"}, "", "prod"},
+		{"after flag with equals", []string{"--timeout=10s", "--profile", "prod", "jobs", "list"}, "", "prod"},
+		{"command after flag with value", []string{"--timeout", "10s", "jobs", "--profile", "prod"}, "staging", "staging"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv(cmd.EnvProfile, test.env)
+			assert.Equal(t, test.want, cmd.ProfileFromArgs(test.args))
+		})
+	}
+}
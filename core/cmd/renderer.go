@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"reflect"
@@ -11,6 +12,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/utils"
 	"github.com/smartcontractkit/chainlink/core/web"
 	webpresenters "github.com/smartcontractkit/chainlink/core/web/presenters"
+	"gopkg.in/yaml.v3"
 )
 
 // Renderer implements the Render method.
@@ -38,6 +40,48 @@ func (rj RendererJSON) Render(v interface{}, _ ...string) error {
 	return nil
 }
 
+// RendererYAML is used to render YAML data. Resources are mapped using their
+// JSON field names, so the output has the same shape as RendererJSON's.
+type RendererYAML struct {
+	io.Writer
+}
+
+// Render writes the given input as a YAML document.
+func (ry RendererYAML) Render(v interface{}, _ ...string) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	// JSON is valid YAML, and decoding it into a node keeps the field order
+	var node yaml.Node
+	if err = yaml.Unmarshal(b, &node); err != nil {
+		return err
+	}
+	clearYAMLStyle(&node)
+
+	enc := yaml.NewEncoder(ry)
+	enc.SetIndent(2)
+	if err = enc.Encode(&node); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// clearYAMLStyle resets the flow and quoting styles inherited from the JSON
+// input, so that the node is written in block style and strings are only
+// quoted where needed.
+func clearYAMLStyle(node *yaml.Node) {
+	// The encoder leaves hex strings too long for an int64 unquoted, but other
+	// YAML parsers would read hashes and addresses as integers
+	if node.Kind != yaml.ScalarNode || node.Tag != "!!str" || !strings.HasPrefix(node.Value, "0x") {
+		node.Style = 0
+	}
+	for _, n := range node.Content {
+		clearYAMLStyle(n)
+	}
+}
+
 // RendererTable is used for data to be rendered as a table.
 type RendererTable struct {
 	io.Writer
@@ -2,10 +2,14 @@ package cmd_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"io/ioutil"
+	"reflect"
 	"regexp"
 	"testing"
+	"time"
 
+	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/cmd"
 	"github.com/smartcontractkit/chainlink/core/config"
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
@@ -13,22 +17,150 @@ import (
 	webpresenters "github.com/smartcontractkit/chainlink/core/web/presenters"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestRendererJSON_RenderVRFKeys(t *testing.T) {
 	t.Parallel()
 
-	r := cmd.RendererJSON{Writer: ioutil.Discard}
+	compressed := "0xe2c659dd73ded1663c0caf02304aac5ccd247047b3993d273a8920bba0402f4d01"
 	keys := []cmd.VRFKeyPresenter{
 		{
+			JAID: cmd.NewJAID(compressed),
 			VRFKeyResource: webpresenters.VRFKeyResource{
-				Compressed:   "0xe2c659dd73ded1663c0caf02304aac5ccd247047b3993d273a8920bba0402f4d01",
+				Compressed:   compressed,
 				Uncompressed: "0xe2c659dd73ded1663c0caf02304aac5ccd247047b3993d273a8920bba0402f4db44652a69526181101d4aa9a58ecf43b1be972330de99ea5e540f56f4e0a672f",
 				Hash:         "0x9926c5f19ec3b3ce005e1c183612f05cfc042966fcdd82ec6e78bf128d91695a",
 			},
 		},
 	}
-	assert.NoError(t, r.Render(&keys))
+
+	var b bytes.Buffer
+	r := cmd.RendererJSON{Writer: &b}
+	require.NoError(t, r.Render(&keys))
+
+	assert.True(t, json.Valid(b.Bytes()))
+	assert.Equal(t, `[
+  {
+    "id": "0xe2c659dd73ded1663c0caf02304aac5ccd247047b3993d273a8920bba0402f4d01",
+    "compressed": "0xe2c659dd73ded1663c0caf02304aac5ccd247047b3993d273a8920bba0402f4d01",
+    "uncompressed": "0xe2c659dd73ded1663c0caf02304aac5ccd247047b3993d273a8920bba0402f4db44652a69526181101d4aa9a58ecf43b1be972330de99ea5e540f56f4e0a672f",
+    "hash": "0x9926c5f19ec3b3ce005e1c183612f05cfc042966fcdd82ec6e78bf128d91695a"
+  }
+]
+`, b.String())
+}
+
+func TestRendererJSON_RenderLogConfig(t *testing.T) {
+	t.Parallel()
+
+	lc := webpresenters.ServiceLogConfigResource{
+		JAID:            webpresenters.NewJAID("log"),
+		ServiceName:     []string{"Global", "IsSqlEnabled", "head_tracker"},
+		LogLevel:        []string{"info", "false", "debug"},
+		DefaultLogLevel: "info",
+	}
+	expected := `{
+  "serviceName": [
+    "Global",
+    "IsSqlEnabled",
+    "head_tracker"
+  ],
+  "logLevel": [
+    "info",
+    "false",
+    "debug"
+  ],
+  "defaultLogLevel": "info"
+}
+`
+
+	// Rendering the same resource twice must produce identical output, so
+	// that scripts can diff it
+	for i := 0; i < 2; i++ {
+		var b bytes.Buffer
+		r := cmd.RendererJSON{Writer: &b}
+		require.NoError(t, r.Render(&lc, "ignored header"))
+
+		assert.True(t, json.Valid(b.Bytes()))
+		assert.Equal(t, expected, b.String())
+	}
+}
+
+func TestRendererYAML_RenderVRFKeys(t *testing.T) {
+	t.Parallel()
+
+	compressed := "0xe2c659dd73ded1663c0caf02304aac5ccd247047b3993d273a8920bba0402f4d01"
+	keys := []cmd.VRFKeyPresenter{
+		{
+			JAID: cmd.NewJAID(compressed),
+			VRFKeyResource: webpresenters.VRFKeyResource{
+				Compressed:   compressed,
+				Uncompressed: "0xe2c659dd73ded1663c0caf02304aac5ccd247047b3993d273a8920bba0402f4db44652a69526181101d4aa9a58ecf43b1be972330de99ea5e540f56f4e0a672f",
+				Hash:         "0x9926c5f19ec3b3ce005e1c183612f05cfc042966fcdd82ec6e78bf128d91695a",
+			},
+		},
+	}
+
+	var b bytes.Buffer
+	r := cmd.RendererYAML{Writer: &b}
+	require.NoError(t, r.Render(&keys))
+
+	assert.Equal(t, `- id: "0xe2c659dd73ded1663c0caf02304aac5ccd247047b3993d273a8920bba0402f4d01"
+  compressed: "0xe2c659dd73ded1663c0caf02304aac5ccd247047b3993d273a8920bba0402f4d01"
+  uncompressed: "0xe2c659dd73ded1663c0caf02304aac5ccd247047b3993d273a8920bba0402f4db44652a69526181101d4aa9a58ecf43b1be972330de99ea5e540f56f4e0a672f"
+  hash: "0x9926c5f19ec3b3ce005e1c183612f05cfc042966fcdd82ec6e78bf128d91695a"
+`, b.String())
+}
+
+func TestRendererYAML_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		// in is rendered, and the output decoded into a new value of the same type
+		in interface{}
+	}{
+		{"vrf keys", &[]cmd.VRFKeyPresenter{{
+			JAID:           cmd.NewJAID("0xe2c659dd73ded1663c0caf02304aac5ccd247047b3993d273a8920bba0402f4d01"),
+			VRFKeyResource: webpresenters.VRFKeyResource{Compressed: "0xe2c659dd73ded1663c0caf02304aac5ccd247047b3993d273a8920bba0402f4d01", Hash: "0x12"},
+		}}},
+		{"bridge", &cmd.BridgePresenter{BridgeResource: webpresenters.BridgeResource{
+			Name:                   "bridge-1",
+			URL:                    "http://example.com",
+			Confirmations:          10,
+			OutgoingToken:          "true",
+			MinimumContractPayment: assets.NewLinkFromJuels(100),
+			CreatedAt:              time.Date(2021, 11, 1, 12, 30, 0, 0, time.UTC),
+		}}},
+		{"log config", &webpresenters.ServiceLogConfigResource{
+			ServiceName:     []string{"Global", "IsSqlEnabled", "head_tracker"},
+			LogLevel:        []string{"info", "false", "debug"},
+			DefaultLogLevel: "info",
+		}},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var b bytes.Buffer
+			r := cmd.RendererYAML{Writer: &b}
+			require.NoError(t, r.Render(test.in, "ignored header"))
+
+			// Fields are named as in JSON, so decode through JSON to map them
+			// back onto the struct
+			var decoded interface{}
+			require.NoError(t, yaml.Unmarshal(b.Bytes(), &decoded))
+			j, err := json.Marshal(decoded)
+			require.NoError(t, err)
+
+			out := reflect.New(reflect.TypeOf(test.in).Elem()).Interface()
+			require.NoError(t, json.Unmarshal(j, out))
+			assert.Equal(t, test.in, out)
+		})
+	}
 }
 
 func TestRendererTable_RenderConfiguration(t *testing.T) {
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/jpillora/backoff"
+)
+
+const (
+	// DefaultHTTPMaxAttempts is the number of times the CLI tries an
+	// idempotent request before giving up
+	DefaultHTTPMaxAttempts = 3
+	// DefaultHTTPRetryBackoffMin is the delay before the first retry
+	DefaultHTTPRetryBackoffMin = 500 * time.Millisecond
+	// DefaultHTTPRetryBackoffMax caps the delay between retries
+	DefaultHTTPRetryBackoffMax = 5 * time.Second
+)
+
+// RetryingTransport is an http.RoundTripper that retries idempotent requests
+// which fail with a connection error or a 5xx response, e.g. while the node
+// is restarting. Non-idempotent requests are sent exactly once.
+type RetryingTransport struct {
+	Transport http.RoundTripper
+	// MaxAttempts is the total number of times a request is tried, including
+	// the first. Values below 1 are treated as 1.
+	MaxAttempts int
+	// BackoffMin and BackoffMax bound the exponential delay between attempts
+	BackoffMin time.Duration
+	BackoffMax time.Duration
+}
+
+// NewRetryingTransport wraps transport with the default retry settings.
+func NewRetryingTransport(transport http.RoundTripper) *RetryingTransport {
+	return &RetryingTransport{
+		Transport:   transport,
+		MaxAttempts: DefaultHTTPMaxAttempts,
+		BackoffMin:  DefaultHTTPRetryBackoffMin,
+		BackoffMax:  DefaultHTTPRetryBackoffMax,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RetryingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if !isIdempotentRequest(req) {
+		return transport.RoundTrip(req)
+	}
+
+	b := backoff.Backoff{Min: t.BackoffMin, Max: t.BackoffMax, Jitter: true}
+	for attempt := 1; ; attempt++ {
+		resp, err := transport.RoundTrip(req)
+		if attempt >= t.MaxAttempts || !isRetryableResponse(resp, err) {
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(b.Duration()):
+		}
+	}
+}
+
+// isIdempotentRequest reports whether req can safely be sent more than once.
+// Only bodiless GET and HEAD requests qualify.
+func isIdempotentRequest(req *http.Request) bool {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return false
+	}
+	return req.Body == nil || req.Body == http.NoBody
+}
+
+func isRetryableResponse(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode >= http.StatusInternalServerError
+}
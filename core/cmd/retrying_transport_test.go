@@ -0,0 +1,142 @@
+package cmd_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/cmd"
+	"github.com/smartcontractkit/chainlink/core/sessions"
+)
+
+func newTestRetryingTransport(maxAttempts int) *cmd.RetryingTransport {
+	return &cmd.RetryingTransport{
+		Transport:   http.DefaultTransport,
+		MaxAttempts: maxAttempts,
+		BackoffMin:  time.Millisecond,
+		BackoffMax:  time.Millisecond,
+	}
+}
+
+// newFlakyServer returns a server which responds with 503 to the first
+// failures requests and with 200 afterwards
+func newFlakyServer(t *testing.T, failures int32) (*httptest.Server, *int32) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &hits
+}
+
+func TestRetryingTransport_RetriesGET(t *testing.T) {
+	t.Parallel()
+
+	srv, hits := newFlakyServer(t, 2)
+	client := &http.Client{Transport: newTestRetryingTransport(3)}
+
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(3), atomic.LoadInt32(hits))
+}
+
+func TestRetryingTransport_GivesUpAfterMaxAttempts(t *testing.T) {
+	t.Parallel()
+
+	srv, hits := newFlakyServer(t, 5)
+	client := &http.Client{Transport: newTestRetryingTransport(2)}
+
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(hits))
+}
+
+func TestRetryingTransport_DoesNotRetryPOST(t *testing.T) {
+	t.Parallel()
+
+	srv, hits := newFlakyServer(t, 2)
+	client := &http.Client{Transport: newTestRetryingTransport(3)}
+
+	resp, err := client.Post(srv.URL, "application/json", strings.NewReader("{}"))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(hits))
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestRetryingTransport_RetriesConnectionErrors(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	transport := newTestRetryingTransport(3)
+	transport.Transport = roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		calls++
+		return nil, errors.New("connection refused")
+	})
+
+	req, err := http.NewRequest(http.MethodGet, "http://localhost", nil)
+	require.NoError(t, err)
+	_, err = transport.RoundTrip(req)
+	require.EqualError(t, err, "connection refused")
+	assert.Equal(t, 3, calls)
+}
+
+type retryTestConfig struct{ url string }
+
+func (c retryTestConfig) ClientNodeURL() string    { return c.url }
+func (c retryTestConfig) InsecureSkipVerify() bool { return false }
+
+type countingCookieAuthenticator struct {
+	authenticated int
+}
+
+func (c *countingCookieAuthenticator) Cookie() (*http.Cookie, error) { return nil, nil }
+
+func (c *countingCookieAuthenticator) Authenticate(sessions.SessionRequest) (*http.Cookie, error) {
+	c.authenticated++
+	return &http.Cookie{Name: "clsession", Value: "session"}, nil
+}
+
+func TestAuthenticatedHTTPClient_ReauthenticatesOnce(t *testing.T) {
+	t.Parallel()
+
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(srv.Close)
+
+	auth := &countingCookieAuthenticator{}
+	client := cmd.NewAuthenticatedHTTPClient(retryTestConfig{srv.URL}, auth, sessions.SessionRequest{Email: "email@test.net", Password: "password"})
+
+	resp, err := client.Get("/v2/jobs")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, 1, auth.authenticated)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&hits))
+}
@@ -1,8 +1,10 @@
 package config
 
 import (
+	"io/ioutil"
 	"math/big"
 	"net/url"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -11,11 +13,14 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zapcore"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
 )
 
 func TestGeneralConfig_Defaults(t *testing.T) {
 	config := NewGeneralConfig()
 	assert.Equal(t, uint64(10), config.BlockBackfillDepth())
+	assert.Equal(t, assets.LinkTotalSupply(), config.BridgeMaxMinimumContractPayment())
 	assert.Equal(t, new(url.URL), config.BridgeResponseURL())
 	assert.Nil(t, config.DefaultChainID())
 	assert.Equal(t, false, config.EthereumDisabled())
@@ -51,6 +56,36 @@ func TestConfig_readFromFile(t *testing.T) {
 	assert.Equal(t, config.TLSPort(), uint16(0))
 }
 
+func TestGeneralConfig_ReloadLogLevel(t *testing.T) {
+	// Not parallel: the env var takes precedence over the config file
+	t.Setenv("LOG_LEVEL", "")
+	dir := t.TempDir()
+	v := viper.New()
+	v.Set("ROOT", dir)
+	config := newGeneralConfigWithViper(v)
+	file := filepath.Join(dir, "chainlink.toml")
+
+	lvl, err := config.ReloadLogLevel()
+	require.NoError(t, err)
+	assert.Equal(t, zapcore.InfoLevel, lvl)
+
+	require.NoError(t, ioutil.WriteFile(file, []byte(`LOG_LEVEL = "debug"`), 0600))
+	lvl, err = config.ReloadLogLevel()
+	require.NoError(t, err)
+	assert.Equal(t, zapcore.DebugLevel, lvl)
+	assert.Equal(t, zapcore.DebugLevel, config.LogLevel())
+
+	require.NoError(t, ioutil.WriteFile(file, []byte(`LOG_LEVEL = "loud"`), 0600))
+	_, err = config.ReloadLogLevel()
+	require.Error(t, err)
+	assert.Equal(t, zapcore.DebugLevel, config.LogLevel())
+
+	t.Setenv("LOG_LEVEL", "warn")
+	lvl, err = config.ReloadLogLevel()
+	require.NoError(t, err)
+	assert.Equal(t, zapcore.WarnLevel, lvl)
+}
+
 func TestStore_addressParser(t *testing.T) {
 	zero := &common.Address{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
 	fifteen := &common.Address{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 15}
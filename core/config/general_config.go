@@ -53,7 +53,9 @@ type GeneralOnlyConfig interface {
 	AuthenticatedRateLimitPeriod() models.Duration
 	BlockBackfillDepth() uint64
 	BlockBackfillSkip() bool
+	BridgeMaxMinimumContractPayment() *assets.Link
 	BridgeResponseURL() *url.URL
+	BridgeURLReachabilityCheck() bool
 	CertFile() string
 	ClientNodeURL() string
 	DatabaseBackupDir() string
@@ -89,6 +91,8 @@ type GeneralOnlyConfig interface {
 	GetAdvisoryLockIDConfiguredOrDefault() int64
 	GetDatabaseDialectConfiguredOrDefault() dialects.DialectName
 	GlobalLockRetryInterval() models.Duration
+	GraphQLPageDefaultLimit() int
+	GraphQLPageMaxLimit() int
 	HTTPServerWriteTimeout() time.Duration
 	InsecureFastScrypt() bool
 	InsecureSkipVerify() bool
@@ -110,6 +114,8 @@ type GeneralOnlyConfig interface {
 	DefaultLogLevel() zapcore.Level
 	LogSQLMigrations() bool
 	LogSQLStatements() bool
+	LogSamplingInitial() uint32
+	LogSamplingThereafter() uint32
 	LogToDisk() bool
 	LogUnixTimestamps() bool
 	MigrateDatabase() bool
@@ -131,6 +137,8 @@ type GeneralOnlyConfig interface {
 	OCRSimulateTransactions() bool
 	OCRTraceLogging() bool
 	OCRTransmitterAddress() (ethkey.EIP55Address, error)
+	ORMConnMaxIdleTime() time.Duration
+	ORMConnMaxLifetime() time.Duration
 	ORMMaxIdleConns() int
 	ORMMaxOpenConns() int
 	P2PAnnounceIP() net.IP
@@ -144,6 +152,8 @@ type GeneralOnlyConfig interface {
 	P2PNetworkingStackRaw() string
 	P2PPeerID() p2pkey.PeerID
 	P2PPeerIDRaw() string
+	P2PPeerstoreAddrTTL() time.Duration
+	P2PPeerstoreRetention() time.Duration
 	P2PPeerstoreWriteInterval() time.Duration
 	P2PV2AnnounceAddresses() []string
 	P2PV2AnnounceAddressesRaw() []string
@@ -156,11 +166,13 @@ type GeneralOnlyConfig interface {
 	RPID() string
 	RPOrigin() string
 	ReaperExpiration() models.Duration
+	ReloadLogLevel() (zapcore.Level, error)
 	ReplayFromBlock() int64
 	RootDir() string
 	SecureCookies() bool
 	SessionOptions() sessions.Options
 	SessionSecret() ([]byte, error)
+	SessionAbsoluteTimeout() bool
 	SessionTimeout() models.Duration
 	SetDialect(dialects.DialectName)
 	SetLogLevel(lvl zapcore.Level) error
@@ -408,11 +420,24 @@ func (c *generalConfig) BlockBackfillSkip() bool {
 	return c.getWithFallback("BlockBackfillSkip", ParseBool).(bool)
 }
 
+// BridgeMaxMinimumContractPayment is the largest MinimumContractPayment a
+// bridge type may have. It defaults to the total supply of LINK, as no job
+// could ever pay more than that.
+func (c *generalConfig) BridgeMaxMinimumContractPayment() *assets.Link {
+	return c.getWithFallback("BridgeMaxMinimumContractPayment", ParseLink).(*assets.Link)
+}
+
 // BridgeResponseURL represents the URL for bridges to send a response to.
 func (c *generalConfig) BridgeResponseURL() *url.URL {
 	return c.getWithFallback("BridgeResponseURL", ParseURL).(*url.URL)
 }
 
+// BridgeURLReachabilityCheck enables checking in the background whether the
+// URL of a newly created bridge is reachable, logging a warning if it is not.
+func (c *generalConfig) BridgeURLReachabilityCheck() bool {
+	return c.getWithFallback("BridgeURLReachabilityCheck", ParseBool).(bool)
+}
+
 // ClientNodeURL is the URL of the Ethereum node this Chainlink node should connect to.
 func (c *generalConfig) ClientNodeURL() string {
 	return c.viper.GetString(EnvVarName("ClientNodeURL"))
@@ -479,6 +504,18 @@ func (c *generalConfig) GlobalLockRetryInterval() models.Duration {
 	return models.MustMakeDuration(c.getWithFallback("GlobalLockRetryInterval", ParseDuration).(time.Duration))
 }
 
+// GraphQLPageDefaultLimit is the number of results returned by a paginated
+// GraphQL query that does not specify a limit.
+func (c *generalConfig) GraphQLPageDefaultLimit() int {
+	return int(c.getWithFallback("GraphQLPageDefaultLimit", ParseUint16).(uint16))
+}
+
+// GraphQLPageMaxLimit is the largest number of results a paginated GraphQL
+// query may return. Larger requested limits are reduced to it.
+func (c *generalConfig) GraphQLPageMaxLimit() int {
+	return int(c.getWithFallback("GraphQLPageMaxLimit", ParseUint16).(uint16))
+}
+
 // DatabaseURL configures the URL for chainlink to connect to. This must be
 // a properly formatted URL, with a valid scheme (postgres://)
 func (c *generalConfig) DatabaseURL() url.URL {
@@ -841,6 +878,19 @@ func (c *generalConfig) OCRKeyBundleID() (string, error) {
 	return kbStr, nil
 }
 
+// ORMConnMaxLifetime is how long a database connection may be reused before
+// it is closed, so that connections behind a load balancer do not go stale.
+// Zero means connections are reused forever.
+func (c *generalConfig) ORMConnMaxLifetime() time.Duration {
+	return c.getWithFallback("ORMConnMaxLifetime", ParseDuration).(time.Duration)
+}
+
+// ORMConnMaxIdleTime is how long a database connection may sit idle in the
+// pool before it is closed. Zero means idle connections are kept forever.
+func (c *generalConfig) ORMConnMaxIdleTime() time.Duration {
+	return c.getWithFallback("ORMConnMaxIdleTime", ParseDuration).(time.Duration)
+}
+
 func (c *generalConfig) ORMMaxOpenConns() int {
 	return int(c.getWithFallback("ORMMaxOpenConns", ParseUint16).(uint16))
 }
@@ -869,6 +919,31 @@ func (c *generalConfig) SetLogLevel(lvl zapcore.Level) error {
 	return nil
 }
 
+// ReloadLogLevel re-reads LOG_LEVEL from the config file in RootDir and the
+// environment, and saves it as the runtime value for the default logger
+// level. The level falls back to the default if LOG_LEVEL is unset.
+func (c *generalConfig) ReloadLogLevel() (zapcore.Level, error) {
+	// c.viper is not safe for concurrent use, so read into a fresh one
+	v := viper.New()
+	name := EnvVarName("LogLevel")
+	_ = v.BindEnv(name, name)
+	v.SetConfigName("chainlink")
+	v.AddConfigPath(c.RootDir())
+	if err := v.ReadInConfig(); err != nil && reflect.TypeOf(err) != configFileNotFoundError {
+		return 0, errors.Wrap(err, "unable to load config file")
+	}
+
+	lvl := DefaultLogLevel.Level
+	if v.IsSet(name) {
+		ll, err := ParseLogLevel(v.GetString(name))
+		if err != nil {
+			return 0, errors.Wrapf(err, "invalid %s", name)
+		}
+		lvl = ll.(LogLevel).Level
+	}
+	return lvl, c.SetLogLevel(lvl)
+}
+
 // LogToDisk configures disk preservation of logs.
 func (c *generalConfig) LogToDisk() bool {
 	return c.viper.GetBool(EnvVarName("LogToDisk"))
@@ -894,6 +969,18 @@ func (c *generalConfig) LogSQLMigrations() bool {
 	return c.viper.GetBool(EnvVarName("LogSQLMigrations"))
 }
 
+// LogSamplingInitial is the number of identical log entries logged per second
+// before sampling starts. Zero, the default, disables sampling.
+func (c *generalConfig) LogSamplingInitial() uint32 {
+	return c.getWithFallback("LogSamplingInitial", ParseUint32).(uint32)
+}
+
+// LogSamplingThereafter is the rate at which identical log entries are logged
+// once sampling started, i.e. every Nth. Zero drops all of them.
+func (c *generalConfig) LogSamplingThereafter() uint32 {
+	return c.getWithFallback("LogSamplingThereafter", ParseUint32).(uint32)
+}
+
 // LogUnixTimestamps if set to true will log with timestamp in unix format, otherwise uses ISO8601
 func (c *generalConfig) LogUnixTimestamps() bool {
 	return c.viper.GetBool(EnvVarName("LogUnixTS"))
@@ -962,6 +1049,18 @@ func (c *generalConfig) P2PDHTAnnouncementCounterUserPrefix() uint32 {
 	return c.viper.GetUint32(EnvVarName("P2PDHTAnnouncementCounterUserPrefix"))
 }
 
+// P2PPeerstoreAddrTTL is the TTL given to peer addresses loaded from the
+// database into the peerstore. Zero means addresses never expire.
+func (c *generalConfig) P2PPeerstoreAddrTTL() time.Duration {
+	return c.getWithFallback("P2PPeerstoreAddrTTL", ParseDuration).(time.Duration)
+}
+
+// P2PPeerstoreRetention is how long a persisted peer address is kept without
+// being updated before it is pruned on startup. Zero disables pruning.
+func (c *generalConfig) P2PPeerstoreRetention() time.Duration {
+	return c.getWithFallback("P2PPeerstoreRetention", ParseDuration).(time.Duration)
+}
+
 func (c *generalConfig) P2PPeerstoreWriteInterval() time.Duration {
 	return c.getWithFallback("P2PPeerstoreWriteInterval", ParseDuration).(time.Duration)
 }
@@ -1119,6 +1218,13 @@ func (c *generalConfig) SecureCookies() bool {
 	return c.viper.GetBool(EnvVarName("SecureCookies"))
 }
 
+// SessionAbsoluteTimeout makes user sessions expire SessionTimeout after they
+// were created, however active they are, instead of after SessionTimeout
+// without any activity.
+func (c *generalConfig) SessionAbsoluteTimeout() bool {
+	return c.viper.GetBool(EnvVarName("SessionAbsoluteTimeout"))
+}
+
 // SessionTimeout is the maximum duration that a user session can persist without any activity.
 func (c *generalConfig) SessionTimeout() models.Duration {
 	return models.MustMakeDuration(c.getWithFallback("SessionTimeout", ParseDuration).(time.Duration))
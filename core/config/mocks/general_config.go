@@ -123,6 +123,22 @@ func (_m *GeneralConfig) BlockBackfillSkip() bool {
 	return r0
 }
 
+// BridgeMaxMinimumContractPayment provides a mock function with given fields:
+func (_m *GeneralConfig) BridgeMaxMinimumContractPayment() *assets.Link {
+	ret := _m.Called()
+
+	var r0 *assets.Link
+	if rf, ok := ret.Get(0).(func() *assets.Link); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*assets.Link)
+		}
+	}
+
+	return r0
+}
+
 // BridgeResponseURL provides a mock function with given fields:
 func (_m *GeneralConfig) BridgeResponseURL() *url.URL {
 	ret := _m.Called()
@@ -139,6 +155,20 @@ func (_m *GeneralConfig) BridgeResponseURL() *url.URL {
 	return r0
 }
 
+// BridgeURLReachabilityCheck provides a mock function with given fields:
+func (_m *GeneralConfig) BridgeURLReachabilityCheck() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // CertFile provides a mock function with given fields:
 func (_m *GeneralConfig) CertFile() string {
 	ret := _m.Called()
@@ -1507,6 +1537,34 @@ func (_m *GeneralConfig) GlobalOCRContractConfirmations() (uint16, bool) {
 	return r0, r1
 }
 
+// GraphQLPageDefaultLimit provides a mock function with given fields:
+func (_m *GeneralConfig) GraphQLPageDefaultLimit() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// GraphQLPageMaxLimit provides a mock function with given fields:
+func (_m *GeneralConfig) GraphQLPageMaxLimit() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
 // HTTPServerWriteTimeout provides a mock function with given fields:
 func (_m *GeneralConfig) HTTPServerWriteTimeout() time.Duration {
 	ret := _m.Called()
@@ -1787,6 +1845,34 @@ func (_m *GeneralConfig) LogSQLStatements() bool {
 	return r0
 }
 
+// LogSamplingInitial provides a mock function with given fields:
+func (_m *GeneralConfig) LogSamplingInitial() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// LogSamplingThereafter provides a mock function with given fields:
+func (_m *GeneralConfig) LogSamplingThereafter() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
 // LogToDisk provides a mock function with given fields:
 func (_m *GeneralConfig) LogToDisk() bool {
 	ret := _m.Called()
@@ -2095,6 +2181,34 @@ func (_m *GeneralConfig) OCRTransmitterAddress() (ethkey.EIP55Address, error) {
 	return r0, r1
 }
 
+// ORMConnMaxIdleTime provides a mock function with given fields:
+func (_m *GeneralConfig) ORMConnMaxIdleTime() time.Duration {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
+// ORMConnMaxLifetime provides a mock function with given fields:
+func (_m *GeneralConfig) ORMConnMaxLifetime() time.Duration {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
 // ORMMaxIdleConns provides a mock function with given fields:
 func (_m *GeneralConfig) ORMMaxIdleConns() int {
 	ret := _m.Called()
@@ -2290,6 +2404,34 @@ func (_m *GeneralConfig) P2PPeerIDRaw() string {
 	return r0
 }
 
+// P2PPeerstoreAddrTTL provides a mock function with given fields:
+func (_m *GeneralConfig) P2PPeerstoreAddrTTL() time.Duration {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
+// P2PPeerstoreRetention provides a mock function with given fields:
+func (_m *GeneralConfig) P2PPeerstoreRetention() time.Duration {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
 // P2PPeerstoreWriteInterval provides a mock function with given fields:
 func (_m *GeneralConfig) P2PPeerstoreWriteInterval() time.Duration {
 	ret := _m.Called()
@@ -2468,6 +2610,27 @@ func (_m *GeneralConfig) ReaperExpiration() models.Duration {
 	return r0
 }
 
+// ReloadLogLevel provides a mock function with given fields:
+func (_m *GeneralConfig) ReloadLogLevel() (zapcore.Level, error) {
+	ret := _m.Called()
+
+	var r0 zapcore.Level
+	if rf, ok := ret.Get(0).(func() zapcore.Level); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(zapcore.Level)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ReplayFromBlock provides a mock function with given fields:
 func (_m *GeneralConfig) ReplayFromBlock() int64 {
 	ret := _m.Called()
@@ -2510,6 +2673,20 @@ func (_m *GeneralConfig) SecureCookies() bool {
 	return r0
 }
 
+// SessionAbsoluteTimeout provides a mock function with given fields:
+func (_m *GeneralConfig) SessionAbsoluteTimeout() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // SessionOptions provides a mock function with given fields:
 func (_m *GeneralConfig) SessionOptions() sessions.Options {
 	ret := _m.Called()
@@ -94,6 +94,7 @@ type EnvPrinter struct {
 	ReplayFromBlock                            int64           `json:"REPLAY_FROM_BLOCK"`
 	RootDir                                    string          `json:"ROOT"`
 	SecureCookies                              bool            `json:"SECURE_COOKIES"`
+	SessionAbsoluteTimeout                     bool            `json:"SESSION_ABSOLUTE_TIMEOUT"`
 	SessionTimeout                             models.Duration `json:"SESSION_TIMEOUT"`
 	TelemetryIngressLogging                    bool            `json:"TELEMETRY_INGRESS_LOGGING"`
 	TelemetryIngressServerPubKey               string          `json:"TELEMETRY_INGRESS_SERVER_PUB_KEY"`
@@ -176,6 +177,7 @@ func NewConfigPrinter(cfg GeneralConfig) (ConfigPrinter, error) {
 			ReplayFromBlock:                       cfg.ReplayFromBlock(),
 			RootDir:                               cfg.RootDir(),
 			SecureCookies:                         cfg.SecureCookies(),
+			SessionAbsoluteTimeout:                cfg.SessionAbsoluteTimeout(),
 			SessionTimeout:                        cfg.SessionTimeout(),
 			TLSHost:                               cfg.TLSHost(),
 			TLSPort:                               cfg.TLSPort(),
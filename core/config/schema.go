@@ -29,7 +29,9 @@ type ConfigSchema struct {
 	BlockHistoryEstimatorBlockDelay            uint16                        `env:"BLOCK_HISTORY_ESTIMATOR_BLOCK_DELAY"`
 	BlockHistoryEstimatorBlockHistorySize      uint16                        `env:"BLOCK_HISTORY_ESTIMATOR_BLOCK_HISTORY_SIZE"`
 	BlockHistoryEstimatorTransactionPercentile uint16                        `env:"BLOCK_HISTORY_ESTIMATOR_TRANSACTION_PERCENTILE"`
+	BridgeMaxMinimumContractPayment            assets.Link                   `env:"BRIDGE_MAX_MINIMUM_CONTRACT_PAYMENT_LINK_JUELS" default:"1000000000000000000000000000"`
 	BridgeResponseURL                          url.URL                       `env:"BRIDGE_RESPONSE_URL"`
+	BridgeURLReachabilityCheck                 bool                          `env:"BRIDGE_URL_REACHABILITY_CHECK" default:"false"`
 	ChainType                                  string                        `env:"CHAIN_TYPE"`
 	ClientNodeURL                              string                        `env:"CLIENT_NODE_URL" default:"http://localhost:6688"`
 	DatabaseBackupDir                          string                        `env:"DATABASE_BACKUP_DIR" default:""`
@@ -92,6 +94,8 @@ type ConfigSchema struct {
 	FlagsContractAddress                       string                        `env:"FLAGS_CONTRACT_ADDRESS"`
 	GasEstimatorMode                           string                        `env:"GAS_ESTIMATOR_MODE"`
 	GlobalLockRetryInterval                    models.Duration               `env:"GLOBAL_LOCK_RETRY_INTERVAL" default:"1s"`
+	GraphQLPageDefaultLimit                    uint16                        `env:"GRAPHQL_PAGE_DEFAULT_LIMIT" default:"50"`
+	GraphQLPageMaxLimit                        uint16                        `env:"GRAPHQL_PAGE_MAX_LIMIT" default:"1000"`
 	HTTPServerWriteTimeout                     time.Duration                 `env:"HTTP_SERVER_WRITE_TIMEOUT" default:"10s"`
 	InsecureFastScrypt                         bool                          `env:"INSECURE_FAST_SCRYPT" default:"false"`
 	InsecureSkipVerify                         bool                          `env:"INSECURE_SKIP_VERIFY" default:"false"`
@@ -111,6 +115,8 @@ type ConfigSchema struct {
 	LinkContractAddress                        string                        `env:"LINK_CONTRACT_ADDRESS"`
 	LogLevel                                   LogLevel                      `env:"LOG_LEVEL"`
 	LogSQLMigrations                           bool                          `env:"LOG_SQL_MIGRATIONS" default:"true"`
+	LogSamplingInitial                         uint32                        `env:"LOG_SAMPLING_INITIAL" default:"0"`
+	LogSamplingThereafter                      uint32                        `env:"LOG_SAMPLING_THEREAFTER" default:"0"`
 	LogSQLStatements                           bool                          `env:"LOG_SQL" default:"false"`
 	LogToDisk                                  bool                          `env:"LOG_TO_DISK" default:"false"`
 	LogUnixTS                                  bool                          `env:"LOG_UNIX_TS" default:"false"`
@@ -137,6 +143,8 @@ type ConfigSchema struct {
 	OCRSimulateTransactions                    bool                          `env:"OCR_SIMULATE_TRANSACTIONS" default:"false"`
 	OCRTraceLogging                            bool                          `env:"OCR_TRACE_LOGGING" default:"false"`
 	OCRTransmitterAddress                      string                        `env:"OCR_TRANSMITTER_ADDRESS"`
+	ORMConnMaxIdleTime                         time.Duration                 `env:"ORM_CONN_MAX_IDLE_TIME" default:"0s"`
+	ORMConnMaxLifetime                         time.Duration                 `env:"ORM_CONN_MAX_LIFETIME" default:"0s"`
 	ORMMaxIdleConns                            int                           `env:"ORM_MAX_IDLE_CONNS" default:"10"`
 	ORMMaxOpenConns                            int                           `env:"ORM_MAX_OPEN_CONNS" default:"20"`
 	P2PAnnounceIP                              net.IP                        `env:"P2P_ANNOUNCE_IP"`
@@ -147,6 +155,8 @@ type ConfigSchema struct {
 	P2PListenPort                              uint16                        `env:"P2P_LISTEN_PORT"`
 	P2PNetworkingStack                         ocrnetworking.NetworkingStack `env:"P2P_NETWORKING_STACK" default:"V1"`
 	P2PPeerID                                  p2pkey.PeerID                 `env:"P2P_PEER_ID"`
+	P2PPeerstoreAddrTTL                        time.Duration                 `env:"P2P_PEERSTORE_ADDR_TTL" default:"0s"`
+	P2PPeerstoreRetention                      time.Duration                 `env:"P2P_PEERSTORE_RETENTION" default:"0s"`
 	P2PPeerstoreWriteInterval                  time.Duration                 `env:"P2P_PEERSTORE_WRITE_INTERVAL" default:"5m"`
 	P2PV2AnnounceAddresses                     []string                      `env:"P2PV2_ANNOUNCE_ADDRESSES"`
 	P2PV2Bootstrappers                         []string                      `env:"P2PV2_BOOTSTRAPPERS"`
@@ -160,6 +170,7 @@ type ConfigSchema struct {
 	ReplayFromBlock                            int64                         `env:"REPLAY_FROM_BLOCK" default:"-1"`
 	RootDir                                    string                        `env:"ROOT" default:"~/.chainlink"`
 	SecureCookies                              bool                          `env:"SECURE_COOKIES" default:"true"`
+	SessionAbsoluteTimeout                     bool                          `env:"SESSION_ABSOLUTE_TIMEOUT" default:"false"`
 	SessionTimeout                             models.Duration               `env:"SESSION_TIMEOUT" default:"15m"`
 	StatsPusherLogging                         string                        `env:"STATS_PUSHER_LOGGING" default:"false"`
 	TLSCertPath                                string                        `env:"TLS_CERT_PATH" `
@@ -22,7 +22,9 @@ func TestConfigSchema(t *testing.T) {
 		"BlockHistoryEstimatorBlockDelay":            "BLOCK_HISTORY_ESTIMATOR_BLOCK_DELAY",
 		"BlockHistoryEstimatorBlockHistorySize":      "BLOCK_HISTORY_ESTIMATOR_BLOCK_HISTORY_SIZE",
 		"BlockHistoryEstimatorTransactionPercentile": "BLOCK_HISTORY_ESTIMATOR_TRANSACTION_PERCENTILE",
+		"BridgeMaxMinimumContractPayment":            "BRIDGE_MAX_MINIMUM_CONTRACT_PAYMENT_LINK_JUELS",
 		"BridgeResponseURL":                          "BRIDGE_RESPONSE_URL",
+		"BridgeURLReachabilityCheck":                 "BRIDGE_URL_REACHABILITY_CHECK",
 		"ChainType":                                  "CHAIN_TYPE",
 		"ClientNodeURL":                              "CLIENT_NODE_URL",
 		"DatabaseBackupDir":                          "DATABASE_BACKUP_DIR",
@@ -91,6 +93,8 @@ func TestConfigSchema(t *testing.T) {
 		"GasUpdaterEnabled":                          "GAS_UPDATER_ENABLED",
 		"GasUpdaterTransactionPercentile":            "GAS_UPDATER_TRANSACTION_PERCENTILE",
 		"GlobalLockRetryInterval":                    "GLOBAL_LOCK_RETRY_INTERVAL",
+		"GraphQLPageDefaultLimit":                    "GRAPHQL_PAGE_DEFAULT_LIMIT",
+		"GraphQLPageMaxLimit":                        "GRAPHQL_PAGE_MAX_LIMIT",
 		"HTTPServerWriteTimeout":                     "HTTP_SERVER_WRITE_TIMEOUT",
 		"InsecureFastScrypt":                         "INSECURE_FAST_SCRYPT",
 		"InsecureSkipVerify":                         "INSECURE_SKIP_VERIFY",
@@ -110,6 +114,8 @@ func TestConfigSchema(t *testing.T) {
 		"LinkContractAddress":                        "LINK_CONTRACT_ADDRESS",
 		"LogLevel":                                   "LOG_LEVEL",
 		"LogSQLMigrations":                           "LOG_SQL_MIGRATIONS",
+		"LogSamplingInitial":                         "LOG_SAMPLING_INITIAL",
+		"LogSamplingThereafter":                      "LOG_SAMPLING_THEREAFTER",
 		"LogSQLStatements":                           "LOG_SQL",
 		"LogToDisk":                                  "LOG_TO_DISK",
 		"LogUnixTS":                                  "LOG_UNIX_TS",
@@ -138,6 +144,8 @@ func TestConfigSchema(t *testing.T) {
 		"OCRSimulateTransactions":                    "OCR_SIMULATE_TRANSACTIONS",
 		"OCRTraceLogging":                            "OCR_TRACE_LOGGING",
 		"OCRTransmitterAddress":                      "OCR_TRANSMITTER_ADDRESS",
+		"ORMConnMaxIdleTime":                         "ORM_CONN_MAX_IDLE_TIME",
+		"ORMConnMaxLifetime":                         "ORM_CONN_MAX_LIFETIME",
 		"ORMMaxIdleConns":                            "ORM_MAX_IDLE_CONNS",
 		"ORMMaxOpenConns":                            "ORM_MAX_OPEN_CONNS",
 		"OptimismGasFees":                            "OPTIMISM_GAS_FEES",
@@ -149,6 +157,8 @@ func TestConfigSchema(t *testing.T) {
 		"P2PListenPort":                              "P2P_LISTEN_PORT",
 		"P2PNetworkingStack":                         "P2P_NETWORKING_STACK",
 		"P2PPeerID":                                  "P2P_PEER_ID",
+		"P2PPeerstoreAddrTTL":                        "P2P_PEERSTORE_ADDR_TTL",
+		"P2PPeerstoreRetention":                      "P2P_PEERSTORE_RETENTION",
 		"P2PPeerstoreWriteInterval":                  "P2P_PEERSTORE_WRITE_INTERVAL",
 		"P2PV2AccountAddresses":                      "P2PV2_ANNOUNCE_ADDRESSES",
 		"P2PV2AnnounceAddresses":                     "P2PV2_ANNOUNCE_ADDRESSES",
@@ -163,6 +173,7 @@ func TestConfigSchema(t *testing.T) {
 		"ReplayFromBlock":                            "REPLAY_FROM_BLOCK",
 		"RootDir":                                    "ROOT",
 		"SecureCookies":                              "SECURE_COOKIES",
+		"SessionAbsoluteTimeout":                     "SESSION_ABSOLUTE_TIMEOUT",
 		"SessionTimeout":                             "SESSION_TIMEOUT",
 		"StatsPusherLogging":                         "STATS_PUSHER_LOGGING",
 		"TLSCertPath":                                "TLS_CERT_PATH",
@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -335,6 +336,8 @@ func NewApplicationWithConfig(t testing.TB, cfg *configtest.TestGeneralConfig, f
 		LogSQLStatements: cfg.LogSQLStatements(),
 		MaxOpenConns:     cfg.ORMMaxOpenConns(),
 		MaxIdleConns:     cfg.ORMMaxIdleConns(),
+		ConnMaxLifetime:  cfg.ORMConnMaxLifetime(),
+		ConnMaxIdleTime:  cfg.ORMConnMaxIdleTime(),
 	})
 	require.NoError(t, err)
 	t.Cleanup(func() { assert.NoError(t, db.Close()) })
@@ -372,7 +375,8 @@ func NewApplicationWithConfig(t testing.TB, cfg *configtest.TestGeneralConfig, f
 		chainORM = evm.NewORM(db)
 	}
 
-	keyStore := keystore.New(db, utils.FastScryptParams, lggr)
+	keyStore, err := keystore.New(db, utils.FastScryptParams, lggr)
+	require.NoError(t, err)
 	chainSet, err := evm.LoadChainSet(evm.ChainSetOpts{
 		ORM:              chainORM,
 		Config:           cfg,
@@ -604,11 +608,23 @@ func (ta *TestApplication) NewAuthenticatingClient(prompter cmd.Prompter) *cmd.C
 
 // NewKeyStore returns a new, unlocked keystore
 func NewKeyStore(t testing.TB, db *sqlx.DB) keystore.Master {
-	keystore := keystore.New(db, utils.FastScryptParams, logger.TestLogger(t))
+	keystore, err := keystore.New(db, utils.FastScryptParams, logger.TestLogger(t))
+	require.NoError(t, err)
 	require.NoError(t, keystore.Unlock(Password))
 	return keystore
 }
 
+// MustPasswordFile copies the password file at path to a file only its owner
+// can access, as keystore.PasswordFromFile requires, and returns its path.
+// Fixtures checked out by git are readable by other users.
+func MustPasswordFile(t testing.TB, path string) string {
+	b, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	private := filepath.Join(t.TempDir(), filepath.Base(path))
+	require.NoError(t, ioutil.WriteFile(private, b, 0600))
+	return private
+}
+
 func ParseJSON(t testing.TB, body io.Reader) models.JSON {
 	t.Helper()
 
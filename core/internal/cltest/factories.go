@@ -108,7 +108,7 @@ func NewBridgeType(t testing.TB, opts BridgeOpts) (*bridges.BridgeTypeAuthentica
 // This is because name is a unique index and identical names used across transactional tests will lock/deadlock
 func MustCreateBridge(t testing.TB, db *sqlx.DB, opts BridgeOpts) (bta *bridges.BridgeTypeAuthentication, bt *bridges.BridgeType) {
 	bta, bt = NewBridgeType(t, opts)
-	orm := bridges.NewORM(db, logger.TestLogger(t))
+	orm := bridges.NewORM(db, nil, logger.TestLogger(t))
 	err := orm.CreateBridgeType(bt)
 	require.NoError(t, err)
 	return bta, bt
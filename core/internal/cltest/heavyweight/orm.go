@@ -48,6 +48,8 @@ func FullTestDB(t *testing.T, name string, migrate bool, loadFixtures bool) (*co
 		LogSQLStatements: gcfg.LogSQLStatements(),
 		MaxOpenConns:     gcfg.ORMMaxOpenConns(),
 		MaxIdleConns:     gcfg.ORMMaxIdleConns(),
+		ConnMaxLifetime:  gcfg.ORMConnMaxLifetime(),
+		ConnMaxIdleTime:  gcfg.ORMConnMaxIdleTime(),
 	})
 	require.NoError(t, err)
 	t.Cleanup(func() {
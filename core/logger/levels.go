@@ -0,0 +1,99 @@
+package logger
+
+import (
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// levelOverrides holds the log levels set with SetLevel, keyed by logger
+// name. They take precedence over the level of the root logger, and are
+// shared by all connected Loggers.
+type levelOverrides struct {
+	mu     sync.RWMutex
+	levels map[string]zapcore.Level
+}
+
+func newLevelOverrides() *levelOverrides {
+	return &levelOverrides{levels: make(map[string]zapcore.Level)}
+}
+
+func (o *levelOverrides) set(name string, lvl zapcore.Level) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.levels[name] = lvl
+}
+
+// level returns the level of the logger called loggerName. An override for a
+// name matching a run of whole segments of loggerName applies, e.g.
+// "PeerStore" matches "OCR.PeerStore" and "OCR.PeerStore.Worker". If several
+// match, the rightmost, most specific one wins. Otherwise the level is def.
+func (o *levelOverrides) level(loggerName string, def zapcore.Level) zapcore.Level {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	if len(o.levels) == 0 {
+		return def
+	}
+	lvl, end := def, -1
+	segments := "." + loggerName + "."
+	for name, l := range o.levels {
+		i := strings.LastIndex(segments, "."+name+".")
+		if i < 0 {
+			continue
+		}
+		if e := i + len(name); e > end {
+			lvl, end = l, e
+		}
+	}
+	return lvl
+}
+
+// min returns the lowest of def and the overridden levels
+func (o *levelOverrides) min(def zapcore.Level) zapcore.Level {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	lvl := def
+	for _, l := range o.levels {
+		if l < lvl {
+			lvl = l
+		}
+	}
+	return lvl
+}
+
+// overrideCore filters entries by the level of the logger they were written
+// to, as determined by overrides, instead of by a single level.
+type overrideCore struct {
+	zapcore.Core
+	level     zap.AtomicLevel
+	overrides *levelOverrides
+}
+
+func (c *overrideCore) Enabled(lvl zapcore.Level) bool {
+	return lvl >= c.overrides.min(c.level.Level())
+}
+
+func (c *overrideCore) With(fields []zapcore.Field) zapcore.Core {
+	return &overrideCore{c.Core.With(fields), c.level, c.overrides}
+}
+
+func (c *overrideCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if ent.Level < c.overrides.level(ent.LoggerName, c.level.Level()) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
+
+// buildZap builds cfg into a zap.Logger whose level can be overridden per
+// logger name by overrides.
+func buildZap(cfg zap.Config, overrides *levelOverrides) (*zap.Logger, error) {
+	level := cfg.Level
+	// The underlying core lets everything through, leaving it to overrideCore
+	// to filter
+	cfg.Level = zap.NewAtomicLevelAt(zapcore.DebugLevel)
+	return cfg.Build(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &overrideCore{core, level, overrides}
+	}))
+}
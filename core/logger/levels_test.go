@@ -0,0 +1,82 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogger_SetLevel(t *testing.T) {
+	lggr := TestLogger(t)
+	lggr.SetLogLevel(zapcore.InfoLevel)
+	logs := MemoryLogTestingOnly()
+
+	// Created before the override
+	peerStore := lggr.Named("PeerStore")
+
+	lggr.SetLevel("PeerStore", zapcore.DebugLevel)
+	lggr.SetLevel("BridgeORM", zapcore.ErrorLevel)
+
+	peerStore.Debug("peerstore debug before")
+	lggr.Named("OCR").Named("PeerStore").Debug("peerstore debug after")
+	peerStore.Named("Worker").Debug("peerstore worker debug")
+	lggr.Named("Other").Debug("other debug")
+	lggr.Named("Other").Info("other info")
+	lggr.Named("PeerStoreish").Debug("similar name debug")
+
+	bridgeORM := lggr.Named("BridgeORM")
+	bridgeORM.Warn("bridge orm warn")
+	bridgeORM.Error("bridge orm error")
+
+	require.Contains(t, logs.String(), "peerstore debug before")
+	require.Contains(t, logs.String(), "peerstore debug after")
+	require.Contains(t, logs.String(), "peerstore worker debug")
+	require.NotContains(t, logs.String(), "other debug")
+	require.Contains(t, logs.String(), "other info")
+	require.NotContains(t, logs.String(), "similar name debug")
+	require.NotContains(t, logs.String(), "bridge orm warn")
+	require.Contains(t, logs.String(), "bridge orm error")
+
+	t.Run("applies to new root loggers", func(t *testing.T) {
+		root, err := lggr.NewRootLogger(zapcore.InfoLevel)
+		require.NoError(t, err)
+		root.Named("PeerStore").Debug("root peerstore debug")
+		require.Contains(t, logs.String(), "root peerstore debug")
+	})
+
+	t.Run("is not shared with unconnected loggers", func(t *testing.T) {
+		other := TestLogger(t)
+		other.SetLogLevel(zapcore.InfoLevel)
+		other.Named("PeerStore").Debug("unconnected peerstore debug")
+		require.NotContains(t, logs.String(), "unconnected peerstore debug")
+	})
+}
+
+func TestLevelOverrides_Level(t *testing.T) {
+	o := newLevelOverrides()
+	o.set("OCR", zapcore.ErrorLevel)
+	o.set("PeerStore", zapcore.DebugLevel)
+	o.set("a.b", zapcore.WarnLevel)
+
+	tests := []struct {
+		name string
+		want zapcore.Level
+	}{
+		{"", zapcore.InfoLevel},
+		{"Other", zapcore.InfoLevel},
+		{"OCR", zapcore.ErrorLevel},
+		{"OCR.Other", zapcore.ErrorLevel},
+		{"OCR.PeerStore", zapcore.DebugLevel},
+		{"PeerStore.OCR", zapcore.ErrorLevel},
+		{"PeerStoreish", zapcore.InfoLevel},
+		{"x.a.b.c", zapcore.WarnLevel},
+		{"a.bc", zapcore.InfoLevel},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.want, o.level(test.name, zapcore.InfoLevel), test.name)
+	}
+
+	assert.Equal(t, zapcore.DebugLevel, o.min(zapcore.InfoLevel))
+}
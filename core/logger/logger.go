@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"math"
 	"os"
 
 	"github.com/fatih/color"
@@ -44,6 +45,13 @@ type Logger interface {
 	// SetLogLevel changes the log level for this and all connected Loggers.
 	SetLogLevel(zapcore.Level)
 
+	// SetLevel overrides the log level of the Loggers named name, and of
+	// their sub-loggers, for this and all connected Loggers, whether they are
+	// created before or after the call.
+	//   l.SetLevel("PeerStore", zapcore.DebugLevel)
+	//   l.Named("OCR").Named("PeerStore").Debug("logged")
+	SetLevel(name string, lvl zapcore.Level)
+
 	Debug(args ...interface{})
 	Info(args ...interface{})
 	Warn(args ...interface{})
@@ -83,23 +91,29 @@ var _ Logger = &zapLogger{}
 
 type zapLogger struct {
 	*zap.SugaredLogger
-	config zap.Config
-	name   string
-	fields []interface{}
+	config    zap.Config
+	name      string
+	fields    []interface{}
+	overrides *levelOverrides
 }
 
 func newZapLogger(cfg zap.Config) (Logger, error) {
-	zl, err := cfg.Build()
+	overrides := newLevelOverrides()
+	zl, err := buildZap(cfg, overrides)
 	if err != nil {
 		return nil, err
 	}
-	return &zapLogger{config: cfg, SugaredLogger: zl.Sugar()}, nil
+	return &zapLogger{config: cfg, SugaredLogger: zl.Sugar(), overrides: overrides}, nil
 }
 
 func (l *zapLogger) SetLogLevel(lvl zapcore.Level) {
 	l.config.Level.SetLevel(lvl)
 }
 
+func (l *zapLogger) SetLevel(name string, lvl zapcore.Level) {
+	l.overrides.set(name, lvl)
+}
+
 // Constants for service names for package specific logging configuration
 const (
 	HeadTracker = "head_tracker"
@@ -122,6 +136,13 @@ func (l *zapLogger) With(args ...interface{}) Logger {
 	return &newLogger
 }
 
+// With returns a child of l that adds the key/value pairs kvs to every entry
+// it logs, so that context like a job ID need only be bound once rather than
+// passed on every call.
+func With(l Logger, kvs ...interface{}) Logger {
+	return l.With(kvs...)
+}
+
 // copyFields returns a copy of fields with add appended.
 func copyFields(fields []interface{}, add ...interface{}) []interface{} {
 	f := make([]interface{}, 0, len(fields)+len(add))
@@ -147,7 +168,7 @@ func (l *zapLogger) Named(name string) Logger {
 func (l *zapLogger) NewRootLogger(lvl zapcore.Level) (Logger, error) {
 	newLogger := *l
 	newLogger.config.Level = zap.NewAtomicLevelAt(lvl)
-	zl, err := newLogger.config.Build()
+	zl, err := buildZap(newLogger.config, l.overrides)
 	if err != nil {
 		return nil, err
 	}
@@ -215,18 +236,36 @@ type Config interface {
 	LogToDisk() bool
 	LogLevel() zapcore.Level
 	LogUnixTimestamps() bool
+	LogSamplingInitial() uint32
+	LogSamplingThereafter() uint32
 }
 
 // NewLogger returns a new Logger configured by c with pretty printing to stdout.
 // If LogToDisk is false, the Logger will only log to stdout.
 // Tests should use TestLogger instead.
 func NewLogger(c Config) Logger {
-	return newLogger(c.LogLevel(), c.RootDir(), c.JSONConsole(), c.LogToDisk(), c.LogUnixTimestamps())
+	sampling := newSamplingConfig(c.LogSamplingInitial(), c.LogSamplingThereafter())
+	return newLogger(c.LogLevel(), c.RootDir(), c.JSONConsole(), c.LogToDisk(), c.LogUnixTimestamps(), sampling)
+}
+
+// newSamplingConfig returns a config logging the first initial identical
+// entries each second, and every thereafter-th one after that. Sampling is
+// disabled if initial is zero.
+func newSamplingConfig(initial, thereafter uint32) *zap.SamplingConfig {
+	if initial == 0 {
+		return nil
+	}
+	if thereafter == 0 {
+		// zap does not accept zero, so sample too rarely to ever log
+		thereafter = math.MaxInt32
+	}
+	return &zap.SamplingConfig{Initial: int(initial), Thereafter: int(thereafter)}
 }
 
-func newLogger(logLevel zapcore.Level, dir string, jsonConsole bool, toDisk bool, unixTS bool) Logger {
+func newLogger(logLevel zapcore.Level, dir string, jsonConsole bool, toDisk bool, unixTS bool, sampling *zap.SamplingConfig) Logger {
 	cfg := newProductionConfig(dir, jsonConsole, toDisk, unixTS)
 	cfg.Level.SetLevel(logLevel)
+	cfg.Sampling = sampling
 	l, err := newZapLogger(cfg)
 	if err != nil {
 		log.Fatal(err)
@@ -1,13 +1,76 @@
 package logger
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func TestNoSampling(t *testing.T) {
 	assert.Nil(t, newBaseConfig().Sampling)
 	assert.Nil(t, newTestConfig().Sampling)
 	assert.Nil(t, newProductionConfig("", false, true, false).Sampling)
+	assert.Nil(t, newSamplingConfig(0, 10))
+}
+
+func TestSampling(t *testing.T) {
+	tests := []struct {
+		initial, thereafter uint32
+		logged              int
+	}{
+		// messages 1-3, 13 and 23
+		{3, 10, 5},
+		{1, 1, 25},
+		// only the first message, the rest are dropped
+		{1, 0, 1},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("initial %d thereafter %d", test.initial, test.thereafter), func(t *testing.T) {
+			cfg := newTestConfig()
+			cfg.Sampling = newSamplingConfig(test.initial, test.thereafter)
+			lggr, err := newZapLogger(cfg)
+			require.NoError(t, err)
+
+			msg := fmt.Sprintf("Bridge unreachable %s", t.Name())
+			for i := 0; i < 25; i++ {
+				lggr.Errorw(msg, "bridge", "foo")
+			}
+			lggr.Errorw("A different message " + t.Name())
+
+			logs := MemoryLogTestingOnly().String()
+			assert.Equal(t, test.logged, strings.Count(logs, msg))
+			assert.Contains(t, logs, "A different message "+t.Name())
+		})
+	}
+}
+
+func TestWith(t *testing.T) {
+	core, observed := observer.New(zapcore.DebugLevel)
+	lggr := &zapLogger{SugaredLogger: zap.New(core).Sugar()}
+
+	child := With(lggr.Named("OCRDB"), "oracleSpecID", int32(42), "configDigest", "abc")
+	child.Infow("Read state", "epoch", 1)
+	child.Named("Sub").Warn("Sub-logger entry")
+	lggr.Info("Parent entry")
+
+	entries := observed.AllUntimed()
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, "Read state", entries[0].Message)
+	assert.Equal(t, "OCRDB", entries[0].LoggerName)
+	assert.Equal(t, map[string]interface{}{"oracleSpecID": int32(42), "configDigest": "abc", "epoch": int64(1)}, entries[0].ContextMap())
+
+	assert.Equal(t, "OCRDB.Sub", entries[1].LoggerName)
+	assert.Equal(t, map[string]interface{}{"oracleSpecID": int32(42), "configDigest": "abc"}, entries[1].ContextMap())
+
+	// The parent is unaffected
+	assert.Empty(t, entries[2].ContextMap())
 }
@@ -0,0 +1,42 @@
+package logger
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// ReloadLevelOnSIGHUP calls level each time the process receives a SIGHUP,
+// until ctx is done, and sets the result as the log level of each of lggrs.
+// The level is shared with all Loggers Named or With'd from them, so this
+// changes their level too, without rebuilding any of them.
+func ReloadLevelOnSIGHUP(ctx context.Context, level func() (zapcore.Level, error), lggrs ...Logger) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGHUP)
+	go func() {
+		defer signal.Stop(ch)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ch:
+				reloadLevel(level, lggrs...)
+			}
+		}
+	}()
+}
+
+func reloadLevel(level func() (zapcore.Level, error), lggrs ...Logger) {
+	lvl, err := level()
+	for _, l := range lggrs {
+		if err != nil {
+			l.Errorw("Failed to reload log level, keeping the current one", "err", err)
+			continue
+		}
+		l.SetLogLevel(lvl)
+		l.Infow("Reloaded log level", "level", lvl)
+	}
+}
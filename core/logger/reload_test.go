@@ -0,0 +1,69 @@
+//go:build !windows
+// +build !windows
+
+package logger
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestReloadLevelOnSIGHUP(t *testing.T) {
+	lggr := TestLogger(t)
+	lggr.SetLogLevel(zapcore.InfoLevel)
+	logs := MemoryLogTestingOnly()
+	named := lggr.Named("Reloaded")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	reloaded := make(chan struct{}, 1)
+	ReloadLevelOnSIGHUP(ctx, func() (zapcore.Level, error) {
+		defer func() { reloaded <- struct{}{} }()
+		return zapcore.DebugLevel, nil
+	}, lggr)
+
+	lggr.Debug("root debug before")
+	named.Debug("named debug before")
+
+	require.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGHUP))
+	select {
+	case <-reloaded:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for SIGHUP to be handled")
+	}
+	// The level is set after level returns
+	require.Eventually(t, func() bool {
+		return lggr.(*zapLogger).config.Level.Level() == zapcore.DebugLevel
+	}, 5*time.Second, 10*time.Millisecond)
+
+	lggr.Debug("root debug after")
+	named.Debug("named debug after")
+	named.Named("Child").Debug("new child debug after")
+
+	assert.NotContains(t, logs.String(), "root debug before")
+	assert.NotContains(t, logs.String(), "named debug before")
+	assert.Contains(t, logs.String(), "root debug after")
+	assert.Contains(t, logs.String(), "named debug after")
+	assert.Contains(t, logs.String(), "new child debug after")
+}
+
+func TestReloadLevel_Error(t *testing.T) {
+	lggr := TestLogger(t)
+	lggr.SetLogLevel(zapcore.InfoLevel)
+	logs := MemoryLogTestingOnly()
+
+	reloadLevel(func() (zapcore.Level, error) {
+		return zapcore.DebugLevel, errors.New("invalid LOG_LEVEL")
+	}, lggr)
+
+	lggr.Debug("debug after failed reload")
+	assert.Contains(t, logs.String(), "Failed to reload log level")
+	assert.NotContains(t, logs.String(), "debug after failed reload")
+}
@@ -1,42 +1,106 @@
 package main
 
 import (
+	"context"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/pkg/errors"
+	clipkg "github.com/urfave/cli"
 
 	"github.com/smartcontractkit/chainlink/core/cmd"
 	"github.com/smartcontractkit/chainlink/core/config"
 	"github.com/smartcontractkit/chainlink/core/logger"
-	"github.com/smartcontractkit/chainlink/core/sessions"
 )
 
+const (
+	exitCodeOK = 0
+	// exitCodeError is returned when the command fails
+	exitCodeError = 1
+	// exitCodeForcedShutdown is returned when the command does not return
+	// within shutdownGracePeriod of being asked to shut down
+	exitCodeForcedShutdown = 2
+)
+
+// shutdownGracePeriod bounds how long a command has to flush and return once
+// a shutdown signal is received. It is longer than the time the application
+// itself allows its services to stop.
+var shutdownGracePeriod = 30 * time.Second
+
 func main() {
-	Run(NewProductionClient(), os.Args...)
+	os.Exit(Run(NewProductionClient(cmd.ProfileFromArgs(os.Args[1:])), os.Args...))
 }
 
-// Run runs the CLI, providing further command instructions by default.
-func Run(client *cmd.Client, args ...string) {
-	app := cmd.NewApp(client)
-	client.Logger.ErrorIf(app.Run(args), "Error running app")
+// Run runs the CLI, providing further command instructions by default, and
+// returns the process exit code.
+func Run(client *cmd.Client, args ...string) int {
+	return runApp(client, cmd.NewApp(client), args)
+}
+
+// runApp runs app until it returns. A SIGINT or SIGTERM cancels the client's
+// RootCtx, after which the command has shutdownGracePeriod to clean up and
+// return before it is abandoned; a second signal terminates the process
+// immediately.
+func runApp(client *cmd.Client, app *clipkg.App, args []string) int {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	client.RootCtx = ctx
+
+	done := make(chan error, 1)
+	go func() {
+		done <- app.Run(args)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		// Restore the default behaviour so that a second signal kills the process
+		stop()
+		client.Logger.Infof("Shutting down, waiting up to %s for the command to return", shutdownGracePeriod)
+		select {
+		case err = <-done:
+		case <-time.After(shutdownGracePeriod):
+			client.Logger.Errorf("Command did not return within %s of shutdown, forcing exit", shutdownGracePeriod)
+			return exitCodeForcedShutdown
+		}
+	}
+
+	if err != nil {
+		client.Logger.ErrorIf(err, "Error running app")
+		return exitCodeError
+	}
+	return exitCodeOK
 }
 
 // NewProductionClient configures an instance of the CLI to be used
-// in production.
-func NewProductionClient() *cmd.Client {
-	cfg := config.NewGeneralConfig()
+// in production. If profile is not empty, the remote node URL, credentials
+// and session cookie of that profile are used.
+func NewProductionClient(profile string) *cmd.Client {
+	var cfg config.GeneralConfig = config.NewGeneralConfig()
 	lggr := logger.NewLogger(cfg)
 
+	cookieStore := cmd.DiskCookieStore{Config: cfg}
+	if profile != "" {
+		p, err := cmd.LoadProfile(cfg.RootDir(), profile)
+		if err != nil {
+			lggr.Fatalw("Error loading CLI profile", "error", err, "profile", profile)
+		}
+		cfg = p.Apply(cfg)
+		cookieStore.File = p.CookieFile
+	}
+
 	prompter := cmd.NewTerminalPrompter()
-	cookieAuth := cmd.NewSessionCookieAuthenticator(cfg, cmd.DiskCookieStore{Config: cfg}, lggr)
-	sr := sessions.SessionRequest{}
+	cookieAuth := cmd.NewSessionCookieAuthenticator(cfg, cookieStore, lggr)
 	sessionRequestBuilder := cmd.NewFileSessionRequestBuilder(lggr)
-	if credentialsFile := cfg.AdminCredentialsFile(); credentialsFile != "" {
-		var err error
-		sr, err = sessionRequestBuilder.Build(credentialsFile)
-		if err != nil && errors.Cause(err) != cmd.ErrNoCredentialFile && !os.IsNotExist(err) {
-			lggr.Fatalw("Error loading API credentials", "error", err, "credentialsFile", credentialsFile)
-		}
+	// Credentials from CHAINLINK_EMAIL/CHAINLINK_PASSWORD take precedence over
+	// the credentials file
+	credentialsFile := cfg.AdminCredentialsFile()
+	sr, err := cmd.NewEnvSessionRequestBuilder(sessionRequestBuilder, lggr).Build(credentialsFile)
+	if err != nil && errors.Cause(err) != cmd.ErrNoCredentialFile && !os.IsNotExist(err) {
+		lggr.Fatalw("Error loading API credentials", "error", err, "credentialsFile", credentialsFile)
 	}
 	return &cmd.Client{
 		Renderer:                       cmd.RendererTable{Writer: os.Stdout},
@@ -4,9 +4,17 @@
 package main
 
 import (
+	"fmt"
 	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clipkg "github.com/urfave/cli"
 	"gopkg.in/guregu/null.v4"
 
 	"github.com/smartcontractkit/chainlink/core/cmd"
@@ -32,6 +40,98 @@ func run(args ...string) {
 	Run(testClient, args...)
 }
 
+func TestNewProductionClient_Profiles(t *testing.T) {
+	rootDir := t.TempDir()
+	t.Setenv("ROOT", rootDir)
+	t.Setenv("CLIENT_NODE_URL", "http://default:6688")
+
+	profiles := `
+[prod-eth]
+url = "https://prod-eth:6688"
+
+[staging]
+url = "https://staging:6688"
+cookie = "%s"
+`
+	stagingCookie := filepath.Join(rootDir, "staging-cookie")
+	require.NoError(t, ioutil.WriteFile(filepath.Join(rootDir, cmd.ProfilesFileName), []byte(fmt.Sprintf(profiles, stagingCookie)), 0600))
+
+	// Each profile gets its own session, identified by the cookie value
+	cookies := map[string]string{
+		filepath.Join(rootDir, "cookie"):          "default",
+		filepath.Join(rootDir, "cookie.prod-eth"): "prod-eth",
+		stagingCookie:                             "staging",
+	}
+	for file, session := range cookies {
+		require.NoError(t, ioutil.WriteFile(file, []byte("clsession="+session), 0600))
+	}
+
+	tests := []struct {
+		name, profile, wantURL, wantSession string
+	}{
+		{"no profile", "", "http://default:6688", "default"},
+		{"default cookie file", "prod-eth", "https://prod-eth:6688", "prod-eth"},
+		{"explicit cookie file", "staging", "https://staging:6688", "staging"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := NewProductionClient(test.profile)
+			assert.Equal(t, test.wantURL, client.Config.ClientNodeURL())
+
+			cookie, err := client.CookieAuthenticator.Cookie()
+			require.NoError(t, err)
+			require.NotNil(t, cookie)
+			assert.Equal(t, test.wantSession, cookie.Value)
+		})
+	}
+}
+
+func TestRunApp_Shutdown(t *testing.T) {
+	// Not parallel: the signal is delivered to the whole process
+
+	// newApp returns an app whose command signals the process, then waits for
+	// the root context to be cancelled before running cleanup
+	newApp := func(t *testing.T, client *cmd.Client, cleanup func()) *clipkg.App {
+		app := clipkg.NewApp()
+		app.Commands = []clipkg.Command{{
+			Name: "run",
+			Action: func(c *clipkg.Context) error {
+				assert.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGTERM))
+				<-client.RootCtx.Done()
+				cleanup()
+				return nil
+			},
+		}}
+		return app
+	}
+
+	t.Run("cancels the root context and waits for cleanup", func(t *testing.T) {
+		client := &cmd.Client{Logger: logger.TestLogger(t)}
+		var cleanedUp bool
+		app := newApp(t, client, func() { cleanedUp = true })
+
+		code := runApp(client, app, []string{"", "run"})
+		assert.Equal(t, exitCodeOK, code)
+		assert.Error(t, client.RootCtx.Err())
+		assert.True(t, cleanedUp)
+	})
+
+	t.Run("forces exit when cleanup overruns the grace period", func(t *testing.T) {
+		defer func(d time.Duration) { shutdownGracePeriod = d }(shutdownGracePeriod)
+		shutdownGracePeriod = 100 * time.Millisecond
+
+		client := &cmd.Client{Logger: logger.TestLogger(t)}
+		release := make(chan struct{})
+		defer close(release)
+		app := newApp(t, client, func() { <-release })
+
+		code := runApp(client, app, []string{"", "run"})
+		assert.Equal(t, exitCodeForcedShutdown, code)
+		assert.Error(t, client.RootCtx.Err())
+	})
+}
+
 func ExampleRun() {
 	run("--help")
 	run("--version")
@@ -60,9 +160,10 @@ func ExampleRun() {
 	//    help, h         Shows a list of commands or help for one command
 	//
 	// GLOBAL OPTIONS:
-	//    --json, -j     json output as opposed to table
-	//    --help, -h     show help
-	//    --version, -v  print the version
+	//    --json, -j       json output as opposed to table
+	//    --profile value  name of the remote node profile in $ROOT/profiles.toml to use [$CHAINLINK_PROFILE]
+	//    --help, -h       show help
+	//    --version, -v    print the version
 	// core.test version unset@unset
 }
 
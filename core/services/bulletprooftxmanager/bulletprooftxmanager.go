@@ -147,7 +147,7 @@ func (b *BulletproofTxManager) Start() (merr error) {
 			b.logger.Warnf("Chain %s does not have any eth keys, no transactions will be sent on this chain", b.chainID.String())
 		}
 
-		eb := NewEthBroadcaster(b.db, b.ethClient, b.config, b.keyStore, b.eventBroadcaster, keyStates, b.gasEstimator, b.resumeCallback, b.logger)
+		eb := NewEthBroadcaster(b.db, b.ethClient, b.config, b.keyStore, b.eventBroadcaster, enabledKeyStates(keyStates), b.gasEstimator, b.resumeCallback, b.logger)
 		ec := NewEthConfirmer(b.db, b.ethClient, b.config, b.keyStore, keyStates, b.gasEstimator, b.resumeCallback, b.logger)
 		if err := eb.Start(); err != nil {
 			return errors.Wrap(err, "BulletproofTxManager: EthBroadcaster failed to start")
@@ -223,7 +223,7 @@ func (b *BulletproofTxManager) runLoop(eb *EthBroadcaster, ec *EthConfirmer) {
 			b.logger.ErrorIfClosing(eb, "EthBroadcaster")
 			b.logger.ErrorIfClosing(ec, "EthConfirmer")
 
-			eb = NewEthBroadcaster(b.db, b.ethClient, b.config, b.keyStore, b.eventBroadcaster, keyStates, b.gasEstimator, b.resumeCallback, b.logger)
+			eb = NewEthBroadcaster(b.db, b.ethClient, b.config, b.keyStore, b.eventBroadcaster, enabledKeyStates(keyStates), b.gasEstimator, b.resumeCallback, b.logger)
 			ec = NewEthConfirmer(b.db, b.ethClient, b.config, b.keyStore, keyStates, b.gasEstimator, b.resumeCallback, b.logger)
 
 			if err := eb.Start(); err != nil {
@@ -236,6 +236,18 @@ func (b *BulletproofTxManager) runLoop(eb *EthBroadcaster, ec *EthConfirmer) {
 	}
 }
 
+// enabledKeyStates filters out disabled keys. The EthBroadcaster does not send
+// new transactions from disabled keys, but the EthConfirmer keeps confirming
+// the transactions they already sent.
+func enabledKeyStates(keyStates []ethkey.State) (enabled []ethkey.State) {
+	for _, state := range keyStates {
+		if !state.Disabled {
+			enabled = append(enabled, state)
+		}
+	}
+	return enabled
+}
+
 // OnNewLongestChain conforms to HeadTrackable
 func (b *BulletproofTxManager) OnNewLongestChain(ctx context.Context, head eth.Head) {
 	ok := b.IfStarted(func() {
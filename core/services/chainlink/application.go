@@ -130,8 +130,10 @@ type ChainlinkApplication struct {
 }
 
 type ApplicationOpts struct {
-	Config                   config.GeneralConfig
-	EventBroadcaster         postgres.EventBroadcaster
+	Config           config.GeneralConfig
+	EventBroadcaster postgres.EventBroadcaster
+	// ShutdownSignal, if set, stops the application and exits the process
+	// when it fires. Leave it unset if the caller manages shutdown itself.
 	ShutdownSignal           shutdown.Signal
 	SqlxDB                   *sqlx.DB
 	KeyStore                 keystore.Master
@@ -194,10 +196,10 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 
 	var (
 		pipelineORM    = pipeline.NewORM(db, globalLogger)
-		bridgeORM      = bridges.NewORM(db, globalLogger)
-		sessionORM     = sessions.NewORM(db, cfg.SessionTimeout().Duration(), globalLogger)
-		pipelineRunner = pipeline.NewRunner(pipelineORM, cfg, chainSet, keyStore.Eth(), keyStore.VRF(), globalLogger)
 		jobORM         = job.NewORM(db, chainSet, pipelineORM, keyStore, globalLogger)
+		bridgeORM      = bridges.NewORM(db, jobORM, globalLogger)
+		sessionORM     = sessions.NewORM(db, cfg.SessionTimeout().Duration(), cfg.SessionAbsoluteTimeout(), globalLogger)
+		pipelineRunner = pipeline.NewRunner(pipelineORM, cfg, chainSet, keyStore.Eth(), keyStore.VRF(), globalLogger)
 		bptxmORM       = bulletprooftxmanager.NewORM(db, globalLogger)
 	)
 
@@ -356,9 +358,9 @@ func (app *ChainlinkApplication) SetServiceLogLevel(ctx context.Context, service
 	return logger.NewORM(app.GetSqlxDB(), app.GetLogger()).SetServiceLogLevel(ctx, serviceName, level.String())
 }
 
-// Start all necessary services. If successful, nil will be returned.  Also
-// listens for interrupt signals from the operating system so that the
-// application can be properly closed before the application exits.
+// Start all necessary services. If successful, nil will be returned. If the
+// application has a shutdown signal, it also waits on it so that the
+// application can be properly closed before the process exits.
 func (app *ChainlinkApplication) Start() error {
 	app.startStopMu.Lock()
 	defer app.startStopMu.Unlock()
@@ -366,11 +368,13 @@ func (app *ChainlinkApplication) Start() error {
 		panic("application is already started")
 	}
 
-	go func() {
-		<-app.shutdownSignal.Wait()
-		app.logger.ErrorIf(app.Stop(), "Error stopping application")
-		app.Exiter(0)
-	}()
+	if app.shutdownSignal != nil {
+		go func() {
+			<-app.shutdownSignal.Wait()
+			app.logger.ErrorIf(app.Stop(), "Error stopping application")
+			app.Exiter(0)
+		}()
+	}
 
 	if app.FeedsService != nil {
 		if err := app.FeedsService.Start(); err != nil {
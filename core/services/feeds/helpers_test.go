@@ -1,7 +1,15 @@
 package feeds
 
+import "github.com/smartcontractkit/chainlink/core/services/postgres"
+
 // SetConnectionsManager allows us to manually set the connections manager.
 // Only used for testing.
 func (s *service) SetConnectionsManager(cm ConnectionsManager) {
 	s.connMgr = cm
 }
+
+// AllowUnknownQueryer lets the service run its transactions on a mock
+// Queryer. Only used for testing.
+func (s *service) AllowUnknownQueryer() {
+	s.txOpts = []postgres.TxOptions{postgres.OptAllowUnknownQueryer()}
+}
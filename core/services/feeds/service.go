@@ -72,6 +72,8 @@ type service struct {
 	chainSet    evm.ChainSet
 	lggr        logger.Logger
 	version     string
+	// txOpts are passed to every transaction of the service
+	txOpts []postgres.TxOptions
 }
 
 // NewService constructs a new feeds service
@@ -393,7 +395,7 @@ func (s *service) ApproveJobProposal(ctx context.Context, id int64) error {
 		}
 
 		return nil
-	})
+	}, s.txOpts...)
 	if err != nil {
 		return errors.Wrap(err, "could not approve job proposal")
 	}
@@ -428,7 +430,7 @@ func (s *service) RejectJobProposal(ctx context.Context, id int64) error {
 		}
 
 		return nil
-	})
+	}, s.txOpts...)
 	if err != nil {
 		return errors.Wrap(err, "could not reject job proposal")
 	}
@@ -481,7 +483,7 @@ func (s *service) CancelJobProposal(ctx context.Context, id int64) error {
 		}
 
 		return nil
-	})
+	}, s.txOpts...)
 	if err != nil {
 		return err
 	}
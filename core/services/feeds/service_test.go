@@ -23,7 +23,6 @@ import (
 	"github.com/smartcontractkit/chainlink/core/services/keystore/keys/csakey"
 	"github.com/smartcontractkit/chainlink/core/services/keystore/keys/ethkey"
 	ksmocks "github.com/smartcontractkit/chainlink/core/services/keystore/mocks"
-	"github.com/smartcontractkit/chainlink/core/services/postgres"
 	pgmocks "github.com/smartcontractkit/chainlink/core/services/postgres/mocks"
 	"github.com/smartcontractkit/chainlink/core/services/versioning"
 	"github.com/smartcontractkit/chainlink/core/store/models"
@@ -37,11 +36,6 @@ import (
 	"gopkg.in/guregu/null.v4"
 )
 
-func init() {
-	// AllowUnknownQueryerTypeInTransaction allows us to pass mocks in place of a real *sqlx.DB or *sqlx.Tx
-	postgres.AllowUnknownQueryerTypeInTransaction = true
-}
-
 const TestSpec = `
 type              = "fluxmonitor"
 schemaVersion     = 1
@@ -116,6 +110,7 @@ func setupTestService(t *testing.T) *TestService {
 	keyStore.On("P2P").Return(p2pKeystore)
 	svc := feeds.NewService(orm, jobORM, queryer, spawner, keyStore, cfg, cc, logger.TestLogger(t), "1.0.0")
 	svc.SetConnectionsManager(connMgr)
+	svc.AllowUnknownQueryer()
 
 	return &TestService{
 		Service:     svc,
@@ -155,6 +155,29 @@ func (_m *ORM) FindJobIDsWithBridge(name string) ([]int32, error) {
 	return r0, r1
 }
 
+// FindJobIDsWithBridges provides a mock function with given fields: names
+func (_m *ORM) FindJobIDsWithBridges(names []string) (map[string][]int32, error) {
+	ret := _m.Called(names)
+
+	var r0 map[string][]int32
+	if rf, ok := ret.Get(0).(func([]string) map[string][]int32); ok {
+		r0 = rf(names)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]int32)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(names)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindJobTx provides a mock function with given fields: id
 func (_m *ORM) FindJobTx(id int32) (job.Job, error) {
 	ret := _m.Called(id)
@@ -43,6 +43,7 @@ type ORM interface {
 	FindJob(ctx context.Context, id int32) (Job, error)
 	FindJobByExternalJobID(ctx context.Context, uuid uuid.UUID) (Job, error)
 	FindJobIDsWithBridge(name string) ([]int32, error)
+	FindJobIDsWithBridges(names []string) (map[string][]int32, error)
 	DeleteJob(id int32, qopts ...postgres.QOpt) error
 	RecordError(jobID int32, description string, qopts ...postgres.QOpt)
 	DismissError(ctx context.Context, errorID int32) error
@@ -91,7 +92,7 @@ func (o *orm) CreateJob(jb *Job, qopts ...postgres.QOpt) error {
 			// Bridge must exist
 			name := task.(*pipeline.BridgeTask).Name
 
-			sql := `SELECT EXISTS(SELECT 1 FROM bridge_types WHERE name = $1);`
+			sql := `SELECT EXISTS(SELECT 1 FROM bridge_types WHERE name = $1 AND deleted_at IS NULL);`
 			var exists bool
 			err := q.Get(&exists, sql, name)
 			if err != nil {
@@ -545,44 +546,56 @@ func (o *orm) findJob(jb *Job, col string, arg interface{}, qopts ...postgres.QO
 	return o.LoadEnvConfigVars(jb)
 }
 
-func (o *orm) FindJobIDsWithBridge(name string) (jids []int32, err error) {
+func (o *orm) FindJobIDsWithBridge(name string) ([]int32, error) {
+	jids, err := o.FindJobIDsWithBridges([]string{name})
+	return jids[name], errors.Wrap(err, "FindJobIDsWithBridge failed")
+}
+
+// FindJobIDsWithBridges returns the IDs of the jobs whose pipelines call each
+// of the named bridges, keyed by bridge name, in a single query. Bridges that
+// no job calls are absent from the map.
+func (o *orm) FindJobIDsWithBridges(names []string) (jids map[string][]int32, err error) {
+	jids = make(map[string][]int32)
+	if len(names) == 0 {
+		return jids, nil
+	}
+	wanted := make(map[string]bool, len(names))
+	patterns := make([]string, len(names))
+	for i, name := range names {
+		wanted[name] = true
+		patterns[i] = "%" + name + "%"
+	}
 	err = postgres.SqlxTransactionWithDefaultCtx(o.db, o.lggr, func(tx postgres.Queryer) error {
-		query := `SELECT jobs.id, dot_dag_source FROM jobs JOIN pipeline_specs ON pipeline_specs.id = jobs.pipeline_spec_id WHERE dot_dag_source ILIKE '%' || $1 || '%' ORDER BY id`
-		var rows *sqlx.Rows
-		rows, err = tx.Queryx(query, name)
-		if err != nil {
-			return err
+		var jobs []struct {
+			ID           int32
+			DotDagSource string
 		}
-		defer rows.Close()
-		var ids []int32
-		var sources []string
-		for rows.Next() {
-			var id int32
-			var source string
-			if err = rows.Scan(&id, &source); err != nil {
-				return err
-			}
-			ids = append(jids, id)
-			sources = append(sources, source)
+		query := `SELECT jobs.id, dot_dag_source FROM jobs JOIN pipeline_specs ON pipeline_specs.id = jobs.pipeline_spec_id WHERE dot_dag_source ILIKE ANY($1) ORDER BY id`
+		if err = tx.Select(&jobs, query, patterns); err != nil {
+			return err
 		}
 
-		for i, id := range ids {
+		for _, jb := range jobs {
 			var p *pipeline.Pipeline
-			p, err = pipeline.Parse(sources[i])
+			p, err = pipeline.Parse(jb.DotDagSource)
 			if err != nil {
-				return errors.Wrapf(err, "could not parse dag for job %d", id)
+				return errors.Wrapf(err, "could not parse dag for job %d", jb.ID)
 			}
+			calls := make(map[string]bool)
 			for _, task := range p.Tasks {
-				if task.Type() == pipeline.TaskTypeBridge {
-					if task.(*pipeline.BridgeTask).Name == name {
-						jids = append(jids, id)
-					}
+				if task.Type() != pipeline.TaskTypeBridge {
+					continue
+				}
+				name := task.(*pipeline.BridgeTask).Name
+				if wanted[name] && !calls[name] {
+					calls[name] = true
+					jids[name] = append(jids[name], jb.ID)
 				}
 			}
 		}
 		return nil
 	})
-	return jids, errors.Wrap(err, "FindJobIDsWithBridge failed")
+	return jids, errors.Wrap(err, "FindJobIDsWithBridges failed")
 }
 
 // PipelineRunsByJobsIDs returns pipeline runs for multiple jobs, not preloading data
@@ -2,6 +2,7 @@ package keystore
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/smartcontractkit/chainlink/core/services/keystore/keys/csakey"
@@ -14,6 +15,7 @@ var ErrCSAKeyExists = errors.New("CSA key does not exist")
 // type CSAKeystoreInterface interface {
 type CSA interface {
 	Get(id string) (csakey.KeyV2, error)
+	GetByPublicKey(pubKeyHex string) (csakey.KeyV2, error)
 	GetAll() ([]csakey.KeyV2, error)
 	Create() (csakey.KeyV2, error)
 	Add(key csakey.KeyV2) error
@@ -45,6 +47,22 @@ func (ks *csa) Get(id string) (csakey.KeyV2, error) {
 	return ks.getByID(id)
 }
 
+// GetByPublicKey returns the CSA key with the given hex encoded public key,
+// with or without a 0x prefix.
+func (ks *csa) GetByPublicKey(pubKeyHex string) (csakey.KeyV2, error) {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+	if ks.isLocked() {
+		return csakey.KeyV2{}, ErrLocked
+	}
+	// The ID of a CSA key is its hex encoded public key
+	key, found := ks.keyRing.CSA[normalizePublicKeyHex(pubKeyHex)]
+	if !found {
+		return csakey.KeyV2{}, KeyNotFoundError{ID: pubKeyHex, KeyType: "CSA"}
+	}
+	return key, nil
+}
+
 func (ks *csa) GetAll() (keys []csakey.KeyV2, _ error) {
 	ks.lock.RLock()
 	defer ks.lock.RUnlock()
@@ -153,3 +171,9 @@ func (ks *csa) getByID(id string) (csakey.KeyV2, error) {
 	}
 	return key, nil
 }
+
+// normalizePublicKeyHex lowercases a hex encoded public key and strips any 0x
+// prefix, matching the encoding used for key IDs
+func normalizePublicKeyHex(pubKeyHex string) string {
+	return strings.ToLower(strings.TrimPrefix(pubKeyHex, "0x"))
+}
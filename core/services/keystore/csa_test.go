@@ -1,6 +1,7 @@
 package keystore_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
@@ -44,6 +45,29 @@ func Test_CSAKeyStore_E2E(t *testing.T) {
 		require.Equal(t, key, retrievedKey)
 	})
 
+	t.Run("gets a key by public key", func(t *testing.T) {
+		defer reset()
+		key, err := ks.Create()
+		require.NoError(t, err)
+		retrievedKey, err := ks.GetByPublicKey(key.PublicKeyString())
+		require.NoError(t, err)
+		require.Equal(t, key, retrievedKey)
+		retrievedKey, err = ks.GetByPublicKey("0x" + strings.ToUpper(key.PublicKeyString()))
+		require.NoError(t, err)
+		require.Equal(t, key, retrievedKey)
+	})
+
+	t.Run("errors when getting non-existant public key", func(t *testing.T) {
+		defer reset()
+		_, err := ks.GetByPublicKey("deadbeef")
+		require.Equal(t, keystore.KeyNotFoundError{ID: "deadbeef", KeyType: "CSA"}, err)
+	})
+
+	t.Run("requires unlock to get a key by public key", func(t *testing.T) {
+		_, err := keystore.ExposedNewMaster(t, db).CSA().GetByPublicKey("deadbeef")
+		require.Equal(t, keystore.ErrLocked, err)
+	})
+
 	t.Run("imports and exports a key", func(t *testing.T) {
 		defer reset()
 		key, err := ks.Create()
@@ -6,6 +6,7 @@ import (
 	"sort"
 	"sync"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -31,11 +32,16 @@ type Eth interface {
 	SubscribeToKeyChanges() (ch chan struct{}, unsub func())
 
 	SignTx(fromAddress common.Address, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+	NewSigner(chainID *big.Int) (bind.SignerFn, error)
 
 	SendingKeys() (keys []ethkey.KeyV2, err error)
 	FundingKeys() (keys []ethkey.KeyV2, err error)
+	EnabledKeysForChain(chainID *big.Int) (keys []ethkey.KeyV2, err error)
 	GetRoundRobinAddress(addresses ...common.Address) (address common.Address, err error)
 
+	Enable(address common.Address, chainID *big.Int) error
+	Disable(address common.Address, chainID *big.Int) error
+
 	GetState(id string) (ethkey.State, error)
 	SetState(ethkey.State) error
 	GetStatesForKeys([]ethkey.KeyV2) ([]ethkey.State, error)
@@ -164,6 +170,9 @@ func (ks *eth) EnsureKeys(chainID *big.Int) (
 	return sendingKey, sendDidExist, fundingKey, fundDidExist, nil
 }
 
+// Import decrypts keyJSON, an encrypted go-ethereum keystore file, and adds
+// the key for chainID. Importing a key that already exists for chainID returns
+// it unchanged.
 func (ks *eth) Import(keyJSON []byte, password string, chainID *big.Int) (ethkey.KeyV2, error) {
 	ks.lock.Lock()
 	defer ks.lock.Unlock()
@@ -175,8 +184,13 @@ func (ks *eth) Import(keyJSON []byte, password string, chainID *big.Int) (ethkey
 		return ethkey.KeyV2{}, errors.Wrap(err, "EthKeyStore#ImportKey failed to decrypt key")
 	}
 	key := ethkey.FromPrivateKey(dKey.PrivateKey)
-	if _, found := ks.keyRing.Eth[key.ID()]; found {
-		return ethkey.KeyV2{}, fmt.Errorf("key with ID %s already exists", key.ID())
+	if existing, found := ks.keyRing.Eth[key.ID()]; found {
+		// Like Migrate, importing a key that is already in the key ring is a
+		// no-op, as long as it is for the same chain
+		if state, exists := ks.keyStates.Eth[key.ID()]; exists && !state.EVMChainID.Equal(utils.NewBig(chainID)) {
+			return ethkey.KeyV2{}, fmt.Errorf("key with ID %s already exists for chain %s", key.ID(), state.EVMChainID.String())
+		}
+		return existing, nil
 	}
 	err = ks.add(key, chainID)
 	if err != nil {
@@ -186,6 +200,8 @@ func (ks *eth) Import(keyJSON []byte, password string, chainID *big.Int) (ethkey
 	return key, nil
 }
 
+// Export returns the key with the given ID, i.e. its address, as an encrypted
+// go-ethereum keystore file.
 func (ks *eth) Export(id string, password string) ([]byte, error) {
 	ks.lock.RLock()
 	defer ks.lock.RUnlock()
@@ -251,6 +267,41 @@ func (ks *eth) SignTx(address common.Address, tx *types.Transaction, chainID *bi
 	return types.SignTx(tx, signer, key.ToEcdsaPrivKey())
 }
 
+// NewSigner returns a bind.SignerFn that signs transactions for chainID with
+// the keys in the keystore, so that abigen bindings can use them directly via
+// bind.TransactOpts. Signing fails for keys which are unknown, pegged to
+// another chain or disabled.
+func (ks *eth) NewSigner(chainID *big.Int) (bind.SignerFn, error) {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+	if ks.isLocked() {
+		return nil, ErrLocked
+	}
+	signer := types.LatestSignerForChainID(chainID)
+	return func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		ks.lock.RLock()
+		defer ks.lock.RUnlock()
+		if ks.isLocked() {
+			return nil, ErrLocked
+		}
+		key, err := ks.getByID(address.Hex())
+		if err != nil {
+			return nil, err
+		}
+		state, exists := ks.keyStates.Eth[key.ID()]
+		if !exists {
+			return nil, errors.Errorf("state not found for eth key ID %s", key.ID())
+		}
+		if !state.EVMChainID.Equal(utils.NewBig(chainID)) {
+			return nil, errors.Errorf("eth key %s is not pegged to chain %s", address.Hex(), chainID.String())
+		}
+		if state.Disabled {
+			return nil, errors.Errorf("eth key %s is disabled", address.Hex())
+		}
+		return types.SignTx(tx, signer, key.ToEcdsaPrivKey())
+	}, nil
+}
+
 func (ks *eth) SendingKeys() (sendingKeys []ethkey.KeyV2, err error) {
 	ks.lock.RLock()
 	defer ks.lock.RUnlock()
@@ -269,6 +320,24 @@ func (ks *eth) FundingKeys() (fundingKeys []ethkey.KeyV2, err error) {
 	return ks.fundingKeys(), nil
 }
 
+// EnabledKeysForChain returns the sending and funding keys pegged to chainID
+// that have not been disabled, sorted by address.
+func (ks *eth) EnabledKeysForChain(chainID *big.Int) (keys []ethkey.KeyV2, err error) {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+	if ks.isLocked() {
+		return nil, ErrLocked
+	}
+	for _, k := range ks.keyRing.Eth {
+		state := ks.keyStates.Eth[k.ID()]
+		if state.EVMChainID.Equal(utils.NewBig(chainID)) && !state.Disabled {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Cmp(keys[j]) < 0 })
+	return keys, nil
+}
+
 func (ks *eth) GetRoundRobinAddress(whitelist ...common.Address) (common.Address, error) {
 	ks.lock.Lock()
 	defer ks.lock.Unlock()
@@ -278,9 +347,9 @@ func (ks *eth) GetRoundRobinAddress(whitelist ...common.Address) (common.Address
 
 	var keys []ethkey.KeyV2
 	if len(whitelist) == 0 {
-		keys = ks.sendingKeys()
+		keys = ks.enabledSendingKeys()
 	} else if len(whitelist) > 0 {
-		for _, k := range ks.sendingKeys() {
+		for _, k := range ks.enabledSendingKeys() {
 			for _, addr := range whitelist {
 				if addr == k.Address.Address() {
 					keys = append(keys, k)
@@ -302,6 +371,39 @@ func (ks *eth) GetRoundRobinAddress(whitelist ...common.Address) (common.Address
 	return leastRecentlyUsed.Address.Address(), nil
 }
 
+// Enable re-enables a key previously disabled on the given chain.
+func (ks *eth) Enable(address common.Address, chainID *big.Int) error {
+	return ks.setDisabled(address, chainID, false)
+}
+
+// Disable stops the key from being used to send transactions on the given
+// chain, without deleting it. Disabled keys are still listed.
+func (ks *eth) Disable(address common.Address, chainID *big.Int) error {
+	return ks.setDisabled(address, chainID, true)
+}
+
+func (ks *eth) setDisabled(address common.Address, chainID *big.Int, disabled bool) error {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	if ks.isLocked() {
+		return ErrLocked
+	}
+	state, exists := ks.keyStates.Eth[address.Hex()]
+	if !exists {
+		return errors.Errorf("state not found for eth key ID %s", address.Hex())
+	}
+	if !state.EVMChainID.Equal(utils.NewBig(chainID)) {
+		return errors.Errorf("eth key %s is not pegged to chain %s", address.Hex(), chainID.String())
+	}
+	sql := `UPDATE eth_key_states SET disabled = $1, updated_at = NOW() WHERE address = $2;`
+	if _, err := ks.orm.db.Exec(sql, disabled, state.Address); err != nil {
+		return errors.Wrap(err, "setDisabled#Exec failed")
+	}
+	state.Disabled = disabled
+	ks.notify()
+	return nil
+}
+
 func (ks *eth) GetState(id string) (ethkey.State, error) {
 	ks.lock.RLock()
 	defer ks.lock.RUnlock()
@@ -327,7 +429,7 @@ func (ks *eth) SetState(state ethkey.State) error {
 		return errors.Errorf("key not found with ID %s", state.KeyID())
 	}
 	ks.keyStates.Eth[state.KeyID()] = &state
-	sql := `UPDATE eth_key_states SET address = :address, next_nonce = :next_nonce, is_funding = :is_funding, evm_chain_id = :evm_chain_id, updated_at = NOW()
+	sql := `UPDATE eth_key_states SET address = :address, next_nonce = :next_nonce, is_funding = :is_funding, disabled = :disabled, evm_chain_id = :evm_chain_id, updated_at = NOW()
 	WHERE address = :address;`
 	_, err := ks.orm.db.NamedExec(sql, state)
 	return errors.Wrap(err, "SetState#Exec failed")
@@ -412,6 +514,16 @@ func (ks *eth) sendingKeys() (sendingKeys []ethkey.KeyV2) {
 	return sendingKeys
 }
 
+// caller must hold lock!
+func (ks *eth) enabledSendingKeys() (sendingKeys []ethkey.KeyV2) {
+	for _, k := range ks.sendingKeys() {
+		if !ks.keyStates.Eth[k.ID()].Disabled {
+			sendingKeys = append(sendingKeys, k)
+		}
+	}
+	return sendingKeys
+}
+
 // caller must hold lock!
 func (ks *eth) add(key ethkey.KeyV2, chainID *big.Int) error {
 	return ks.addEthKeyWithState(key, ethkey.State{EVMChainID: *utils.NewBig(chainID)})
@@ -421,8 +533,8 @@ func (ks *eth) add(key ethkey.KeyV2, chainID *big.Int) error {
 func (ks *eth) addEthKeyWithState(key ethkey.KeyV2, state ethkey.State) error {
 	state.Address = key.Address
 	return ks.safeAddKey(key, func(tx postgres.Queryer) error {
-		sql := `INSERT INTO eth_key_states (address, next_nonce, is_funding, evm_chain_id, created_at, updated_at)
-VALUES (:address, :next_nonce, :is_funding, :evm_chain_id, NOW(), NOW())
+		sql := `INSERT INTO eth_key_states (address, next_nonce, is_funding, disabled, evm_chain_id, created_at, updated_at)
+VALUES (:address, :next_nonce, :is_funding, :disabled, :evm_chain_id, NOW(), NOW())
 RETURNING *;`
 		if err := postgres.NewQ(ks.orm.db).GetNamed(sql, &state, state); err != nil {
 			return errors.Wrap(err, "failed to insert eth_key_state")
@@ -3,9 +3,11 @@ package keystore_test
 import (
 	"fmt"
 	"math/big"
+	"sort"
 	"testing"
 	"time"
 
+	gethkeystore "github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
@@ -173,6 +175,110 @@ func Test_EthKeyStore_GetRoundRobinAddress(t *testing.T) {
 	})
 }
 
+func Test_EthKeyStore_EnableDisable(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+
+	keyStore := cltest.NewKeyStore(t, db)
+	ethKeyStore := keyStore.Eth()
+
+	k1, _ := cltest.MustInsertRandomKey(t, ethKeyStore)
+	k2, _ := cltest.MustInsertRandomKey(t, ethKeyStore)
+	address := k1.Address.Address()
+
+	assertDisabled := func(t *testing.T, disabled bool) {
+		state, err := ethKeyStore.GetState(k1.ID())
+		require.NoError(t, err)
+		require.Equal(t, disabled, state.Disabled)
+
+		var persisted bool
+		require.NoError(t, db.Get(&persisted, `SELECT disabled FROM eth_key_states WHERE address = $1`, k1.Address))
+		require.Equal(t, disabled, persisted)
+	}
+
+	t.Run("disables a key", func(t *testing.T) {
+		require.NoError(t, ethKeyStore.Disable(address, &cltest.FixtureChainID))
+		assertDisabled(t, true)
+
+		// The key is still listed
+		keys, err := ethKeyStore.SendingKeys()
+		require.NoError(t, err)
+		require.Len(t, keys, 2)
+
+		// but not used to send transactions
+		for i := 0; i < 3; i++ {
+			next, err := ethKeyStore.GetRoundRobinAddress()
+			require.NoError(t, err)
+			require.Equal(t, k2.Address.Address(), next)
+		}
+		_, err = ethKeyStore.GetRoundRobinAddress(address)
+		require.EqualError(t, err, "no keys available")
+	})
+
+	t.Run("persists across restarts", func(t *testing.T) {
+		reloaded := keystore.ExposedNewMaster(t, db)
+		require.NoError(t, reloaded.Unlock(cltest.Password))
+		state, err := reloaded.Eth().GetState(k1.ID())
+		require.NoError(t, err)
+		require.True(t, state.Disabled)
+	})
+
+	t.Run("enables a key", func(t *testing.T) {
+		require.NoError(t, ethKeyStore.Enable(address, &cltest.FixtureChainID))
+		assertDisabled(t, false)
+
+		next, err := ethKeyStore.GetRoundRobinAddress(address)
+		require.NoError(t, err)
+		require.Equal(t, address, next)
+	})
+
+	t.Run("errors for a key on another chain", func(t *testing.T) {
+		err := ethKeyStore.Disable(address, big.NewInt(1337))
+		require.EqualError(t, err, fmt.Sprintf("eth key %s is not pegged to chain 1337", k1.Address.Hex()))
+		assertDisabled(t, false)
+	})
+
+	t.Run("errors for an unknown key", func(t *testing.T) {
+		err := ethKeyStore.Disable(cltest.NewAddress(), &cltest.FixtureChainID)
+		require.Error(t, err)
+	})
+}
+
+func Test_EthKeyStore_EnabledKeysForChain(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+
+	keyStore := cltest.NewKeyStore(t, db)
+	ethKeyStore := keyStore.Eth()
+
+	otherChainID := big.NewInt(1337)
+	k1, _ := cltest.MustInsertRandomKey(t, ethKeyStore)
+	k2, _ := cltest.MustInsertRandomKey(t, ethKeyStore, true)
+	k3, _ := cltest.MustInsertRandomKey(t, ethKeyStore)
+	k4, _ := cltest.MustInsertRandomKey(t, ethKeyStore, *utils.NewBig(otherChainID))
+	require.NoError(t, ethKeyStore.Disable(k3.Address.Address(), &cltest.FixtureChainID))
+
+	keys, err := ethKeyStore.EnabledKeysForChain(&cltest.FixtureChainID)
+	require.NoError(t, err)
+	expected := []ethkey.KeyV2{k1, k2}
+	sort.Slice(expected, func(i, j int) bool { return expected[i].Cmp(expected[j]) < 0 })
+	require.Equal(t, expected, keys)
+
+	keys, err = ethKeyStore.EnabledKeysForChain(otherChainID)
+	require.NoError(t, err)
+	require.Equal(t, []ethkey.KeyV2{k4}, keys)
+
+	keys, err = ethKeyStore.EnabledKeysForChain(big.NewInt(42))
+	require.NoError(t, err)
+	require.Empty(t, keys)
+
+	locked := keystore.ExposedNewMaster(t, db)
+	_, err = locked.Eth().EnabledKeysForChain(&cltest.FixtureChainID)
+	require.Equal(t, keystore.ErrLocked, err)
+}
+
 func Test_EthKeyStore_SignTx(t *testing.T) {
 	db := pgtest.NewSqlxDB(t)
 	keyStore := cltest.NewKeyStore(t, db)
@@ -193,6 +299,56 @@ func Test_EthKeyStore_SignTx(t *testing.T) {
 	require.NotEqual(t, tx, signed)
 }
 
+func Test_EthKeyStore_NewSigner(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+	keyStore := cltest.NewKeyStore(t, db)
+	ethKeyStore := keyStore.Eth()
+
+	chainID := &cltest.FixtureChainID
+	k1, _ := cltest.MustInsertRandomKey(t, ethKeyStore)
+	k2, _ := cltest.MustInsertRandomKey(t, ethKeyStore, *utils.NewBig(big.NewInt(1337)))
+	k3, _ := cltest.MustInsertRandomKey(t, ethKeyStore)
+	require.NoError(t, ethKeyStore.Disable(k3.Address.Address(), chainID))
+
+	signerFn, err := ethKeyStore.NewSigner(chainID)
+	require.NoError(t, err)
+
+	tx := types.NewTransaction(0, cltest.NewAddress(), big.NewInt(53), 21000, big.NewInt(1000000000), []byte{1, 2, 3, 4})
+
+	t.Run("signs with the key of the address", func(t *testing.T) {
+		signed, err := signerFn(k1.Address.Address(), tx)
+		require.NoError(t, err)
+
+		sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+		require.NoError(t, err)
+		require.Equal(t, k1.Address.Address(), sender)
+	})
+
+	t.Run("errors for an unknown key", func(t *testing.T) {
+		randomAddress := cltest.NewAddress()
+		_, err := signerFn(randomAddress, tx)
+		require.EqualError(t, err, fmt.Sprintf("unable to find eth key with id %s", randomAddress.Hex()))
+	})
+
+	t.Run("errors for a key on another chain", func(t *testing.T) {
+		_, err := signerFn(k2.Address.Address(), tx)
+		require.EqualError(t, err, fmt.Sprintf("eth key %s is not pegged to chain %s", k2.Address.Hex(), chainID.String()))
+	})
+
+	t.Run("errors for a disabled key", func(t *testing.T) {
+		_, err := signerFn(k3.Address.Address(), tx)
+		require.EqualError(t, err, fmt.Sprintf("eth key %s is disabled", k3.Address.Hex()))
+	})
+
+	t.Run("errors when the keystore is locked", func(t *testing.T) {
+		locked := keystore.ExposedNewMaster(t, db)
+		_, err := locked.Eth().NewSigner(chainID)
+		require.Equal(t, keystore.ErrLocked, err)
+	})
+}
+
 func Test_EthKeyStore_E2E(t *testing.T) {
 	db := pgtest.NewSqlxDB(t)
 
@@ -273,6 +429,54 @@ func Test_EthKeyStore_E2E(t *testing.T) {
 	})
 }
 
+func Test_EthKeyStore_ImportExport(t *testing.T) {
+	t.Parallel()
+
+	src := cltest.NewKeyStore(t, pgtest.NewSqlxDB(t)).Eth()
+	dst := cltest.NewKeyStore(t, pgtest.NewSqlxDB(t)).Eth()
+
+	key, err := src.Create(&cltest.FixtureChainID)
+	require.NoError(t, err)
+	exportJSON, err := src.Export(key.ID(), "export password")
+	require.NoError(t, err)
+
+	t.Run("exports go-ethereum keystore JSON", func(t *testing.T) {
+		dKey, err := gethkeystore.DecryptKey(exportJSON, "export password")
+		require.NoError(t, err)
+		require.Equal(t, key.Address.Address(), dKey.Address)
+	})
+
+	t.Run("imports into another keystore", func(t *testing.T) {
+		_, err := dst.Import(exportJSON, "wrong password", &cltest.FixtureChainID)
+		require.Error(t, err)
+
+		importedKey, err := dst.Import(exportJSON, "export password", &cltest.FixtureChainID)
+		require.NoError(t, err)
+		require.Equal(t, key.ID(), importedKey.ID())
+		require.Equal(t, key.ToEcdsaPrivKey(), importedKey.ToEcdsaPrivKey())
+
+		state, err := dst.GetState(key.ID())
+		require.NoError(t, err)
+		require.Equal(t, cltest.FixtureChainID.String(), state.EVMChainID.String())
+	})
+
+	t.Run("importing an existing key is a no-op", func(t *testing.T) {
+		importedKey, err := dst.Import(exportJSON, "export password", &cltest.FixtureChainID)
+		require.NoError(t, err)
+		require.Equal(t, key.ID(), importedKey.ID())
+
+		keys, err := dst.GetAll()
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+	})
+
+	t.Run("cannot import an existing key for another chain", func(t *testing.T) {
+		_, err := dst.Import(exportJSON, "export password", big.NewInt(1337))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "already exists for chain 0")
+	})
+}
+
 func Test_EthKeyStore_SubscribeToKeyChanges(t *testing.T) {
 	chDone := make(chan struct{})
 	defer func() { close(chDone) }()
@@ -20,7 +20,9 @@ func mustNewEthKey(t *testing.T) *ethkey.KeyV2 {
 type ExportedEncryptedKeyRing = encryptedKeyRing
 
 func ExposedNewMaster(t *testing.T, db *sqlx.DB) *master {
-	return newMaster(db, utils.FastScryptParams, logger.TestLogger(t))
+	ks, err := newMaster(db, utils.FastScryptParams, logger.TestLogger(t))
+	require.NoError(t, err)
+	return ks
 }
 
 func (m *master) ExportedSave() error {
@@ -11,6 +11,7 @@ type State struct {
 	Address    EIP55Address
 	NextNonce  int64
 	IsFunding  bool
+	Disabled   bool
 	EVMChainID utils.Big
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
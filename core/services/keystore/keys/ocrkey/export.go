@@ -10,8 +10,13 @@ import (
 
 const keyTypeIdentifier = "OCR"
 
+// FromEncryptedJSON decrypts an exported OCR key. Only the crypto section is
+// read, so exports from older nodes with non-checksummed public fields still
+// import.
 func FromEncryptedJSON(keyJSON []byte, password string) (KeyV2, error) {
-	var export EncryptedOCRKeyExport
+	var export struct {
+		Crypto keystore.CryptoJSON `json:"crypto"`
+	}
 	if err := json.Unmarshal(keyJSON, &export); err != nil {
 		return KeyV2{}, err
 	}
@@ -1,13 +1,13 @@
 package ocrkey
 
 import (
+	"bytes"
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/pkg/errors"
 	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting/types"
 )
@@ -21,6 +21,16 @@ func (ocsa OnChainSigningAddress) String() string {
 	return fmt.Sprintf("%s%s", onChainSigningAddressPrefix, address)
 }
 
+// Equal reports whether both addresses refer to the same 20 byte address.
+func (ocsa OnChainSigningAddress) Equal(other OnChainSigningAddress) bool {
+	return ocsa == other
+}
+
+// Less orders addresses by their underlying bytes, for deterministic sorting.
+func (ocsa OnChainSigningAddress) Less(other OnChainSigningAddress) bool {
+	return bytes.Compare(ocsa[:], other[:]) < 0
+}
+
 func (ocsa OnChainSigningAddress) MarshalJSON() ([]byte, error) {
 	return json.Marshal(ocsa.String())
 }
@@ -33,20 +43,23 @@ func (ocsa *OnChainSigningAddress) UnmarshalJSON(input []byte) error {
 	return ocsa.UnmarshalText([]byte(hexString))
 }
 
+// UnmarshalText parses an "ocrsad_" prefixed address, rejecting any address
+// that is not in its EIP-55 checksummed form.
 func (ocsa *OnChainSigningAddress) UnmarshalText(bs []byte) error {
 	input := string(bs)
-	if strings.HasPrefix(input, onChainSigningAddressPrefix) {
-		input = string(bs[len(onChainSigningAddressPrefix):])
+	if !strings.HasPrefix(input, onChainSigningAddressPrefix) {
+		return errors.Errorf(`"%s" is not a valid on-chain signing address: missing "%s" prefix`, input, onChainSigningAddressPrefix)
 	}
-
-	result, err := hexutil.Decode(input)
-	if err != nil {
-		return err
+	hexAddress := strings.TrimPrefix(input, onChainSigningAddressPrefix)
+	if !common.IsHexAddress(hexAddress) || !strings.HasPrefix(hexAddress, "0x") {
+		return errors.Errorf(`"%s" is not a valid on-chain signing address: expected 0x-prefixed 20 byte hex address`, input)
+	}
+	address := common.HexToAddress(hexAddress)
+	if address.Hex() != hexAddress {
+		return errors.Errorf(`"%s" is not a valid on-chain signing address: "%s" is not EIP-55 checksummed, expected "%s"`, input, hexAddress, address.Hex())
 	}
 
-	var onChainSigningAddress common.Address
-	copy(onChainSigningAddress[:], result[:common.AddressLength])
-	*ocsa = OnChainSigningAddress(onChainSigningAddress)
+	*ocsa = OnChainSigningAddress(address)
 	return nil
 }
 
@@ -1,9 +1,11 @@
 package ocrkey_test
 
 import (
-	"github.com/stretchr/testify/require"
+	"sort"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/smartcontractkit/chainlink/core/services/keystore/keys/ocrkey"
 )
 
@@ -17,3 +19,77 @@ func TestOCR_OnChainSigningAddress_String(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, ocrSigningKey, address.String())
 }
+
+func TestOCR_OnChainSigningAddress_UnmarshalText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		input     string
+		wantError string
+	}{
+		{"checksummed", "ocrsad_0x30762A700F7d836528dfB14DD60Ec2A3aEaA7694", ""},
+		{"all lowercase", "ocrsad_0x30762a700f7d836528dfb14dd60ec2a3aeaa7694", "is not EIP-55 checksummed"},
+		{"missing prefix", "0x30762A700F7d836528dfB14DD60Ec2A3aEaA7694", `missing "ocrsad_" prefix`},
+		{"malformed prefix", "ocrsad0x30762A700F7d836528dfB14DD60Ec2A3aEaA7694", `missing "ocrsad_" prefix`},
+		{"missing 0x", "ocrsad_30762A700F7d836528dfB14DD60Ec2A3aEaA7694", "expected 0x-prefixed 20 byte hex address"},
+		{"too short", "ocrsad_0x30762A700F7d836528dfB14DD60Ec2A3aEaA76", "expected 0x-prefixed 20 byte hex address"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var address ocrkey.OnChainSigningAddress
+			err := address.UnmarshalText([]byte(test.input))
+			if test.wantError == "" {
+				require.NoError(t, err)
+				require.Equal(t, test.input, address.String())
+			} else {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), test.wantError)
+			}
+		})
+	}
+}
+
+func TestOCR_OnChainSigningAddress_Equal(t *testing.T) {
+	t.Parallel()
+
+	var a, b, c ocrkey.OnChainSigningAddress
+	require.NoError(t, a.UnmarshalText([]byte("ocrsad_0x30762A700F7d836528dfB14DD60Ec2A3aEaA7694")))
+	require.NoError(t, b.UnmarshalText([]byte("ocrsad_0x30762A700F7d836528dfB14DD60Ec2A3aEaA7694")))
+	require.NoError(t, c.UnmarshalText([]byte("ocrsad_0x2Ed5B18b62DACd7a85B6ED19247eA718BDae6114")))
+
+	require.True(t, a.Equal(b))
+	require.True(t, b.Equal(a))
+	require.False(t, a.Equal(c))
+	require.False(t, c.Equal(a))
+}
+
+func TestOCR_OnChainSigningAddress_Less(t *testing.T) {
+	t.Parallel()
+
+	inputs := []string{
+		"ocrsad_0x30762A700F7d836528dfB14DD60Ec2A3aEaA7694",
+		"ocrsad_0xFFfFfFffFFfffFFfFFfFFFFFffFFFffffFfFFFfF",
+		"ocrsad_0x0000000000000000000000000000000000000001",
+		"ocrsad_0x2Ed5B18b62DACd7a85B6ED19247eA718BDae6114",
+	}
+	addresses := make([]ocrkey.OnChainSigningAddress, len(inputs))
+	for i, input := range inputs {
+		require.NoError(t, addresses[i].UnmarshalText([]byte(input)))
+	}
+
+	sort.Slice(addresses, func(i, j int) bool { return addresses[i].Less(addresses[j]) })
+
+	var sorted []string
+	for _, address := range addresses {
+		sorted = append(sorted, address.String())
+	}
+	require.Equal(t, []string{
+		"ocrsad_0x0000000000000000000000000000000000000001",
+		"ocrsad_0x2Ed5B18b62DACd7a85B6ED19247eA718BDae6114",
+		"ocrsad_0x30762A700F7d836528dfB14DD60Ec2A3aEaA7694",
+		"ocrsad_0xFFfFfFffFFfffFFfFFfFFFFFffFFFffffFfFFFfF",
+	}, sorted)
+	require.False(t, addresses[0].Less(addresses[0]))
+}
@@ -0,0 +1,44 @@
+package keystore
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// keyManagerLock guards a keyManager. Once the keystore is exported with
+// ExportLockWaits, it also records how long each writer waits to acquire it.
+type keyManagerLock struct {
+	sync.RWMutex
+	// waits is only set and read while the write lock is held
+	waits prometheus.Observer
+}
+
+// Lock acquires the write lock, recording how long it waited for it
+func (l *keyManagerLock) Lock() {
+	start := time.Now()
+	l.RWMutex.Lock()
+	if l.waits != nil {
+		l.waits.Observe(time.Since(start).Seconds())
+	}
+}
+
+// ExportLockWaits registers a histogram with registerer of how long callers
+// wait to acquire the keystore's write lock. It is held for every change to
+// the key ring and while the key ring is saved, so long waits mean that
+// callers, such as the transaction manager, are serialized by the keystore.
+func (ks *master) ExportLockWaits(registerer prometheus.Registerer) error {
+	waits := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "keystore_lock_wait_seconds",
+		Help:    "Time spent waiting to acquire the keystore write lock",
+		Buckets: prometheus.ExponentialBuckets(0.0001, 4, 10),
+	})
+	if err := registerer.Register(waits); err != nil {
+		return err
+	}
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	ks.lock.waits = waits
+	return nil
+}
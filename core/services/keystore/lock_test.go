@@ -0,0 +1,66 @@
+package keystore
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+func TestMaster_ExportLockWaits(t *testing.T) {
+	t.Parallel()
+
+	ks, err := newMaster(nil, utils.FastScryptParams, logger.TestLogger(t))
+	require.NoError(t, err)
+	registry := prometheus.NewRegistry()
+	require.NoError(t, ks.ExportLockWaits(registry))
+	require.Error(t, ks.ExportLockWaits(registry), "registering twice should fail")
+
+	waits := func() (count uint64, sum float64) {
+		families, err := registry.Gather()
+		require.NoError(t, err)
+		require.Len(t, families, 1)
+		assert.Equal(t, "keystore_lock_wait_seconds", families[0].GetName())
+		h := families[0].GetMetric()[0].GetHistogram()
+		return h.GetSampleCount(), h.GetSampleSum()
+	}
+	count, _ := waits()
+	require.Equal(t, uint64(0), count)
+
+	// Hold the lock while writers and readers pile up behind it
+	const writers = 5
+	const held = 50 * time.Millisecond
+	ks.lock.Lock()
+	var started, wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		started.Add(1)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			started.Done()
+			ks.lock.Lock()
+			defer ks.lock.Unlock()
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ks.lock.RLock()
+		defer ks.lock.RUnlock()
+	}()
+	started.Wait()
+	time.Sleep(held)
+	ks.lock.Unlock()
+	wg.Wait()
+
+	// Only writers are recorded, including the one that held the lock
+	count, sum := waits()
+	assert.Equal(t, uint64(writers+1), count)
+	assert.GreaterOrEqual(t, sum, held.Seconds())
+}
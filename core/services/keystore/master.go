@@ -3,10 +3,15 @@ package keystore
 import (
 	"fmt"
 	"math/big"
+	"os"
+	"path/filepath"
 	"reflect"
-	"sync"
+	"sort"
+	"strings"
 
+	gethkeystore "github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/services/keystore/keys/csakey"
@@ -21,6 +26,10 @@ import (
 
 var ErrLocked = errors.New("Keystore is locked")
 
+// ErrWrongPassword is returned by VerifyPassword if the password does not
+// decrypt the key ring.
+var ErrWrongPassword = errors.New("Keystore password is incorrect")
+
 //go:generate mockery --name Master --output ./mocks/ --case=underscore
 
 type Master interface {
@@ -30,8 +39,48 @@ type Master interface {
 	P2P() P2P
 	VRF() VRF
 	Unlock(password string) error
+	UnlockFromFile(path string) error
+	VerifyPassword(password string) error
 	Migrate(vrfPassword string, chainID *big.Int) error
+	ImportFromFileKeystore(dir, password string, chainID *big.Int) (MigrationReport, error)
 	IsEmpty() (bool, error)
+	Health() error
+	AllKeys() (AllKeysReport, error)
+	ExportLockWaits(registerer prometheus.Registerer) error
+	RepairStates(password string) (RepairReport, error)
+}
+
+// KeyReport identifies a key in an AllKeysReport
+type KeyReport struct {
+	ID string
+	// PublicIdentifier is what the key is known by outside of the node, e.g.
+	// the address of an Eth key or the peer ID of a P2P key
+	PublicIdentifier string
+}
+
+// AllKeysReport lists the keys of each type in the key ring, sorted by ID
+type AllKeysReport struct {
+	CSA []KeyReport
+	Eth []KeyReport
+	OCR []KeyReport
+	P2P []KeyReport
+	VRF []KeyReport
+}
+
+// MigrationReport lists the keys found by a migration, split into those added
+// to the key ring and those skipped as it already had them
+type MigrationReport struct {
+	Added   AllKeysReport
+	Skipped AllKeysReport
+}
+
+// RepairReport lists the IDs of the Eth keys whose states were changed by
+// RepairStates, sorted by ID
+type RepairReport struct {
+	// RemovedStates are states deleted because the key ring has no such key
+	RemovedStates []string
+	// AddedStates are default states created for keys missing one
+	AddedStates []string
 }
 
 type master struct {
@@ -43,15 +92,20 @@ type master struct {
 	vrf *vrf
 }
 
-func New(db *sqlx.DB, scryptParams utils.ScryptParams, lggr logger.Logger) Master {
+// New returns a locked keystore which encrypts keys with scryptParams. It
+// returns an error if scryptParams are too weak, see utils.ScryptParams.
+func New(db *sqlx.DB, scryptParams utils.ScryptParams, lggr logger.Logger) (Master, error) {
 	return newMaster(db, scryptParams, lggr)
 }
 
-func newMaster(db *sqlx.DB, scryptParams utils.ScryptParams, lggr logger.Logger) *master {
+func newMaster(db *sqlx.DB, scryptParams utils.ScryptParams, lggr logger.Logger) (*master, error) {
+	if err := scryptParams.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid scrypt params")
+	}
 	km := &keyManager{
 		orm:          NewORM(db, lggr),
 		scryptParams: scryptParams,
-		lock:         &sync.RWMutex{},
+		lock:         &keyManagerLock{},
 		logger:       lggr.Named("KeyStore"),
 	}
 
@@ -62,7 +116,7 @@ func newMaster(db *sqlx.DB, scryptParams utils.ScryptParams, lggr logger.Logger)
 		ocr:        newOCRKeyStore(km),
 		p2p:        newP2PKeyStore(km),
 		vrf:        newVRFKeyStore(km),
-	}
+	}, nil
 }
 
 func (ks master) CSA() CSA {
@@ -94,6 +148,50 @@ func (ks *master) IsEmpty() (bool, error) {
 	return count == 0, nil
 }
 
+// Health returns ErrLocked if the keystore is locked, or an error if its
+// database is unreachable or a key is missing its state. It returns nil if the
+// keystore is usable.
+func (ks *master) Health() error {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+	if ks.isLocked() {
+		return ErrLocked
+	}
+	if err := ks.orm.ping(); err != nil {
+		return errors.Wrap(err, "keystore database is unreachable")
+	}
+	return ks.keyStates.validate(ks.keyRing)
+}
+
+// AllKeys returns the keys of every type in the unlocked key ring, or
+// ErrLocked if the keystore is locked.
+func (ks *master) AllKeys() (report AllKeysReport, err error) {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+	if ks.isLocked() {
+		return report, ErrLocked
+	}
+	for _, key := range ks.keyRing.CSA {
+		report.CSA = append(report.CSA, KeyReport{key.ID(), key.PublicKeyString()})
+	}
+	for _, key := range ks.keyRing.Eth {
+		report.Eth = append(report.Eth, KeyReport{key.ID(), key.Address.Hex()})
+	}
+	for _, key := range ks.keyRing.OCR {
+		report.OCR = append(report.OCR, KeyReport{key.ID(), key.OnChainSigning.Address().String()})
+	}
+	for _, key := range ks.keyRing.P2P {
+		report.P2P = append(report.P2P, KeyReport{key.ID(), key.PeerID().String()})
+	}
+	for _, key := range ks.keyRing.VRF {
+		report.VRF = append(report.VRF, KeyReport{key.ID(), key.PublicKey.String()})
+	}
+	for _, keys := range [][]KeyReport{report.CSA, report.Eth, report.OCR, report.P2P, report.VRF} {
+		sort.Slice(keys, func(i, j int) bool { return keys[i].ID < keys[j].ID })
+	}
+	return report, nil
+}
+
 func (ks *master) Migrate(vrfPssword string, chainID *big.Int) error {
 	ks.lock.Lock()
 	defer ks.lock.Unlock()
@@ -166,12 +264,71 @@ func (ks *master) Migrate(vrfPssword string, chainID *big.Int) error {
 	return nil
 }
 
+// ImportFromFileKeystore imports the Eth keys in dir, a go-ethereum style file
+// keystore as used by nodes from before keys were stored in the database.
+// Every key file is decrypted with password before any key is imported, so a
+// bad file imports nothing. Like Migrate, keys already in the key ring are
+// skipped, so importing the same directory again is a no-op. New keys are
+// pegged to chainID.
+func (ks *master) ImportFromFileKeystore(dir, password string, chainID *big.Int) (report MigrationReport, err error) {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	if ks.isLocked() {
+		return report, ErrLocked
+	}
+	ethKeys, err := readFileKeystore(dir, password)
+	if err != nil {
+		return report, err
+	}
+	for _, ethKey := range ethKeys {
+		keyReport := KeyReport{ethKey.ID(), ethKey.Address.Hex()}
+		if _, exists := ks.keyRing.Eth[ethKey.ID()]; exists {
+			report.Skipped.Eth = append(report.Skipped.Eth, keyReport)
+			continue
+		}
+		ks.logger.Debugf("Importing Eth key %s from file keystore (and pegging to chain ID %s)", ethKey.ID(), chainID.String())
+		if err = ks.eth.add(ethKey, chainID); err != nil {
+			return report, err
+		}
+		report.Added.Eth = append(report.Added.Eth, keyReport)
+	}
+	if len(report.Added.Eth) > 0 {
+		ks.eth.notify()
+	}
+	return report, nil
+}
+
+// readFileKeystore decrypts each key file in dir. Like go-ethereum, it ignores
+// subdirectories, hidden files and editor backups ending in "~".
+func readFileKeystore(dir, password string) (keys []ethkey.KeyV2, err error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read file keystore")
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || strings.HasSuffix(name, "~") {
+			continue
+		}
+		keyJSON, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to read key file %s", name)
+		}
+		dKey, err := gethkeystore.DecryptKey(keyJSON, password)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to decrypt key file %s", name)
+		}
+		keys = append(keys, ethkey.FromPrivateKey(dKey.PrivateKey))
+	}
+	return keys, nil
+}
+
 type keyManager struct {
 	orm          ksORM
 	scryptParams utils.ScryptParams
 	keyRing      keyRing
 	keyStates    keyStates
-	lock         *sync.RWMutex
+	lock         *keyManagerLock
 	password     string
 	logger       logger.Logger
 }
@@ -211,6 +368,113 @@ func (km *keyManager) Unlock(password string) error {
 	return nil
 }
 
+// RepairStates reconciles the key states in the database with the key ring,
+// in a single transaction: states of keys that are not in the key ring are
+// deleted, and keys without a state get a default one, pegged to the first EVM
+// chain like the states created by the multichain migration.
+//
+// Since Unlock refuses a key ring whose states do not validate, a locked
+// keystore decrypts the key ring with password itself and is left unlocked
+// once the states are repaired. An unlocked keystore only accepts the password
+// it was unlocked with.
+func (km *keyManager) RepairStates(password string) (report RepairReport, err error) {
+	km.lock.Lock()
+	defer km.lock.Unlock()
+	kr := km.keyRing
+	if km.isLocked() {
+		var ekr encryptedKeyRing
+		ekr, err = km.orm.getEncryptedKeyRing()
+		if err != nil {
+			return report, errors.Wrap(err, "unable to get encrypted key ring")
+		}
+		if kr, err = ekr.Decrypt(password); err != nil {
+			return report, errors.Wrap(err, "unable to decrypt encrypted key ring")
+		}
+		kr.logPubKeys(km.logger)
+	} else if password != km.password {
+		return report, errors.New("attempting to repair keystore with a different password")
+	}
+	err = postgres.NewQ(km.orm.db).Transaction(km.logger, func(tx postgres.Queryer) error {
+		var states []ethkey.State
+		if err = tx.Select(&states, `SELECT * FROM eth_key_states FOR UPDATE`); err != nil {
+			return errors.Wrap(err, "error loading eth_key_states from DB")
+		}
+		hasState := make(map[string]bool, len(states))
+		for _, state := range states {
+			hasState[state.KeyID()] = true
+			if _, exists := kr.Eth[state.KeyID()]; exists {
+				continue
+			}
+			if _, err = tx.Exec(`DELETE FROM eth_key_states WHERE address = $1`, state.Address); err != nil {
+				return errors.Wrapf(err, "failed to delete orphaned state for eth key %s", state.KeyID())
+			}
+			report.RemovedStates = append(report.RemovedStates, state.KeyID())
+		}
+		sort.Strings(report.RemovedStates)
+		for _, key := range kr.Eth {
+			if !hasState[key.ID()] {
+				report.AddedStates = append(report.AddedStates, key.ID())
+			}
+		}
+		if len(report.AddedStates) == 0 {
+			return nil
+		}
+		sort.Strings(report.AddedStates)
+		var chainID utils.Big
+		if err = tx.Get(&chainID, `SELECT id FROM evm_chains ORDER BY created_at, id ASC LIMIT 1`); err != nil {
+			return errors.Wrap(err, "failed to find an EVM chain to peg eth keys to")
+		}
+		for _, id := range report.AddedStates {
+			sql := `INSERT INTO eth_key_states (address, next_nonce, is_funding, disabled, evm_chain_id, created_at, updated_at)
+VALUES ($1, 0, false, false, $2, NOW(), NOW());`
+			if _, err = tx.Exec(sql, kr.Eth[id].Address, chainID); err != nil {
+				return errors.Wrapf(err, "failed to insert state for eth key %s", id)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return RepairReport{}, errors.Wrap(err, "unable to repair key states")
+	}
+	ks, err := km.orm.loadKeyStates()
+	if err != nil {
+		return report, errors.Wrap(err, "unable to load key states")
+	}
+	if err = ks.validate(kr); err != nil {
+		return report, err
+	}
+	km.keyRing, km.keyStates, km.password = kr, ks, password
+	return report, nil
+}
+
+// VerifyPassword checks that password decrypts the key ring, without
+// unlocking the keystore. It returns ErrWrongPassword if it does not, or
+// another error if the key ring could not be read. Any password is accepted
+// while the key ring is empty, as Unlock does.
+func (km *keyManager) VerifyPassword(password string) error {
+	ekr, err := km.orm.getEncryptedKeyRing()
+	if err != nil {
+		return errors.Wrap(err, "unable to get encrypted key ring")
+	}
+	if _, err = ekr.Decrypt(password); err != nil {
+		if errors.Is(err, gethkeystore.ErrDecrypt) {
+			return ErrWrongPassword
+		}
+		return errors.Wrap(err, "unable to decrypt encrypted key ring")
+	}
+	return nil
+}
+
+// UnlockFromFile unlocks the keystore with the password read from the file at
+// path. See PasswordFromFile for the requirements on the file.
+func (km *keyManager) UnlockFromFile(path string) error {
+	password, err := PasswordFromFile(path)
+	if err != nil {
+		return err
+	}
+	return km.Unlock(password)
+}
+
 // caller must hold lock!
 func (km *keyManager) save(callbacks ...func(postgres.Queryer) error) error {
 	ekb, err := km.keyRing.Encrypt(km.password, km.scryptParams)
@@ -222,42 +486,40 @@ func (km *keyManager) save(callbacks ...func(postgres.Queryer) error) error {
 
 // caller must hold lock!
 func (km *keyManager) safeAddKey(unknownKey Key, callbacks ...func(postgres.Queryer) error) error {
-	fieldName, err := getFieldNameForKey(unknownKey)
-	if err != nil {
-		return err
-	}
-	// add key to keyring
-	id := reflect.ValueOf(unknownKey.ID())
-	key := reflect.ValueOf(unknownKey)
-	keyRing := reflect.Indirect(reflect.ValueOf(km.keyRing))
-	keyMap := keyRing.FieldByName(fieldName)
-	keyMap.SetMapIndex(id, key)
-	// save keyring to DB
-	err = km.save(callbacks...)
-	// if save fails, remove key from keyring
-	if err != nil {
-		keyMap.SetMapIndex(id, reflect.Value{})
-		return err
-	}
-	return nil
+	return km.updateKeyMapAndSave(unknownKey, func(keyMap reflect.Value) {
+		keyMap.SetMapIndex(reflect.ValueOf(unknownKey.ID()), reflect.ValueOf(unknownKey))
+	}, callbacks...)
 }
 
 // caller must hold lock!
 func (km *keyManager) safeRemoveKey(unknownKey Key, callbacks ...func(postgres.Queryer) error) (err error) {
+	return km.updateKeyMapAndSave(unknownKey, func(keyMap reflect.Value) {
+		keyMap.SetMapIndex(reflect.ValueOf(unknownKey.ID()), reflect.Value{})
+	}, callbacks...)
+}
+
+// updateKeyMapAndSave applies update to a copy of the keyRing map holding
+// keys of unknownKey's type, swaps the copy into the keyRing and saves it. If
+// the save fails, the original map is swapped back, so the keyRing is left
+// exactly as it was before the call.
+// caller must hold lock!
+func (km *keyManager) updateKeyMapAndSave(unknownKey Key, update func(keyMap reflect.Value), callbacks ...func(postgres.Queryer) error) error {
 	fieldName, err := getFieldNameForKey(unknownKey)
 	if err != nil {
 		return err
 	}
-	id := reflect.ValueOf(unknownKey.ID())
-	key := reflect.ValueOf(unknownKey)
-	keyRing := reflect.Indirect(reflect.ValueOf(km.keyRing))
-	keyMap := keyRing.FieldByName(fieldName)
-	keyMap.SetMapIndex(id, reflect.Value{})
+	keyMap := reflect.ValueOf(&km.keyRing).Elem().FieldByName(fieldName)
+	snapshot := reflect.ValueOf(keyMap.Interface())
+	updated := reflect.MakeMapWithSize(keyMap.Type(), keyMap.Len()+1)
+	for iter := keyMap.MapRange(); iter.Next(); {
+		updated.SetMapIndex(iter.Key(), iter.Value())
+	}
+	update(updated)
+	keyMap.Set(updated)
 	// save keyring to DB
-	err = km.save(callbacks...)
-	// if save fails, add key back to keyRing
-	if err != nil {
-		keyMap.SetMapIndex(id, key)
+	if err = km.save(callbacks...); err != nil {
+		// if save fails, restore the keyring
+		keyMap.Set(snapshot)
 		return err
 	}
 	return nil
@@ -268,18 +530,47 @@ func (km *keyManager) isLocked() bool {
 	return len(km.password) == 0
 }
 
+// keyType describes a type of key held in the keyRing
+type keyType struct {
+	// field is the name of the keyRing map holding keys of this type
+	field string
+	// label is the name of the key type, as shown to users
+	label string
+}
+
+// keyTypes registers every type of key the keystore can hold, in display
+// order. A new key type needs a map in keyRing and an entry here.
+var keyTypes = []struct {
+	key Key
+	keyType
+}{
+	{csakey.KeyV2{}, keyType{field: "CSA", label: "CSA"}},
+	{ethkey.KeyV2{}, keyType{field: "Eth", label: "Eth"}},
+	{ocrkey.KeyV2{}, keyType{field: "OCR", label: "OCR"}},
+	{p2pkey.KeyV2{}, keyType{field: "P2P", label: "P2P"}},
+	{vrfkey.KeyV2{}, keyType{field: "VRF", label: "VRF"}},
+}
+
+var keyTypesByType = func() map[reflect.Type]keyType {
+	m := make(map[reflect.Type]keyType, len(keyTypes))
+	for _, kt := range keyTypes {
+		m[reflect.TypeOf(kt.key)] = kt.keyType
+	}
+	return m
+}()
+
+// KeyTypes returns the labels of all types of key held by the keystore
+func KeyTypes() []string {
+	labels := make([]string, len(keyTypes))
+	for i, kt := range keyTypes {
+		labels[i] = kt.label
+	}
+	return labels
+}
+
 func getFieldNameForKey(unknownKey Key) (string, error) {
-	switch unknownKey.(type) {
-	case csakey.KeyV2:
-		return "CSA", nil
-	case ethkey.KeyV2:
-		return "Eth", nil
-	case ocrkey.KeyV2:
-		return "OCR", nil
-	case p2pkey.KeyV2:
-		return "P2P", nil
-	case vrfkey.KeyV2:
-		return "VRF", nil
+	if kt, ok := keyTypesByType[reflect.TypeOf(unknownKey)]; ok {
+		return kt.field, nil
 	}
 	return "", fmt.Errorf("unknown key type: %T", unknownKey)
 }
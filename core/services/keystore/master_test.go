@@ -2,14 +2,53 @@ package keystore_test
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/services/keystore"
+	"github.com/smartcontractkit/chainlink/core/services/keystore/keys/ethkey"
+	"github.com/smartcontractkit/chainlink/core/utils"
 	"github.com/stretchr/testify/require"
 )
 
+func TestMasterKeystore_New_ScryptParams(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+	lggr := logger.TestLogger(t)
+
+	t.Run("accepts production params", func(t *testing.T) {
+		_, err := keystore.New(db, utils.DefaultScryptParams, lggr)
+		require.NoError(t, err)
+
+		_, err = keystore.New(db, utils.ScryptParams{N: utils.MinScryptN, P: utils.MinScryptP}, lggr)
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects weak params", func(t *testing.T) {
+		_, err := keystore.New(db, utils.ScryptParams{N: 1, P: 1}, lggr)
+		require.EqualError(t, err, fmt.Sprintf("invalid scrypt params: scrypt N parameter 1 is below the minimum of %d", utils.MinScryptN))
+
+		_, err = keystore.New(db, utils.ScryptParams{N: utils.FastN, P: utils.FastP}, lggr)
+		require.Error(t, err)
+
+		_, err = keystore.New(db, utils.ScryptParams{N: utils.MinScryptN, P: 0}, lggr)
+		require.EqualError(t, err, "invalid scrypt params: scrypt P parameter 0 is below the minimum of 1")
+	})
+
+	t.Run("accepts fast params in insecure fast mode", func(t *testing.T) {
+		_, err := keystore.New(db, utils.FastScryptParams, lggr)
+		require.NoError(t, err)
+
+		_, err = keystore.New(db, utils.ScryptParams{N: 1, P: 1, InsecureFast: true}, lggr)
+		require.NoError(t, err)
+	})
+}
+
 func TestMasterKeystore_Unlock_Save(t *testing.T) {
 	t.Parallel()
 
@@ -58,3 +97,213 @@ func TestMasterKeystore_Unlock_Save(t *testing.T) {
 		require.NoError(t, keyStore.Unlock(cltest.Password))
 	})
 }
+
+func TestMasterKeystore_VerifyPassword(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+	keyStore := keystore.ExposedNewMaster(t, db)
+	require.NoError(t, keyStore.Unlock(cltest.Password))
+	cltest.MustAddRandomKeyToKeystore(t, keyStore.Eth()) // need at least 1 key to encrypt
+	keyStore.ResetXXXTestOnly()
+
+	t.Run("correct password", func(t *testing.T) {
+		require.NoError(t, keyStore.VerifyPassword(cltest.Password))
+		require.Equal(t, keystore.ErrLocked, keyStore.Health())
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		require.Equal(t, keystore.ErrWrongPassword, keyStore.VerifyPassword("wrong password"))
+		require.Equal(t, keystore.ErrLocked, keyStore.Health())
+	})
+
+	t.Run("does not prevent unlocking with the correct password", func(t *testing.T) {
+		require.NoError(t, keyStore.Unlock(cltest.Password))
+		keyStore.ResetXXXTestOnly()
+	})
+
+	t.Run("database unreachable", func(t *testing.T) {
+		db := pgtest.NewSqlxDB(t)
+		keyStore := keystore.ExposedNewMaster(t, db)
+		require.NoError(t, db.Close())
+
+		err := keyStore.VerifyPassword(cltest.Password)
+		require.Error(t, err)
+		require.NotEqual(t, keystore.ErrWrongPassword, err)
+		require.Contains(t, err.Error(), "unable to get encrypted key ring")
+	})
+}
+
+func TestMasterKeystore_Health(t *testing.T) {
+	t.Parallel()
+
+	t.Run("locked", func(t *testing.T) {
+		keyStore := keystore.ExposedNewMaster(t, pgtest.NewSqlxDB(t))
+		require.Equal(t, keystore.ErrLocked, keyStore.Health())
+	})
+
+	t.Run("unlocked", func(t *testing.T) {
+		keyStore := keystore.ExposedNewMaster(t, pgtest.NewSqlxDB(t))
+		require.NoError(t, keyStore.Unlock(cltest.Password))
+		cltest.MustAddRandomKeyToKeystore(t, keyStore.Eth())
+		require.NoError(t, keyStore.Health())
+	})
+
+	t.Run("database unreachable", func(t *testing.T) {
+		db := pgtest.NewSqlxDB(t)
+		keyStore := keystore.ExposedNewMaster(t, db)
+		require.NoError(t, keyStore.Unlock(cltest.Password))
+		require.NoError(t, db.Close())
+
+		err := keyStore.Health()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "keystore database is unreachable")
+	})
+}
+
+func TestMasterKeystore_AllKeys(t *testing.T) {
+	t.Parallel()
+
+	keyStore := keystore.ExposedNewMaster(t, pgtest.NewSqlxDB(t))
+
+	_, err := keyStore.AllKeys()
+	require.Equal(t, keystore.ErrLocked, err)
+
+	require.NoError(t, keyStore.Unlock(cltest.Password))
+
+	report, err := keyStore.AllKeys()
+	require.NoError(t, err)
+	require.Empty(t, report.Eth)
+
+	csaKey, err := keyStore.CSA().Create()
+	require.NoError(t, err)
+	ethKey, err := keyStore.Eth().Create(&cltest.FixtureChainID)
+	require.NoError(t, err)
+	ocrKey, err := keyStore.OCR().Create()
+	require.NoError(t, err)
+	p2pKey, err := keyStore.P2P().Create()
+	require.NoError(t, err)
+	vrfKey, err := keyStore.VRF().Create()
+	require.NoError(t, err)
+
+	report, err = keyStore.AllKeys()
+	require.NoError(t, err)
+	require.Equal(t, []keystore.KeyReport{{ID: csaKey.ID(), PublicIdentifier: csaKey.PublicKeyString()}}, report.CSA)
+	require.Equal(t, []keystore.KeyReport{{ID: ethKey.ID(), PublicIdentifier: ethKey.Address.Hex()}}, report.Eth)
+	require.Equal(t, []keystore.KeyReport{{ID: ocrKey.ID(), PublicIdentifier: ocrKey.OnChainSigning.Address().String()}}, report.OCR)
+	require.Equal(t, []keystore.KeyReport{{ID: p2pKey.ID(), PublicIdentifier: p2pKey.PeerID().String()}}, report.P2P)
+	require.Equal(t, []keystore.KeyReport{{ID: vrfKey.ID(), PublicIdentifier: vrfKey.PublicKey.String()}}, report.VRF)
+}
+
+func TestMasterKeystore_ImportFromFileKeystore(t *testing.T) {
+	t.Parallel()
+
+	keyStore := keystore.ExposedNewMaster(t, pgtest.NewSqlxDB(t))
+	dir := t.TempDir()
+
+	_, err := keyStore.ImportFromFileKeystore(dir, cltest.Password, &cltest.FixtureChainID)
+	require.Equal(t, keystore.ErrLocked, err)
+
+	require.NoError(t, keyStore.Unlock(cltest.Password))
+
+	var want []keystore.KeyReport
+	for i := 0; i < 2; i++ {
+		key, err := ethkey.NewV2()
+		require.NoError(t, err)
+		keyJSON, err := key.ToEncryptedJSON("file keystore password", utils.FastScryptParams)
+		require.NoError(t, err)
+		name := fmt.Sprintf("UTC--2018-01-01T00-00-00.000000000Z--%x", key.Address.Bytes())
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), keyJSON, 0600))
+		want = append(want, keystore.KeyReport{ID: key.ID(), PublicIdentifier: key.Address.Hex()})
+	}
+	// Ignored like go-ethereum does
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".DS_Store"), []byte("not a key"), 0600))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "subdir"), 0700))
+
+	_, err = keyStore.ImportFromFileKeystore(dir, "wrong password", &cltest.FixtureChainID)
+	require.Error(t, err)
+	keys, err := keyStore.Eth().GetAll()
+	require.NoError(t, err)
+	require.Empty(t, keys)
+
+	report, err := keyStore.ImportFromFileKeystore(dir, "file keystore password", &cltest.FixtureChainID)
+	require.NoError(t, err)
+	require.ElementsMatch(t, want, report.Added.Eth)
+	require.Empty(t, report.Skipped.Eth)
+
+	all, err := keyStore.AllKeys()
+	require.NoError(t, err)
+	require.ElementsMatch(t, want, all.Eth)
+	for _, key := range want {
+		state, err := keyStore.Eth().GetState(key.ID)
+		require.NoError(t, err)
+		require.Equal(t, cltest.FixtureChainID.String(), state.EVMChainID.String())
+	}
+
+	// Importing again is a no-op
+	report, err = keyStore.ImportFromFileKeystore(dir, "file keystore password", &cltest.FixtureChainID)
+	require.NoError(t, err)
+	require.Empty(t, report.Added.Eth)
+	require.ElementsMatch(t, want, report.Skipped.Eth)
+
+	all, err = keyStore.AllKeys()
+	require.NoError(t, err)
+	require.ElementsMatch(t, want, all.Eth)
+}
+
+func TestMasterKeystore_RepairStates(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+	keyStore := cltest.NewKeyStore(t, db)
+	ethKeyStore := keyStore.Eth()
+
+	k1, _ := cltest.MustInsertRandomKey(t, ethKeyStore)
+	k2, _ := cltest.MustInsertRandomKey(t, ethKeyStore)
+	_, err := db.Exec(`DELETE FROM eth_key_states WHERE address = $1`, k2.Address)
+	require.NoError(t, err)
+	orphan := cltest.NewEIP55Address()
+	_, err = db.Exec(`INSERT INTO eth_key_states (address, next_nonce, is_funding, evm_chain_id, created_at, updated_at) VALUES ($1, 0, false, $2, NOW(), NOW())`, orphan, utils.NewBig(&cltest.FixtureChainID))
+	require.NoError(t, err)
+
+	t.Run("errors with a different password", func(t *testing.T) {
+		_, err := keyStore.RepairStates("wrong password")
+		require.EqualError(t, err, "attempting to repair keystore with a different password")
+	})
+
+	t.Run("repairs and unlocks a key ring that fails to unlock", func(t *testing.T) {
+		locked := keystore.ExposedNewMaster(t, db)
+		require.Error(t, locked.Unlock(cltest.Password))
+		require.Equal(t, keystore.ErrLocked, locked.Health())
+
+		_, err := locked.RepairStates("wrong password")
+		require.Error(t, err)
+		require.Equal(t, keystore.ErrLocked, locked.Health())
+
+		report, err := locked.RepairStates(cltest.Password)
+		require.NoError(t, err)
+		require.Equal(t, []string{orphan.Hex()}, report.RemovedStates)
+		require.Equal(t, []string{k2.ID()}, report.AddedStates)
+
+		var count int
+		require.NoError(t, db.Get(&count, `SELECT count(*) FROM eth_key_states WHERE address = $1`, orphan))
+		require.Zero(t, count)
+
+		var firstChainID utils.Big
+		require.NoError(t, db.Get(&firstChainID, `SELECT id FROM evm_chains ORDER BY created_at, id ASC LIMIT 1`))
+		state, err := locked.Eth().GetState(k2.ID())
+		require.NoError(t, err)
+		require.Equal(t, firstChainID.String(), state.EVMChainID.String())
+
+		_, err = locked.Eth().GetState(k1.ID())
+		require.NoError(t, err)
+		require.NoError(t, locked.Health())
+	})
+
+	t.Run("changes nothing once repaired", func(t *testing.T) {
+		report, err := keyStore.RepairStates(cltest.Password)
+		require.NoError(t, err)
+		require.Empty(t, report.RemovedStates)
+		require.Empty(t, report.AddedStates)
+	})
+}
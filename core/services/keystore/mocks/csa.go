@@ -136,6 +136,27 @@ func (_m *CSA) GetAll() ([]csakey.KeyV2, error) {
 	return r0, r1
 }
 
+// GetByPublicKey provides a mock function with given fields: pubKeyHex
+func (_m *CSA) GetByPublicKey(pubKeyHex string) (csakey.KeyV2, error) {
+	ret := _m.Called(pubKeyHex)
+
+	var r0 csakey.KeyV2
+	if rf, ok := ret.Get(0).(func(string) csakey.KeyV2); ok {
+		r0 = rf(pubKeyHex)
+	} else {
+		r0 = ret.Get(0).(csakey.KeyV2)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(pubKeyHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetV1KeysAsV2 provides a mock function with given fields:
 func (_m *CSA) GetV1KeysAsV2() ([]csakey.KeyV2, error) {
 	ret := _m.Called()
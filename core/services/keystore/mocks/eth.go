@@ -5,6 +5,8 @@ package mocks
 import (
 	big "math/big"
 
+	bind "github.com/ethereum/go-ethereum/accounts/abi/bind"
+
 	common "github.com/ethereum/go-ethereum/common"
 	ethkey "github.com/smartcontractkit/chainlink/core/services/keystore/keys/ethkey"
 
@@ -74,6 +76,57 @@ func (_m *Eth) Delete(id string) (ethkey.KeyV2, error) {
 	return r0, r1
 }
 
+// Disable provides a mock function with given fields: address, chainID
+func (_m *Eth) Disable(address common.Address, chainID *big.Int) error {
+	ret := _m.Called(address, chainID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Address, *big.Int) error); ok {
+		r0 = rf(address, chainID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Enable provides a mock function with given fields: address, chainID
+func (_m *Eth) Enable(address common.Address, chainID *big.Int) error {
+	ret := _m.Called(address, chainID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Address, *big.Int) error); ok {
+		r0 = rf(address, chainID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnabledKeysForChain provides a mock function with given fields: chainID
+func (_m *Eth) EnabledKeysForChain(chainID *big.Int) ([]ethkey.KeyV2, error) {
+	ret := _m.Called(chainID)
+
+	var r0 []ethkey.KeyV2
+	if rf, ok := ret.Get(0).(func(*big.Int) []ethkey.KeyV2); ok {
+		r0 = rf(chainID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ethkey.KeyV2)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*big.Int) error); ok {
+		r1 = rf(chainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // EnsureKeys provides a mock function with given fields: chainID
 func (_m *Eth) EnsureKeys(chainID *big.Int) (ethkey.KeyV2, bool, ethkey.KeyV2, bool, error) {
 	ret := _m.Called(chainID)
@@ -355,6 +408,29 @@ func (_m *Eth) Import(keyJSON []byte, password string, chainID *big.Int) (ethkey
 	return r0, r1
 }
 
+// NewSigner provides a mock function with given fields: chainID
+func (_m *Eth) NewSigner(chainID *big.Int) (bind.SignerFn, error) {
+	ret := _m.Called(chainID)
+
+	var r0 bind.SignerFn
+	if rf, ok := ret.Get(0).(func(*big.Int) bind.SignerFn); ok {
+		r0 = rf(chainID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(bind.SignerFn)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*big.Int) error); ok {
+		r1 = rf(chainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SendingKeys provides a mock function with given fields:
 func (_m *Eth) SendingKeys() ([]ethkey.KeyV2, error) {
 	ret := _m.Called()
@@ -7,6 +7,8 @@ import (
 
 	keystore "github.com/smartcontractkit/chainlink/core/services/keystore"
 	mock "github.com/stretchr/testify/mock"
+
+	prometheus "github.com/prometheus/client_golang/prometheus"
 )
 
 // Master is an autogenerated mock type for the Master type
@@ -14,6 +16,27 @@ type Master struct {
 	mock.Mock
 }
 
+// AllKeys provides a mock function with given fields:
+func (_m *Master) AllKeys() (keystore.AllKeysReport, error) {
+	ret := _m.Called()
+
+	var r0 keystore.AllKeysReport
+	if rf, ok := ret.Get(0).(func() keystore.AllKeysReport); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(keystore.AllKeysReport)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CSA provides a mock function with given fields:
 func (_m *Master) CSA() keystore.CSA {
 	ret := _m.Called()
@@ -46,6 +69,55 @@ func (_m *Master) Eth() keystore.Eth {
 	return r0
 }
 
+// ExportLockWaits provides a mock function with given fields: registerer
+func (_m *Master) ExportLockWaits(registerer prometheus.Registerer) error {
+	ret := _m.Called(registerer)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(prometheus.Registerer) error); ok {
+		r0 = rf(registerer)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Health provides a mock function with given fields:
+func (_m *Master) Health() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ImportFromFileKeystore provides a mock function with given fields: dir, password, chainID
+func (_m *Master) ImportFromFileKeystore(dir string, password string, chainID *big.Int) (keystore.MigrationReport, error) {
+	ret := _m.Called(dir, password, chainID)
+
+	var r0 keystore.MigrationReport
+	if rf, ok := ret.Get(0).(func(string, string, *big.Int) keystore.MigrationReport); ok {
+		r0 = rf(dir, password, chainID)
+	} else {
+		r0 = ret.Get(0).(keystore.MigrationReport)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, *big.Int) error); ok {
+		r1 = rf(dir, password, chainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsEmpty provides a mock function with given fields:
 func (_m *Master) IsEmpty() (bool, error) {
 	ret := _m.Called()
@@ -113,6 +185,27 @@ func (_m *Master) P2P() keystore.P2P {
 	return r0
 }
 
+// RepairStates provides a mock function with given fields: password
+func (_m *Master) RepairStates(password string) (keystore.RepairReport, error) {
+	ret := _m.Called(password)
+
+	var r0 keystore.RepairReport
+	if rf, ok := ret.Get(0).(func(string) keystore.RepairReport); ok {
+		r0 = rf(password)
+	} else {
+		r0 = ret.Get(0).(keystore.RepairReport)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Unlock provides a mock function with given fields: password
 func (_m *Master) Unlock(password string) error {
 	ret := _m.Called(password)
@@ -127,6 +220,20 @@ func (_m *Master) Unlock(password string) error {
 	return r0
 }
 
+// UnlockFromFile provides a mock function with given fields: path
+func (_m *Master) UnlockFromFile(path string) error {
+	ret := _m.Called(path)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(path)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // VRF provides a mock function with given fields:
 func (_m *Master) VRF() keystore.VRF {
 	ret := _m.Called()
@@ -142,3 +249,17 @@ func (_m *Master) VRF() keystore.VRF {
 
 	return r0
 }
+
+// VerifyPassword provides a mock function with given fields: password
+func (_m *Master) VerifyPassword(password string) error {
+	ret := _m.Called(password)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(password)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -3,8 +3,10 @@
 package mocks
 
 import (
-	ocrkey "github.com/smartcontractkit/chainlink/core/services/keystore/keys/ocrkey"
+	keystore "github.com/smartcontractkit/chainlink/core/services/keystore"
 	mock "github.com/stretchr/testify/mock"
+
+	ocrkey "github.com/smartcontractkit/chainlink/core/services/keystore/keys/ocrkey"
 )
 
 // OCR is an autogenerated mock type for the OCR type
@@ -119,6 +121,27 @@ func (_m *OCR) Export(id string, password string) ([]byte, error) {
 	return r0, r1
 }
 
+// ExportPublicComponents provides a mock function with given fields: id
+func (_m *OCR) ExportPublicComponents(id string) (keystore.OCRPublicComponents, error) {
+	ret := _m.Called(id)
+
+	var r0 keystore.OCRPublicComponents
+	if rf, ok := ret.Get(0).(func(string) keystore.OCRPublicComponents); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(keystore.OCRPublicComponents)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Get provides a mock function with given fields: id
 func (_m *OCR) Get(id string) (ocrkey.KeyV2, error) {
 	ret := _m.Called(id)
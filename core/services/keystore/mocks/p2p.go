@@ -163,6 +163,27 @@ func (_m *P2P) GetAll() ([]p2pkey.KeyV2, error) {
 	return r0, r1
 }
 
+// GetByPublicKey provides a mock function with given fields: pubKeyHex
+func (_m *P2P) GetByPublicKey(pubKeyHex string) (p2pkey.KeyV2, error) {
+	ret := _m.Called(pubKeyHex)
+
+	var r0 p2pkey.KeyV2
+	if rf, ok := ret.Get(0).(func(string) p2pkey.KeyV2); ok {
+		r0 = rf(pubKeyHex)
+	} else {
+		r0 = ret.Get(0).(p2pkey.KeyV2)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(pubKeyHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetOrFirst provides a mock function with given fields: id
 func (_m *P2P) GetOrFirst(id p2pkey.PeerID) (p2pkey.KeyV2, error) {
 	ret := _m.Called(id)
@@ -2,6 +2,7 @@ package keystore
 
 import (
 	"math/big"
+	"reflect"
 	"testing"
 
 	"github.com/smartcontractkit/chainlink/core/services/keystore/keys/csakey"
@@ -66,3 +67,78 @@ func TestKeyRing_Encrypt_Decrypt(t *testing.T) {
 	require.Equal(t, originalKeyRing.VRF[vrf1.ID()].PublicKey, decryptedKeyRing.VRF[vrf1.ID()].PublicKey)
 	require.Equal(t, originalKeyRing.VRF[vrf2.ID()].PublicKey, decryptedKeyRing.VRF[vrf2.ID()].PublicKey)
 }
+
+func TestGetFieldNameForKey(t *testing.T) {
+	keyRingType := reflect.TypeOf(keyRing{})
+	tests := []struct {
+		key   Key
+		field string
+	}{
+		{csakey.KeyV2{}, "CSA"},
+		{ethkey.KeyV2{}, "Eth"},
+		{ocrkey.KeyV2{}, "OCR"},
+		{p2pkey.KeyV2{}, "P2P"},
+		{vrfkey.KeyV2{}, "VRF"},
+	}
+	require.Len(t, keyTypes, len(tests), "every registered key type must be tested")
+	for _, test := range tests {
+		fieldName, err := getFieldNameForKey(test.key)
+		require.NoError(t, err)
+		require.Equal(t, test.field, fieldName)
+
+		field, ok := keyRingType.FieldByName(fieldName)
+		require.True(t, ok, "keyRing has no field %s", fieldName)
+		require.Equal(t, reflect.Map, field.Type.Kind())
+		require.Equal(t, reflect.TypeOf(test.key), field.Type.Elem())
+	}
+
+	_, err := getFieldNameForKey(unknownKey{})
+	require.EqualError(t, err, "unknown key type: keystore.unknownKey")
+	// pointers are not registered
+	_, err = getFieldNameForKey(&csakey.KeyV2{})
+	require.EqualError(t, err, "unknown key type: *csakey.KeyV2")
+
+	require.Equal(t, []string{"CSA", "Eth", "OCR", "P2P", "VRF"}, KeyTypes())
+}
+
+type unknownKey struct{}
+
+func (unknownKey) ID() string { return "unknown" }
+
+func TestKeyManager_FailedSaveRestoresKeyRing(t *testing.T) {
+	csa1, csa2 := csakey.MustNewV2XXXTestingOnly(big.NewInt(1)), csakey.MustNewV2XXXTestingOnly(big.NewInt(2))
+	vrf1 := vrfkey.MustNewV2XXXTestingOnly(big.NewInt(1))
+	kr := newKeyRing()
+	kr.CSA[csa1.ID()] = csa1
+	kr.VRF[vrf1.ID()] = vrf1
+	// scrypt rejects an N that is not a power of 2, so every save fails
+	// before reaching the database
+	km := &keyManager{
+		keyRing:      kr,
+		password:     password,
+		scryptParams: utils.ScryptParams{N: 3, P: 1},
+		lock:         &keyManagerLock{},
+	}
+	copyKeyRing := func(kr keyRing) keyRing {
+		cp := newKeyRing()
+		for id, k := range kr.CSA {
+			cp.CSA[id] = k
+		}
+		for id, k := range kr.VRF {
+			cp.VRF[id] = k
+		}
+		return cp
+	}
+	before := copyKeyRing(km.keyRing)
+
+	require.Error(t, km.safeAddKey(csa2))
+	require.Equal(t, before, km.keyRing)
+
+	require.Error(t, km.safeRemoveKey(csa1))
+	require.Equal(t, before, km.keyRing)
+
+	require.Error(t, km.safeRemoveKey(vrf1))
+	require.Equal(t, before, km.keyRing)
+	// the maps reachable before the calls were never modified
+	require.Equal(t, before, copyKeyRing(kr))
+}
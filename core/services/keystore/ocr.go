@@ -19,6 +19,7 @@ type OCR interface {
 	Import(keyJSON []byte, password string) (ocrkey.KeyV2, error)
 	Export(id string, password string) ([]byte, error)
 	EnsureKey() (ocrkey.KeyV2, bool, error)
+	ExportPublicComponents(id string) (OCRPublicComponents, error)
 
 	GetV1KeysAsV2() ([]ocrkey.KeyV2, error)
 }
@@ -33,6 +34,14 @@ func (e KeyNotFoundError) Error() string {
 	return fmt.Sprintf("unable to find %s key with id %s", e.KeyType, e.ID)
 }
 
+// OCRPublicComponents holds the public parts of an OCR key that are submitted
+// on-chain via setConfig.
+type OCRPublicComponents struct {
+	OnChainSigningAddress ocrkey.OnChainSigningAddress `json:"onChainSigningAddress"`
+	OffChainPublicKey     ocrkey.OffChainPublicKey     `json:"offChainPublicKey"`
+	ConfigPublicKey       ocrkey.ConfigPublicKey       `json:"configPublicKey"`
+}
+
 type ocr struct {
 	*keyManager
 }
@@ -134,6 +143,23 @@ func (ks *ocr) Export(id string, password string) ([]byte, error) {
 	return key.ToEncryptedJSON(password, ks.scryptParams)
 }
 
+func (ks *ocr) ExportPublicComponents(id string) (OCRPublicComponents, error) {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+	if ks.isLocked() {
+		return OCRPublicComponents{}, ErrLocked
+	}
+	key, err := ks.getByID(id)
+	if err != nil {
+		return OCRPublicComponents{}, err
+	}
+	return OCRPublicComponents{
+		OnChainSigningAddress: key.OnChainSigning.Address(),
+		OffChainPublicKey:     key.OffChainSigning.PublicKey(),
+		ConfigPublicKey:       key.PublicKeyConfig(),
+	}, nil
+}
+
 func (ks *ocr) EnsureKey() (ocrkey.KeyV2, bool, error) {
 	ks.lock.Lock()
 	defer ks.lock.Unlock()
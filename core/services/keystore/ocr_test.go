@@ -62,6 +62,19 @@ func Test_OCRKeyStore_E2E(t *testing.T) {
 		require.Equal(t, importedKey, retrievedKey)
 	})
 
+	t.Run("exports public components", func(t *testing.T) {
+		defer reset()
+		key, err := ks.Create()
+		require.NoError(t, err)
+		components, err := ks.ExportPublicComponents(key.ID())
+		require.NoError(t, err)
+		require.Equal(t, key.OnChainSigning.Address(), components.OnChainSigningAddress)
+		require.Equal(t, key.OffChainSigning.PublicKey(), components.OffChainPublicKey)
+		require.Equal(t, ocrkey.ConfigPublicKey(key.PublicKeyConfig()), components.ConfigPublicKey)
+		_, err = ks.ExportPublicComponents("non-existant-id")
+		require.Error(t, err)
+	})
+
 	t.Run("adds an externally created key / deletes a key", func(t *testing.T) {
 		defer reset()
 		newKey, err := ocrkey.NewV2()
@@ -1,6 +1,7 @@
 package keystore
 
 import (
+	"context"
 	"database/sql"
 
 	"github.com/smartcontractkit/chainlink/core/logger"
@@ -27,6 +28,10 @@ type ksORM struct {
 	lggr logger.Logger
 }
 
+func (orm ksORM) ping() error {
+	return postgres.NewQ(orm.db).Ping(context.Background())
+}
+
 func (orm ksORM) saveEncryptedKeyRing(kr *encryptedKeyRing, callbacks ...func(postgres.Queryer) error) error {
 	return postgres.NewQ(orm.db).Transaction(orm.lggr, func(tx postgres.Queryer) error {
 		_, err := tx.Exec(`
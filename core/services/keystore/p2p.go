@@ -14,6 +14,7 @@ import (
 
 type P2P interface {
 	Get(id p2pkey.PeerID) (p2pkey.KeyV2, error)
+	GetByPublicKey(pubKeyHex string) (p2pkey.KeyV2, error)
 	GetAll() ([]p2pkey.KeyV2, error)
 	Create() (p2pkey.KeyV2, error)
 	Add(key p2pkey.KeyV2) error
@@ -48,6 +49,23 @@ func (ks *p2p) Get(id p2pkey.PeerID) (p2pkey.KeyV2, error) {
 	return ks.getByID(id)
 }
 
+// GetByPublicKey returns the P2P key with the given hex encoded public key,
+// with or without a 0x prefix.
+func (ks *p2p) GetByPublicKey(pubKeyHex string) (p2pkey.KeyV2, error) {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+	if ks.isLocked() {
+		return p2pkey.KeyV2{}, ErrLocked
+	}
+	normalized := normalizePublicKeyHex(pubKeyHex)
+	for _, key := range ks.keyRing.P2P {
+		if key.PublicKeyHex() == normalized {
+			return key, nil
+		}
+	}
+	return p2pkey.KeyV2{}, KeyNotFoundError{ID: pubKeyHex, KeyType: "P2P"}
+}
+
 func (ks *p2p) GetAll() (keys []p2pkey.KeyV2, _ error) {
 	ks.lock.RLock()
 	defer ks.lock.RUnlock()
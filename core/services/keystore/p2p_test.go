@@ -2,6 +2,7 @@ package keystore_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
@@ -47,6 +48,29 @@ func Test_P2PKeyStore_E2E(t *testing.T) {
 		require.Equal(t, key, retrievedKey)
 	})
 
+	t.Run("gets a key by public key", func(t *testing.T) {
+		defer reset()
+		key, err := ks.Create()
+		require.NoError(t, err)
+		retrievedKey, err := ks.GetByPublicKey(key.PublicKeyHex())
+		require.NoError(t, err)
+		require.Equal(t, key, retrievedKey)
+		retrievedKey, err = ks.GetByPublicKey("0x" + strings.ToUpper(key.PublicKeyHex()))
+		require.NoError(t, err)
+		require.Equal(t, key, retrievedKey)
+	})
+
+	t.Run("errors when getting non-existant public key", func(t *testing.T) {
+		defer reset()
+		_, err := ks.GetByPublicKey("deadbeef")
+		require.Equal(t, keystore.KeyNotFoundError{ID: "deadbeef", KeyType: "P2P"}, err)
+	})
+
+	t.Run("requires unlock to get a key by public key", func(t *testing.T) {
+		_, err := keystore.ExposedNewMaster(t, db).P2P().GetByPublicKey("deadbeef")
+		require.Equal(t, keystore.ErrLocked, err)
+	})
+
 	t.Run("imports and exports a key", func(t *testing.T) {
 		defer reset()
 		key, err := ks.Create()
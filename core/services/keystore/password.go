@@ -0,0 +1,37 @@
+package keystore
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// passwordFileMaxPerms are the most permissive permissions a keystore password
+// file may have: other users must not have any access to it
+const passwordFileMaxPerms = os.FileMode(0770)
+
+// PasswordFromFile reads the keystore password from the file at path, with
+// surrounding whitespace trimmed. Files that other users can access and files
+// containing an empty password are rejected.
+func PasswordFromFile(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read keystore password file")
+	}
+	if perms := info.Mode().Perm(); utils.TooPermissive(perms, passwordFileMaxPerms) {
+		return "", errors.Errorf("keystore password file %s has overly permissive file permissions %s, it must not be accessible by other users", path, perms)
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read keystore password file")
+	}
+	password := strings.TrimSpace(string(b))
+	if password == "" {
+		return "", errors.Errorf("keystore password file %s is empty", path)
+	}
+	return password, nil
+}
@@ -0,0 +1,66 @@
+package keystore_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/core/services/keystore"
+)
+
+func writePasswordFile(t *testing.T, contents string, perms os.FileMode) string {
+	path := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, ioutil.WriteFile(path, []byte(contents), perms))
+	// WriteFile is subject to the umask
+	require.NoError(t, os.Chmod(path, perms))
+	return path
+}
+
+func TestPasswordFromFile(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid file", func(t *testing.T) {
+		path := writePasswordFile(t, "  "+cltest.Password+"\n", 0600)
+		password, err := keystore.PasswordFromFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, cltest.Password, password)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := keystore.PasswordFromFile(filepath.Join(t.TempDir(), "missing"))
+		require.Error(t, err)
+		assert.True(t, os.IsNotExist(errors.Cause(err)))
+	})
+
+	t.Run("too permissive file", func(t *testing.T) {
+		path := writePasswordFile(t, cltest.Password, 0644)
+		_, err := keystore.PasswordFromFile(path)
+		require.EqualError(t, err, "keystore password file "+path+" has overly permissive file permissions -rw-r--r--, it must not be accessible by other users")
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		path := writePasswordFile(t, "\n", 0600)
+		_, err := keystore.PasswordFromFile(path)
+		require.EqualError(t, err, "keystore password file "+path+" is empty")
+	})
+}
+
+func TestMasterKeystore_UnlockFromFile(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+	keyStore := keystore.ExposedNewMaster(t, db)
+
+	require.Error(t, keyStore.UnlockFromFile(writePasswordFile(t, cltest.Password, 0644)))
+	require.Equal(t, keystore.ErrLocked, keyStore.Health())
+
+	require.NoError(t, keyStore.UnlockFromFile(writePasswordFile(t, cltest.Password, 0600)))
+	require.NoError(t, keyStore.Health())
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/lib/pq"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/multierr"
 
 	"github.com/smartcontractkit/chainlink/core/logger"
@@ -16,12 +17,26 @@ import (
 	"github.com/smartcontractkit/chainlink/core/utils"
 	"github.com/smartcontractkit/libocr/gethwrappers/offchainaggregator"
 	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting/types"
+	"github.com/smartcontractkit/sqlx"
 )
 
 type db struct {
 	*sql.DB
-	oracleSpecID int32
-	lggr         logger.Logger
+	oracleSpecID   int32
+	lggr           logger.Logger
+	durations      *prometheus.HistogramVec
+	configDecoders map[uint64]configDecoder
+}
+
+// configDecoder converts the Encoded field of a contract config stored with
+// some EncodedConfigVersion into the encoding libocr expects
+type configDecoder func(encoded []byte) ([]byte, error)
+
+// configDecoders holds the decoder of each EncodedConfigVersion that
+// ReadConfig can read. libocr has only ever used version 1, which is stored
+// as is.
+var configDecoders = map[uint64]configDecoder{
+	1: func(encoded []byte) ([]byte, error) { return encoded, nil },
 }
 
 var (
@@ -29,12 +44,53 @@ var (
 	_ OCRContractTrackerDB = &db{}
 )
 
-// NewDB returns a new DB scoped to this oracleSpecID
-func NewDB(sqldb *sql.DB, oracleSpecID int32, lggr logger.Logger) *db {
-	return &db{sqldb, oracleSpecID, lggr.Named("OCRDB")}
+// NewDB returns a new DB scoped to this oracleSpecID. If registerer is not
+// nil, the durations of database operations are exported to it.
+func NewDB(sqldb *sql.DB, oracleSpecID int32, lggr logger.Logger, registerer prometheus.Registerer) *db {
+	lggr = logger.With(lggr.Named("OCRDB"), "oracleSpecID", oracleSpecID)
+	var durations *prometheus.HistogramVec
+	if registerer != nil {
+		var err error
+		if durations, err = registerDBDurations(registerer); err != nil {
+			lggr.Errorw("Failed to register OCR database metrics", "err", err)
+		}
+	}
+	return &db{sqldb, oracleSpecID, lggr, durations, configDecoders}
+}
+
+// registerDBDurations registers the histogram of OCR database operation
+// durations with registerer. The histogram is shared by the DBs of all specs,
+// so the one already registered is reused.
+func registerDBDurations(registerer prometheus.Registerer) (*prometheus.HistogramVec, error) {
+	durations := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "ocr_db_operation_duration_seconds",
+		Help:    "Duration of OCR database operations",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"operation"})
+	err := registerer.Register(durations)
+	var are prometheus.AlreadyRegisteredError
+	if errors.As(err, &are) {
+		if existing, ok := are.ExistingCollector.(*prometheus.HistogramVec); ok {
+			return existing, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return durations, nil
+}
+
+// observe records the duration of operation since start
+func (d *db) observe(operation string, start time.Time) {
+	if d.durations == nil {
+		return
+	}
+	d.durations.WithLabelValues(operation).Observe(time.Since(start).Seconds())
 }
 
 func (d *db) ReadState(ctx context.Context, cd ocrtypes.ConfigDigest) (ps *ocrtypes.PersistentState, err error) {
+	defer d.observe("read_state", time.Now())
+
 	q := d.QueryRowContext(ctx, `
 SELECT epoch, highest_sent_epoch, highest_received_epoch
 FROM offchainreporting_persistent_states
@@ -59,12 +115,23 @@ LIMIT 1`, d.oracleSpecID, cd)
 	return ps, nil
 }
 
+// execer is satisfied by both *sql.DB and *sqlx.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
 func (d *db) WriteState(ctx context.Context, cd ocrtypes.ConfigDigest, state ocrtypes.PersistentState) error {
+	defer d.observe("write_state", time.Now())
+
+	return d.writeState(ctx, d.DB, cd, state)
+}
+
+func (d *db) writeState(ctx context.Context, q execer, cd ocrtypes.ConfigDigest, state ocrtypes.PersistentState) error {
 	var highestReceivedEpoch []int64
 	for _, v := range state.HighestReceivedEpoch {
 		highestReceivedEpoch = append(highestReceivedEpoch, int64(v))
 	}
-	_, err := d.ExecContext(ctx, `
+	_, err := q.ExecContext(ctx, `
 INSERT INTO offchainreporting_persistent_states (offchainreporting_oracle_spec_id, config_digest, epoch, highest_sent_epoch, highest_received_epoch, created_at, updated_at)
 VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
 ON CONFLICT (offchainreporting_oracle_spec_id, config_digest) DO UPDATE SET
@@ -82,24 +149,71 @@ ON CONFLICT (offchainreporting_oracle_spec_id, config_digest) DO UPDATE SET
 }
 
 func (d *db) ReadConfig(ctx context.Context) (c *ocrtypes.ContractConfig, err error) {
+	defer d.observe("read_config", time.Now())
+
 	q := d.QueryRowContext(ctx, `
 	SELECT config_digest, signers, transmitters, threshold, encoded_config_version, encoded
 	FROM offchainreporting_contract_configs
 	WHERE offchainreporting_oracle_spec_id = $1
 	LIMIT 1`, d.oracleSpecID)
 
-	c = new(ocrtypes.ContractConfig)
-
-	var signers [][]byte
-	var transmitters [][]byte
-
-	err = q.Scan(&c.ConfigDigest, (*pq.ByteaArray)(&signers), (*pq.ByteaArray)(&transmitters), &c.Threshold, &c.EncodedConfigVersion, &c.Encoded)
+	c, err = scanConfig(q)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	} else if err != nil {
 		return nil, errors.Wrap(err, "ReadConfig failed")
 	}
 
+	// Configs are decoded according to the version they were stored with, so
+	// that a config is never read with the encoding of another version
+	decode, ok := d.configDecoders[c.EncodedConfigVersion]
+	if !ok {
+		return nil, errors.Errorf("ReadConfig failed: unknown encoded config version %d", c.EncodedConfigVersion)
+	}
+	if c.Encoded, err = decode(c.Encoded); err != nil {
+		return nil, errors.Wrapf(err, "ReadConfig failed to decode config of version %d", c.EncodedConfigVersion)
+	}
+
+	return c, nil
+}
+
+// ConfigHistory returns the configs of this spec that were overwritten by
+// WriteConfig, newest first. The current config, as returned by ReadConfig,
+// is not included.
+func (d *db) ConfigHistory(ctx context.Context) (cs []ocrtypes.ContractConfig, err error) {
+	rows, err := d.QueryContext(ctx, `
+	SELECT config_digest, signers, transmitters, threshold, encoded_config_version, encoded
+	FROM offchainreporting_contract_config_history
+	WHERE offchainreporting_oracle_spec_id = $1
+	ORDER BY id DESC`, d.oracleSpecID)
+	if err != nil {
+		return nil, errors.Wrap(err, "ConfigHistory failed to query rows")
+	}
+	defer func() { err = multierr.Combine(err, rows.Close()) }()
+
+	for rows.Next() {
+		c, err := scanConfig(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "ConfigHistory failed to scan row")
+		}
+		cs = append(cs, *c)
+	}
+
+	return cs, errors.Wrap(rows.Err(), "ConfigHistory failed to iterate rows")
+}
+
+// scanConfig scans a contract config from a row of config_digest, signers,
+// transmitters, threshold, encoded_config_version and encoded
+func scanConfig(row interface{ Scan(...interface{}) error }) (*ocrtypes.ContractConfig, error) {
+	c := new(ocrtypes.ContractConfig)
+
+	var signers [][]byte
+	var transmitters [][]byte
+
+	if err := row.Scan(&c.ConfigDigest, (*pq.ByteaArray)(&signers), (*pq.ByteaArray)(&transmitters), &c.Threshold, &c.EncodedConfigVersion, &c.Encoded); err != nil {
+		return nil, err
+	}
+
 	for _, s := range signers {
 		c.Signers = append(c.Signers, common.BytesToAddress(s))
 	}
@@ -107,10 +221,16 @@ func (d *db) ReadConfig(ctx context.Context) (c *ocrtypes.ContractConfig, err er
 		c.Transmitters = append(c.Transmitters, common.BytesToAddress(t))
 	}
 
-	return
+	return c, nil
 }
 
 func (d *db) WriteConfig(ctx context.Context, c ocrtypes.ContractConfig) error {
+	defer d.observe("write_config", time.Now())
+
+	return d.writeConfig(ctx, d.DB, c)
+}
+
+func (d *db) writeConfig(ctx context.Context, q execer, c ocrtypes.ContractConfig) error {
 	var signers [][]byte
 	var transmitters [][]byte
 	for _, s := range c.Signers {
@@ -119,7 +239,15 @@ func (d *db) WriteConfig(ctx context.Context, c ocrtypes.ContractConfig) error {
 	for _, t := range c.Transmitters {
 		transmitters = append(transmitters, t.Bytes())
 	}
-	_, err := d.ExecContext(ctx, `
+	// The config being replaced, if it differs, is appended to the history in
+	// the same statement, so that it cannot be lost
+	_, err := q.ExecContext(ctx, `
+WITH superseded AS (
+	INSERT INTO offchainreporting_contract_config_history (offchainreporting_oracle_spec_id, config_digest, signers, transmitters, threshold, encoded_config_version, encoded, created_at, superseded_at)
+	SELECT offchainreporting_oracle_spec_id, config_digest, signers, transmitters, threshold, encoded_config_version, encoded, updated_at, NOW()
+	FROM offchainreporting_contract_configs
+	WHERE offchainreporting_oracle_spec_id = $1 AND config_digest <> $2
+)
 INSERT INTO offchainreporting_contract_configs (offchainreporting_oracle_spec_id, config_digest, signers, transmitters, threshold, encoded_config_version, encoded, created_at, updated_at)
 VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
 ON CONFLICT (offchainreporting_oracle_spec_id) DO UPDATE SET
@@ -135,10 +263,83 @@ ON CONFLICT (offchainreporting_oracle_spec_id) DO UPDATE SET
 	return errors.Wrap(err, "WriteConfig failed")
 }
 
+// WriteConfigAndState writes the contract config and the persistent state for
+// the given config digest in a single transaction, so that a crash cannot
+// leave one persisted without the other.
+func (d *db) WriteConfigAndState(ctx context.Context, c ocrtypes.ContractConfig, cd ocrtypes.ConfigDigest, state ocrtypes.PersistentState) error {
+	err := postgres.SqlTransaction(ctx, d.DB, d.lggr, func(tx *sqlx.Tx) error {
+		if err := d.writeConfig(ctx, tx, c); err != nil {
+			return err
+		}
+		return d.writeState(ctx, tx, cd, state)
+	})
+	return errors.Wrap(err, "WriteConfigAndState failed")
+}
+
+var pendingTransmissionInsertColumns = []string{
+	"offchainreporting_oracle_spec_id",
+	"config_digest",
+	"epoch",
+	"round",
+	"time",
+	"median",
+	"serialized_report",
+	"rs",
+	"ss",
+	"vs",
+	"created_at",
+	"updated_at",
+}
+
+const upsertPendingTransmissionSQL = `ON CONFLICT (offchainreporting_oracle_spec_id, config_digest, epoch, round) DO UPDATE SET
+	time = EXCLUDED.time,
+	median = EXCLUDED.median,
+	serialized_report = EXCLUDED.serialized_report,
+	rs = EXCLUDED.rs,
+	ss = EXCLUDED.ss,
+	vs = EXCLUDED.vs,
+	updated_at = EXCLUDED.updated_at`
+
 func (d *db) StorePendingTransmission(ctx context.Context, k ocrtypes.PendingTransmissionKey, p ocrtypes.PendingTransmission) error {
+	defer d.observe("store_pending_transmission", time.Now())
+
+	err := d.storePendingTransmissions(ctx, map[ocrtypes.PendingTransmissionKey]ocrtypes.PendingTransmission{k: p})
+	return errors.Wrap(err, "StorePendingTransmission failed")
+}
+
+// StorePendingTransmissions upserts all the given pending transmissions in a
+// single transaction, so either all of them are stored or none are. They are
+// split across as many statements as needed to stay within Postgres's bind
+// parameter limit.
+func (d *db) StorePendingTransmissions(ctx context.Context, items map[ocrtypes.PendingTransmissionKey]ocrtypes.PendingTransmission) error {
+	return errors.Wrap(d.storePendingTransmissions(ctx, items), "StorePendingTransmissions failed")
+}
+
+func (d *db) storePendingTransmissions(ctx context.Context, items map[ocrtypes.PendingTransmissionKey]ocrtypes.PendingTransmission) error {
+	if len(items) == 0 {
+		return nil
+	}
+	now := time.Now()
+	rows := make([][]interface{}, 0, len(items))
+	for k, p := range items {
+		rows = append(rows, append(d.pendingTransmissionArgs(k, p), now, now))
+	}
+
+	return postgres.SqlTransaction(ctx, d.DB, d.lggr, func(tx *sqlx.Tx) error {
+		return postgres.BulkUpsert(tx, "offchainreporting_pending_transmissions", pendingTransmissionInsertColumns, rows, 0, upsertPendingTransmissionSQL)
+	})
+}
+
+func (d *db) pendingTransmissionArgs(k ocrtypes.PendingTransmissionKey, p ocrtypes.PendingTransmission) []interface{} {
 	median := utils.NewBig(p.Median)
-	var rs [][]byte
-	var ss [][]byte
+	// rs, ss and serialized_report are NOT NULL, and nil slices would be
+	// written as NULL, so empty values are always stored as empty
+	rs := make([][]byte, 0, len(p.Rs))
+	ss := make([][]byte, 0, len(p.Ss))
+	serializedReport := p.SerializedReport
+	if serializedReport == nil {
+		serializedReport = []byte{}
+	}
 	// Note: p.Rs and p.Ss are of type [][32]byte.
 	// See last example of https://github.com/golang/go/wiki/CommonMistakes#using-reference-to-loop-iterator-variable
 	for _, v := range p.Rs {
@@ -150,47 +351,19 @@ func (d *db) StorePendingTransmission(ctx context.Context, k ocrtypes.PendingTra
 		ss = append(ss, v[:])
 	}
 
-	_, err := d.ExecContext(ctx, `
-INSERT INTO offchainreporting_pending_transmissions (
-	offchainreporting_oracle_spec_id,
-	config_digest,
-	epoch,
-	round,
-	time,
-	median,
-	serialized_report,
-	rs,
-	ss,
-	vs,
-	created_at,
-	updated_at
-)
-VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,NOW(),NOW())
-ON CONFLICT (offchainreporting_oracle_spec_id, config_digest, epoch, round) DO UPDATE SET
-	time = EXCLUDED.time,
-	median = EXCLUDED.median,
-	serialized_report = EXCLUDED.serialized_report,
-	rs = EXCLUDED.rs,
-	ss = EXCLUDED.ss,
-	vs = EXCLUDED.vs,
-	updated_at = NOW()
-`, d.oracleSpecID, k.ConfigDigest, k.Epoch, k.Round, p.Time, median, p.SerializedReport, pq.ByteaArray(rs), pq.ByteaArray(ss), p.Vs[:])
+	return []interface{}{d.oracleSpecID, k.ConfigDigest, k.Epoch, k.Round, truncateToPostgresPrecision(p.Time), median, serializedReport, pq.ByteaArray(rs), pq.ByteaArray(ss), p.Vs[:]}
+}
 
-	return errors.Wrap(err, "StorePendingTransmission failed")
+// truncateToPostgresPrecision drops the nanoseconds that a timestamptz column
+// cannot hold. Left to Postgres they would be rounded rather than truncated,
+// which can move a time across a boundary used by DeletePendingTransmissionsOlderThan.
+func truncateToPostgresPrecision(t time.Time) time.Time {
+	return t.Truncate(time.Microsecond)
 }
 
 func (d *db) PendingTransmissionsWithConfigDigest(ctx context.Context, cd ocrtypes.ConfigDigest) (map[ocrtypes.PendingTransmissionKey]ocrtypes.PendingTransmission, error) {
 	rows, err := d.QueryContext(ctx, `
-SELECT
-	config_digest,
-	epoch,
-	round,
-	time,
-	median,
-	serialized_report,
-	rs,
-	ss,
-	vs
+SELECT `+pendingTransmissionColumns+`
 FROM offchainreporting_pending_transmissions
 WHERE offchainreporting_oracle_spec_id = $1 AND config_digest = $2
 `, d.oracleSpecID, cd)
@@ -202,34 +375,10 @@ WHERE offchainreporting_oracle_spec_id = $1 AND config_digest = $2
 	m := make(map[ocrtypes.PendingTransmissionKey]ocrtypes.PendingTransmission)
 
 	for rows.Next() {
-		k := ocrtypes.PendingTransmissionKey{}
-		p := ocrtypes.PendingTransmission{}
-
-		var median utils.Big
-		var rs [][]byte
-		var ss [][]byte
-		var vs []byte
-		if err := rows.Scan(&k.ConfigDigest, &k.Epoch, &k.Round, &p.Time, &median, &p.SerializedReport, (*pq.ByteaArray)(&rs), (*pq.ByteaArray)(&ss), &vs); err != nil {
+		k, p, err := scanPendingTransmission(rows)
+		if err != nil {
 			return nil, errors.Wrap(err, "PendingTransmissionsWithConfigDigest failed to scan row")
 		}
-		p.Median = median.ToInt()
-		for i, v := range rs {
-			var r [32]byte
-			if n := copy(r[:], v); n != 32 {
-				return nil, errors.Errorf("expected 32 bytes for rs value at index %v, got %v bytes", i, n)
-			}
-			p.Rs = append(p.Rs, r)
-		}
-		for i, v := range ss {
-			var s [32]byte
-			if n := copy(s[:], v); n != 32 {
-				return nil, errors.Errorf("expected 32 bytes for ss value at index %v, got %v bytes", i, n)
-			}
-			p.Ss = append(p.Ss, s)
-		}
-		if n := copy(p.Vs[:], vs); n != 32 {
-			return nil, errors.Errorf("expected 32 bytes for vs, got %v bytes", n)
-		}
 		m[k] = p
 	}
 
@@ -240,26 +389,208 @@ WHERE offchainreporting_oracle_spec_id = $1 AND config_digest = $2
 	return m, nil
 }
 
+// PendingTransmissionRecord is a pending transmission together with its key
+type PendingTransmissionRecord struct {
+	Key          ocrtypes.PendingTransmissionKey
+	Transmission ocrtypes.PendingTransmission
+}
+
+// PendingTransmissionsPage returns up to limit pending transmissions stored
+// for this spec with the given config digest, ordered by epoch and round and
+// starting at offset, along with the total number of such transmissions.
+func (d *db) PendingTransmissionsPage(ctx context.Context, cd ocrtypes.ConfigDigest, offset, limit int) ([]PendingTransmissionRecord, int, error) {
+	count, err := d.CountPendingTransmissions(ctx, cd)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "PendingTransmissionsPage failed to count rows")
+	}
+
+	rows, err := d.QueryContext(ctx, `
+SELECT `+pendingTransmissionColumns+`
+FROM offchainreporting_pending_transmissions
+WHERE offchainreporting_oracle_spec_id = $1 AND config_digest = $2
+ORDER BY epoch ASC, round ASC
+OFFSET $3 LIMIT $4
+`, d.oracleSpecID, cd, offset, limit)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "PendingTransmissionsPage failed to query rows")
+	}
+	defer d.lggr.ErrorIfClosing(rows, "offchainreporting_pending_transmissions rows")
+
+	records := []PendingTransmissionRecord{}
+	for rows.Next() {
+		k, p, err := scanPendingTransmission(rows)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "PendingTransmissionsPage failed to scan row")
+		}
+		records = append(records, PendingTransmissionRecord{Key: k, Transmission: p})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return records, count, nil
+}
+
+const pendingTransmissionColumns = `
+	config_digest,
+	epoch,
+	round,
+	time,
+	median,
+	serialized_report,
+	rs,
+	ss,
+	vs`
+
+// scanPendingTransmission scans a row of pendingTransmissionColumns
+func scanPendingTransmission(rows *sql.Rows) (k ocrtypes.PendingTransmissionKey, p ocrtypes.PendingTransmission, err error) {
+	var median utils.Big
+	var rs [][]byte
+	var ss [][]byte
+	var vs []byte
+	if err = rows.Scan(&k.ConfigDigest, &k.Epoch, &k.Round, &p.Time, &median, &p.SerializedReport, (*pq.ByteaArray)(&rs), (*pq.ByteaArray)(&ss), &vs); err != nil {
+		return k, p, err
+	}
+	p.Median = median.ToInt()
+	// Empty rs and ss are read back as empty, not nil, slices
+	p.Rs = make([][32]byte, 0, len(rs))
+	for i, v := range rs {
+		if len(v) != 32 {
+			return k, p, errors.Errorf("expected 32 bytes for rs value at index %v, got %v bytes", i, len(v))
+		}
+		var r [32]byte
+		copy(r[:], v)
+		p.Rs = append(p.Rs, r)
+	}
+	p.Ss = make([][32]byte, 0, len(ss))
+	for i, v := range ss {
+		if len(v) != 32 {
+			return k, p, errors.Errorf("expected 32 bytes for ss value at index %v, got %v bytes", i, len(v))
+		}
+		var s [32]byte
+		copy(s[:], v)
+		p.Ss = append(p.Ss, s)
+	}
+	if len(vs) != 32 {
+		return k, p, errors.Errorf("expected 32 bytes for vs, got %v bytes", len(vs))
+	}
+	copy(p.Vs[:], vs)
+	if p.SerializedReport == nil {
+		p.SerializedReport = []byte{}
+	}
+	return k, p, nil
+}
+
+// CountPendingTransmissions returns the number of pending transmissions
+// stored for this spec with the given config digest.
+func (d *db) CountPendingTransmissions(ctx context.Context, cd ocrtypes.ConfigDigest) (count int, err error) {
+	err = d.QueryRowContext(ctx, `
+SELECT COUNT(*) FROM offchainreporting_pending_transmissions
+WHERE offchainreporting_oracle_spec_id = $1 AND config_digest = $2
+`, d.oracleSpecID, cd).Scan(&count)
+
+	err = errors.Wrap(err, "CountPendingTransmissions failed")
+
+	return
+}
+
+// ConfigDigests returns the distinct config digests for which this spec has
+// persistent state or pending transmissions stored.
+func (d *db) ConfigDigests(ctx context.Context) (cds []ocrtypes.ConfigDigest, err error) {
+	rows, err := d.QueryContext(ctx, `
+SELECT config_digest FROM offchainreporting_persistent_states WHERE offchainreporting_oracle_spec_id = $1
+UNION
+SELECT config_digest FROM offchainreporting_pending_transmissions WHERE offchainreporting_oracle_spec_id = $1
+ORDER BY config_digest
+`, d.oracleSpecID)
+	if err != nil {
+		return nil, errors.Wrap(err, "ConfigDigests failed to query rows")
+	}
+	defer d.lggr.ErrorIfClosing(rows, "config digest rows")
+
+	for rows.Next() {
+		var cd ocrtypes.ConfigDigest
+		if err := rows.Scan(&cd); err != nil {
+			return nil, errors.Wrap(err, "ConfigDigests failed to scan row")
+		}
+		cds = append(cds, cd)
+	}
+
+	return cds, rows.Err()
+}
+
 func (d *db) DeletePendingTransmission(ctx context.Context, k ocrtypes.PendingTransmissionKey) (err error) {
-	_, err = d.ExecContext(ctx, `
+	_, err = d.DeletePendingTransmissionIfExists(ctx, k)
+	return
+}
+
+// DeletePendingTransmissionIfExists behaves like DeletePendingTransmission but
+// also returns whether this spec had a pending transmission with key k. Use
+// it right after a transmission is confirmed on-chain: the row is read and
+// deleted in a single statement, and existed is false if it had already been
+// deleted, which lets the caller detect a double delete.
+func (d *db) DeletePendingTransmissionIfExists(ctx context.Context, k ocrtypes.PendingTransmissionKey) (existed bool, err error) {
+	result, err := d.ExecContext(ctx, `
 DELETE FROM offchainreporting_pending_transmissions
 WHERE offchainreporting_oracle_spec_id = $1 AND  config_digest = $2 AND epoch = $3 AND round = $4
 `, d.oracleSpecID, k.ConfigDigest, k.Epoch, k.Round)
+	if err != nil {
+		return false, errors.Wrap(err, "DeletePendingTransmission failed")
+	}
 
-	err = errors.Wrap(err, "DeletePendingTransmission failed")
+	n, err := result.RowsAffected()
+	return n > 0, errors.Wrap(err, "DeletePendingTransmission failed to get rows affected")
+}
 
-	return
+// DeletePendingTransmissions deletes this spec's pending transmissions with
+// any of keys in a single statement, and returns the number deleted. Keys
+// without a pending transmission are ignored.
+func (d *db) DeletePendingTransmissions(ctx context.Context, keys []ocrtypes.PendingTransmissionKey) (int64, error) {
+	if len(keys) == 0 {
+		return 0, nil
+	}
+	digests := make(pq.ByteaArray, len(keys))
+	epochs := make(pq.Int64Array, len(keys))
+	rounds := make(pq.Int64Array, len(keys))
+	for i := range keys {
+		digests[i] = keys[i].ConfigDigest[:]
+		epochs[i] = int64(keys[i].Epoch)
+		rounds[i] = int64(keys[i].Round)
+	}
+	result, err := d.ExecContext(ctx, `
+DELETE FROM offchainreporting_pending_transmissions
+WHERE offchainreporting_oracle_spec_id = $1 AND (config_digest, epoch, round) IN (
+	SELECT * FROM unnest($2::bytea[], $3::bigint[], $4::bigint[])
+)
+`, d.oracleSpecID, digests, epochs, rounds)
+	if err != nil {
+		return 0, errors.Wrap(err, "DeletePendingTransmissions failed")
+	}
+
+	n, err := result.RowsAffected()
+	return n, errors.Wrap(err, "DeletePendingTransmissions failed to get rows affected")
 }
 
 func (d *db) DeletePendingTransmissionsOlderThan(ctx context.Context, t time.Time) (err error) {
-	_, err = d.ExecContext(ctx, `
+	_, err = d.DeletePendingTransmissionsOlderThanN(ctx, t)
+	return
+}
+
+// DeletePendingTransmissionsOlderThanN behaves like
+// DeletePendingTransmissionsOlderThan but also returns the number of pending
+// transmissions that were deleted.
+func (d *db) DeletePendingTransmissionsOlderThanN(ctx context.Context, t time.Time) (int64, error) {
+	result, err := d.ExecContext(ctx, `
 DELETE FROM offchainreporting_pending_transmissions
 WHERE offchainreporting_oracle_spec_id = $1 AND time < $2
-`, d.oracleSpecID, t)
-
-	err = errors.Wrap(err, "DeletePendingTransmissionsOlderThan failed")
+`, d.oracleSpecID, truncateToPostgresPrecision(t))
+	if err != nil {
+		return 0, errors.Wrap(err, "DeletePendingTransmissionsOlderThan failed")
+	}
 
-	return
+	n, err := result.RowsAffected()
+	return n, errors.Wrap(err, "DeletePendingTransmissionsOlderThan failed to get rows affected")
 }
 
 func (d *db) SaveLatestRoundRequested(tx postgres.Queryer, rr offchainaggregator.OffchainAggregatorRoundRequested) error {
@@ -314,3 +645,38 @@ LIMIT 1
 
 	return
 }
+
+// LoadAllLatestRoundsRequested returns the latest round requested of every
+// spec, keyed by oracle spec ID. Specs that have had no round requested are
+// absent from the map.
+func LoadAllLatestRoundsRequested(sqldb *sql.DB) (rrs map[int32]offchainaggregator.OffchainAggregatorRoundRequested, err error) {
+	rows, err := sqldb.Query(`
+SELECT offchainreporting_oracle_spec_id, requester, config_digest, epoch, round, raw
+FROM offchainreporting_latest_round_requested
+`)
+	if err != nil {
+		return nil, errors.Wrap(err, "LoadAllLatestRoundsRequested failed to query rows")
+	}
+	defer func() { err = multierr.Combine(err, rows.Close()) }()
+
+	rrs = make(map[int32]offchainaggregator.OffchainAggregatorRoundRequested)
+	for rows.Next() {
+		var specID int32
+		var rr offchainaggregator.OffchainAggregatorRoundRequested
+		var configDigest []byte
+		var rawLog []byte
+
+		if err = rows.Scan(&specID, &rr.Requester, &configDigest, &rr.Epoch, &rr.Round, &rawLog); err != nil {
+			return nil, errors.Wrap(err, "LoadAllLatestRoundsRequested failed to scan row")
+		}
+		if rr.ConfigDigest, err = ocrtypes.BytesToConfigDigest(configDigest); err != nil {
+			return nil, errors.Wrapf(err, "LoadAllLatestRoundsRequested failed to decode config digest of spec %d", specID)
+		}
+		if err = json.Unmarshal(rawLog, &rr.Raw); err != nil {
+			return nil, errors.Wrapf(err, "LoadAllLatestRoundsRequested failed to unmarshal raw log of spec %d", specID)
+		}
+		rrs[specID] = rr
+	}
+
+	return rrs, errors.Wrap(rows.Err(), "LoadAllLatestRoundsRequested failed to iterate rows")
+}
@@ -3,12 +3,15 @@ package offchainreporting_test
 import (
 	"bytes"
 	"context"
+	"errors"
 	"math/big"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest/heavyweight"
 	"github.com/smartcontractkit/chainlink/core/internal/testutils/pgtest"
 	"github.com/smartcontractkit/chainlink/core/services/offchainreporting"
 	"github.com/smartcontractkit/chainlink/core/services/postgres"
@@ -111,7 +114,7 @@ func Test_DB_ReadWriteConfig(t *testing.T) {
 		Signers:              []common.Address{cltest.NewAddress(), cltest.NewAddress()},
 		Transmitters:         []common.Address{cltest.NewAddress(), cltest.NewAddress()},
 		Threshold:            uint8(35),
-		EncodedConfigVersion: uint64(987654),
+		EncodedConfigVersion: uint64(1),
 		Encoded:              []byte{1, 2, 3, 4, 5},
 	}
 	ethKeyStore := cltest.NewKeyStore(t, db).Eth()
@@ -139,7 +142,7 @@ func Test_DB_ReadWriteConfig(t *testing.T) {
 			Signers:              []common.Address{utils.ZeroAddress, transmitterAddress, cltest.NewAddress()},
 			Transmitters:         []common.Address{utils.ZeroAddress, transmitterAddress, cltest.NewAddress()},
 			Threshold:            uint8(36),
-			EncodedConfigVersion: uint64(987655),
+			EncodedConfigVersion: uint64(1),
 			Encoded:              []byte{2, 3, 4, 5, 6},
 		}
 
@@ -167,6 +170,281 @@ func Test_DB_ReadWriteConfig(t *testing.T) {
 	})
 }
 
+func Test_DB_ReadConfig_EncodedConfigVersion(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	sqlDB := db.DB
+
+	ethKeyStore := cltest.NewKeyStore(t, db).Eth()
+	key, _ := cltest.MustInsertRandomKey(t, ethKeyStore)
+	spec := cltest.MustInsertOffchainreportingOracleSpec(t, db, key.Address)
+
+	odb := offchainreporting.NewTestDB(t, sqlDB, spec.ID)
+	// A hypothetical version 2 prefixes the version 1 encoding with a byte
+	odb.ExportedAddConfigDecoder(2, func(encoded []byte) ([]byte, error) {
+		if len(encoded) == 0 || encoded[0] != 0xff {
+			return nil, errors.New("missing version 2 prefix")
+		}
+		return encoded[1:], nil
+	})
+
+	configWithVersion := func(version uint64, encoded []byte) ocrtypes.ContractConfig {
+		return ocrtypes.ContractConfig{
+			ConfigDigest:         cltest.MakeConfigDigest(t),
+			Signers:              []common.Address{cltest.NewAddress()},
+			Transmitters:         []common.Address{cltest.NewAddress()},
+			Threshold:            uint8(35),
+			EncodedConfigVersion: version,
+			Encoded:              encoded,
+		}
+	}
+
+	t.Run("reads a version 1 config", func(t *testing.T) {
+		config := configWithVersion(1, []byte{1, 2, 3, 4, 5})
+		require.NoError(t, odb.WriteConfig(ctx, config))
+
+		readConfig, err := odb.ReadConfig(ctx)
+		require.NoError(t, err)
+		require.Equal(t, &config, readConfig)
+	})
+
+	t.Run("decodes a version 2 config", func(t *testing.T) {
+		config := configWithVersion(2, []byte{0xff, 1, 2, 3, 4, 5})
+		require.NoError(t, odb.WriteConfig(ctx, config))
+
+		readConfig, err := odb.ReadConfig(ctx)
+		require.NoError(t, err)
+		config.Encoded = []byte{1, 2, 3, 4, 5}
+		require.Equal(t, &config, readConfig)
+
+		require.NoError(t, odb.WriteConfig(ctx, configWithVersion(2, []byte{1, 2, 3})))
+		_, err = odb.ReadConfig(ctx)
+		require.EqualError(t, err, "ReadConfig failed to decode config of version 2: missing version 2 prefix")
+	})
+
+	t.Run("errors for an unknown version", func(t *testing.T) {
+		require.NoError(t, odb.WriteConfig(ctx, configWithVersion(987654, []byte{1, 2, 3, 4, 5})))
+
+		_, err := odb.ReadConfig(ctx)
+		require.EqualError(t, err, "ReadConfig failed: unknown encoded config version 987654")
+	})
+}
+
+func Test_DB_ConfigHistory(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	sqlDB := db.DB
+
+	ethKeyStore := cltest.NewKeyStore(t, db).Eth()
+	key, _ := cltest.MustInsertRandomKey(t, ethKeyStore)
+	spec := cltest.MustInsertOffchainreportingOracleSpec(t, db, key.Address)
+	spec2 := cltest.MustInsertOffchainreportingOracleSpec(t, db, key.Address)
+	odb := offchainreporting.NewTestDB(t, sqlDB, spec.ID)
+	odb2 := offchainreporting.NewTestDB(t, sqlDB, spec2.ID)
+
+	history, err := odb.ConfigHistory(ctx)
+	require.NoError(t, err)
+	require.Empty(t, history)
+
+	var configs []ocrtypes.ContractConfig
+	for i := 0; i < 3; i++ {
+		config := ocrtypes.ContractConfig{
+			ConfigDigest:         cltest.MakeConfigDigest(t),
+			Signers:              []common.Address{cltest.NewAddress()},
+			Transmitters:         []common.Address{cltest.NewAddress()},
+			Threshold:            uint8(35 + i),
+			EncodedConfigVersion: uint64(1),
+			Encoded:              []byte{1, 2, 3, byte(i)},
+		}
+		require.NoError(t, odb.WriteConfig(ctx, config))
+		configs = append(configs, config)
+	}
+	// Writing the current config again does not add it to the history
+	require.NoError(t, odb.WriteConfig(ctx, configs[2]))
+	require.NoError(t, odb2.WriteConfig(ctx, configs[0]))
+
+	readConfig, err := odb.ReadConfig(ctx)
+	require.NoError(t, err)
+	require.Equal(t, &configs[2], readConfig)
+
+	history, err = odb.ConfigHistory(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []ocrtypes.ContractConfig{configs[1], configs[0]}, history)
+
+	history, err = odb2.ConfigHistory(ctx)
+	require.NoError(t, err)
+	require.Empty(t, history)
+}
+
+func Test_DB_WriteConfigAndState(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	sqlDB := db.DB
+
+	ethKeyStore := cltest.NewKeyStore(t, db).Eth()
+	key, _ := cltest.MustInsertRandomKey(t, ethKeyStore)
+	spec := cltest.MustInsertOffchainreportingOracleSpec(t, db, key.Address)
+
+	config := ocrtypes.ContractConfig{
+		ConfigDigest:         cltest.MakeConfigDigest(t),
+		Signers:              []common.Address{cltest.NewAddress()},
+		Transmitters:         []common.Address{cltest.NewAddress()},
+		Threshold:            uint8(35),
+		EncodedConfigVersion: uint64(1),
+		Encoded:              []byte{1, 2, 3, 4, 5},
+	}
+	state := ocrtypes.PersistentState{
+		Epoch:                1,
+		HighestSentEpoch:     2,
+		HighestReceivedEpoch: []uint32{3},
+	}
+
+	t.Run("writes config and state together", func(t *testing.T) {
+		odb := offchainreporting.NewTestDB(t, sqlDB, spec.ID)
+
+		require.NoError(t, odb.WriteConfigAndState(ctx, config, config.ConfigDigest, state))
+
+		readConfig, err := odb.ReadConfig(ctx)
+		require.NoError(t, err)
+		require.Equal(t, &config, readConfig)
+
+		readState, err := odb.ReadState(ctx, config.ConfigDigest)
+		require.NoError(t, err)
+		require.Equal(t, state, *readState)
+	})
+
+	t.Run("rolls back the config if writing the state fails", func(t *testing.T) {
+		// Needs a real database, since transactions are no-ops in pgtest.NewSqlxDB
+		_, db := heavyweight.FullTestDB(t, "ocr_write_config_and_state", true, false)
+		key, _ := cltest.MustInsertRandomKey(t, cltest.NewKeyStore(t, db).Eth())
+		spec := cltest.MustInsertOffchainreportingOracleSpec(t, db, key.Address)
+		odb := offchainreporting.NewTestDB(t, db.DB, spec.ID)
+
+		_, err := db.Exec(`
+CREATE FUNCTION fail_persistent_state_insert() RETURNS trigger AS $$
+BEGIN
+	RAISE EXCEPTION 'injected failure';
+END
+$$ LANGUAGE plpgsql;
+CREATE TRIGGER fail_persistent_state_insert BEFORE INSERT ON offchainreporting_persistent_states
+	FOR EACH ROW EXECUTE PROCEDURE fail_persistent_state_insert();
+`)
+		require.NoError(t, err)
+
+		err = odb.WriteConfigAndState(ctx, config, config.ConfigDigest, state)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "injected failure")
+
+		readConfig, err := odb.ReadConfig(ctx)
+		require.NoError(t, err)
+		require.Nil(t, readConfig)
+	})
+}
+
+func Test_DB_ConfigDigests(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	sqlDB := db.DB
+
+	ethKeyStore := cltest.NewKeyStore(t, db).Eth()
+	key, _ := cltest.MustInsertRandomKey(t, ethKeyStore)
+	spec := cltest.MustInsertOffchainreportingOracleSpec(t, db, key.Address)
+	spec2 := cltest.MustInsertOffchainreportingOracleSpec(t, db, key.Address)
+	odb := offchainreporting.NewTestDB(t, sqlDB, spec.ID)
+	odb2 := offchainreporting.NewTestDB(t, sqlDB, spec2.ID)
+
+	cds, err := odb.ConfigDigests(ctx)
+	require.NoError(t, err)
+	require.Len(t, cds, 0)
+
+	cd1 := cltest.MakeConfigDigest(t)
+	cd2 := cltest.MakeConfigDigest(t)
+	cd3 := cltest.MakeConfigDigest(t)
+
+	state := ocrtypes.PersistentState{Epoch: 1, HighestSentEpoch: 2, HighestReceivedEpoch: []uint32{3}}
+	p := ocrtypes.PendingTransmission{
+		Time:             time.Now(),
+		Median:           ocrtypes.Observation(big.NewInt(41)),
+		SerializedReport: []byte{0, 2, 3},
+		Rs:               [][32]byte{cltest.Random32Byte()},
+		Ss:               [][32]byte{cltest.Random32Byte()},
+		Vs:               cltest.Random32Byte(),
+	}
+
+	// cd1 has both state and a pending transmission, cd2 only a pending transmission
+	require.NoError(t, odb.WriteState(ctx, cd1, state))
+	require.NoError(t, odb.StorePendingTransmission(ctx, ocrtypes.PendingTransmissionKey{ConfigDigest: cd1, Epoch: 1, Round: 1}, p))
+	require.NoError(t, odb.StorePendingTransmission(ctx, ocrtypes.PendingTransmissionKey{ConfigDigest: cd2, Epoch: 1, Round: 1}, p))
+	// cd3 belongs to the other spec
+	require.NoError(t, odb2.WriteState(ctx, cd3, state))
+
+	cds, err = odb.ConfigDigests(ctx)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []ocrtypes.ConfigDigest{cd1, cd2}, cds)
+
+	cds, err = odb2.ConfigDigests(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []ocrtypes.ConfigDigest{cd3}, cds)
+}
+
+func Test_DB_Metrics(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	sqlDB := db.DB
+
+	ethKeyStore := cltest.NewKeyStore(t, db).Eth()
+	key, _ := cltest.MustInsertRandomKey(t, ethKeyStore)
+	spec := cltest.MustInsertOffchainreportingOracleSpec(t, db, key.Address)
+	spec2 := cltest.MustInsertOffchainreportingOracleSpec(t, db, key.Address)
+
+	registry := prometheus.NewRegistry()
+	odb := offchainreporting.NewTestDBWithRegisterer(t, sqlDB, spec.ID, registry)
+	// The histogram is shared rather than registered a second time
+	odb2 := offchainreporting.NewTestDBWithRegisterer(t, sqlDB, spec2.ID, registry)
+
+	cd := cltest.MakeConfigDigest(t)
+	state := ocrtypes.PersistentState{Epoch: 1, HighestSentEpoch: 2, HighestReceivedEpoch: []uint32{3}}
+	config := ocrtypes.ContractConfig{
+		ConfigDigest:         cd,
+		Signers:              []common.Address{cltest.NewAddress()},
+		Transmitters:         []common.Address{cltest.NewAddress()},
+		Threshold:            uint8(35),
+		EncodedConfigVersion: uint64(1),
+		Encoded:              []byte{1, 2, 3, 4, 5},
+	}
+
+	require.NoError(t, odb.WriteState(ctx, cd, state))
+	require.NoError(t, odb2.WriteState(ctx, cd, state))
+	for i := 0; i < 3; i++ {
+		_, err := odb.ReadState(ctx, cd)
+		require.NoError(t, err)
+	}
+	require.NoError(t, odb.WriteConfig(ctx, config))
+	_, err := odb.ReadConfig(ctx)
+	require.NoError(t, err)
+	require.NoError(t, odb.StorePendingTransmission(ctx, ocrtypes.PendingTransmissionKey{ConfigDigest: cd, Epoch: 1, Round: 1}, ocrtypes.PendingTransmission{
+		Time:             time.Now(),
+		Median:           ocrtypes.Observation(big.NewInt(41)),
+		SerializedReport: []byte{0, 2, 3},
+		Rs:               [][32]byte{cltest.Random32Byte()},
+		Ss:               [][32]byte{cltest.Random32Byte()},
+		Vs:               cltest.Random32Byte(),
+	}))
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 1)
+	assert.Equal(t, "ocr_db_operation_duration_seconds", families[0].GetName())
+
+	counts := make(map[string]uint64)
+	for _, m := range families[0].GetMetric() {
+		require.Len(t, m.GetLabel(), 1)
+		counts[m.GetLabel()[0].GetValue()] = m.GetHistogram().GetSampleCount()
+	}
+	assert.Equal(t, map[string]uint64{
+		"write_state":                2,
+		"read_state":                 3,
+		"write_config":               1,
+		"read_config":                1,
+		"store_pending_transmission": 1,
+	}, counts)
+}
+
 func assertPendingTransmissionEqual(t *testing.T, pt1, pt2 ocrtypes.PendingTransmission) {
 	t.Helper()
 
@@ -271,9 +549,9 @@ func Test_DB_PendingTransmissions(t *testing.T) {
 
 		require.Len(t, m, 2)
 
-		// HACK to get around time equality because otherwise its annoying (time storage into postgres is mildly lossy)
-		require.Equal(t, p.Time.Unix(), m[k].Time.Unix())
-		require.Equal(t, p2.Time.Unix(), m[k2].Time.Unix())
+		// Postgres stores times to microsecond precision
+		require.True(t, p.Time.Truncate(time.Microsecond).Equal(m[k].Time))
+		require.True(t, p2.Time.Truncate(time.Microsecond).Equal(m[k2].Time))
 
 		var zt time.Time
 		p.Time, p2.Time = zt, zt
@@ -318,6 +596,79 @@ func Test_DB_PendingTransmissions(t *testing.T) {
 		require.Len(t, m, 1)
 	})
 
+	t.Run("reports whether the deleted pending transmission existed", func(t *testing.T) {
+		k := ocrtypes.PendingTransmissionKey{ConfigDigest: cltest.MakeConfigDigest(t), Epoch: 3, Round: 4}
+		p := ocrtypes.PendingTransmission{
+			Time:             time.Unix(100, 0),
+			Median:           ocrtypes.Observation(big.NewInt(44)),
+			SerializedReport: []byte{1, 4, 3},
+			Rs:               [][32]byte{cltest.Random32Byte()},
+			Ss:               [][32]byte{cltest.Random32Byte()},
+			Vs:               cltest.Random32Byte(),
+		}
+		require.NoError(t, odb.StorePendingTransmission(ctx, k, p))
+		require.NoError(t, odb2.StorePendingTransmission(ctx, k, p))
+
+		existed, err := odb.DeletePendingTransmissionIfExists(ctx, k)
+		require.NoError(t, err)
+		require.True(t, existed)
+
+		m, err := odb.PendingTransmissionsWithConfigDigest(ctx, k.ConfigDigest)
+		require.NoError(t, err)
+		require.Len(t, m, 0)
+
+		// Deleting it again is not an error, but is reported
+		existed, err = odb.DeletePendingTransmissionIfExists(ctx, k)
+		require.NoError(t, err)
+		require.False(t, existed)
+
+		// Did not affect other oracleSpecID
+		m, err = odb2.PendingTransmissionsWithConfigDigest(ctx, k.ConfigDigest)
+		require.NoError(t, err)
+		require.Len(t, m, 1)
+	})
+
+	t.Run("deletes pending transmissions by key set", func(t *testing.T) {
+		cd := cltest.MakeConfigDigest(t)
+		p := ocrtypes.PendingTransmission{
+			Time:             time.Unix(100, 0),
+			Median:           ocrtypes.Observation(big.NewInt(44)),
+			SerializedReport: []byte{1, 4, 3},
+			Rs:               [][32]byte{cltest.Random32Byte()},
+			Ss:               [][32]byte{cltest.Random32Byte()},
+			Vs:               cltest.Random32Byte(),
+		}
+		var keys []ocrtypes.PendingTransmissionKey
+		for i := 0; i < 4; i++ {
+			k := ocrtypes.PendingTransmissionKey{ConfigDigest: cd, Epoch: 1, Round: uint8(i + 1)}
+			require.NoError(t, odb.StorePendingTransmission(ctx, k, p))
+			keys = append(keys, k)
+		}
+		require.NoError(t, odb2.StorePendingTransmission(ctx, keys[0], p))
+
+		n, err := odb.DeletePendingTransmissions(ctx, nil)
+		require.NoError(t, err)
+		require.Equal(t, int64(0), n)
+
+		// A key without a pending transmission is ignored
+		missing := ocrtypes.PendingTransmissionKey{ConfigDigest: cd, Epoch: 2, Round: 1}
+		n, err = odb.DeletePendingTransmissions(ctx, []ocrtypes.PendingTransmissionKey{keys[0], keys[2], missing})
+		require.NoError(t, err)
+		require.Equal(t, int64(2), n)
+
+		m, err := odb.PendingTransmissionsWithConfigDigest(ctx, cd)
+		require.NoError(t, err)
+		require.Len(t, m, 2)
+		require.Contains(t, m, keys[1])
+		require.Contains(t, m, keys[3])
+
+		// Did not affect other oracleSpecID
+		m, err = odb2.PendingTransmissionsWithConfigDigest(ctx, cd)
+		require.NoError(t, err)
+		require.Len(t, m, 1)
+		require.Contains(t, m, keys[0])
+	})
+
 	t.Run("allows multiple duplicate keys for different spec ID", func(t *testing.T) {
 		p := ocrtypes.PendingTransmission{
 			Time:             time.Unix(100, 0),
@@ -385,6 +736,333 @@ func Test_DB_PendingTransmissions(t *testing.T) {
 		require.NoError(t, err)
 		require.Len(t, m, 1)
 	})
+
+	t.Run("preserves sub-second precision of the transmission time", func(t *testing.T) {
+		odb := offchainreporting.NewTestDB(t, sqlDB, spec.ID)
+		cd := cltest.MakeConfigDigest(t)
+
+		k := ocrtypes.PendingTransmissionKey{ConfigDigest: cd, Epoch: 1, Round: 1}
+		p := ocrtypes.PendingTransmission{
+			Time:             time.Unix(1000, 123456789),
+			Median:           ocrtypes.Observation(big.NewInt(41)),
+			SerializedReport: []byte{0, 2, 3},
+			Rs:               [][32]byte{cltest.Random32Byte()},
+			Ss:               [][32]byte{cltest.Random32Byte()},
+			Vs:               cltest.Random32Byte(),
+		}
+		require.NoError(t, odb.StorePendingTransmission(ctx, k, p))
+
+		m, err := odb.PendingTransmissionsWithConfigDigest(ctx, cd)
+		require.NoError(t, err)
+		require.Equal(t, time.Unix(1000, 123456000).UTC(), m[k].Time.UTC())
+
+		// A cutoff within the same microsecond does not delete it
+		n, err := odb.DeletePendingTransmissionsOlderThanN(ctx, time.Unix(1000, 123456999))
+		require.NoError(t, err)
+		require.Equal(t, int64(0), n)
+
+		n, err = odb.DeletePendingTransmissionsOlderThanN(ctx, time.Unix(1000, 123457000))
+		require.NoError(t, err)
+		require.Equal(t, int64(1), n)
+	})
+
+	t.Run("counts pending transmissions", func(t *testing.T) {
+		odb := offchainreporting.NewTestDB(t, sqlDB, spec.ID)
+		cd := cltest.MakeConfigDigest(t)
+
+		count, err := odb.CountPendingTransmissions(ctx, cd)
+		require.NoError(t, err)
+		require.Equal(t, 0, count)
+
+		var keys []ocrtypes.PendingTransmissionKey
+		for i := 0; i < 3; i++ {
+			k := ocrtypes.PendingTransmissionKey{ConfigDigest: cd, Epoch: uint32(i), Round: 1}
+			p := ocrtypes.PendingTransmission{
+				Time:             time.Now(),
+				Median:           ocrtypes.Observation(big.NewInt(int64(i))),
+				SerializedReport: []byte{byte(i)},
+				Rs:               [][32]byte{cltest.Random32Byte()},
+				Ss:               [][32]byte{cltest.Random32Byte()},
+				Vs:               cltest.Random32Byte(),
+			}
+			require.NoError(t, odb.StorePendingTransmission(ctx, k, p))
+			// Storing the same key again is an update, not a new row
+			require.NoError(t, odb.StorePendingTransmission(ctx, k, p))
+			keys = append(keys, k)
+
+			count, err = odb.CountPendingTransmissions(ctx, cd)
+			require.NoError(t, err)
+			require.Equal(t, i+1, count)
+		}
+
+		require.NoError(t, odb.DeletePendingTransmission(ctx, keys[0]))
+
+		count, err = odb.CountPendingTransmissions(ctx, cd)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+
+		// Scoped to the oracle spec
+		count, err = odb2.CountPendingTransmissions(ctx, cd)
+		require.NoError(t, err)
+		require.Equal(t, 0, count)
+
+		require.NoError(t, odb.DeletePendingTransmission(ctx, keys[1]))
+		require.NoError(t, odb.DeletePendingTransmission(ctx, keys[2]))
+	})
+
+	t.Run("returns the number of pending transmissions deleted", func(t *testing.T) {
+		odb := offchainreporting.NewTestDB(t, sqlDB, spec.ID)
+		cd := cltest.MakeConfigDigest(t)
+
+		for i, ts := range []int64{100, 200, 300, 2000} {
+			k := ocrtypes.PendingTransmissionKey{ConfigDigest: cd, Epoch: uint32(i), Round: 1}
+			p := ocrtypes.PendingTransmission{
+				Time:             time.Unix(ts, 0),
+				Median:           ocrtypes.Observation(big.NewInt(int64(i))),
+				SerializedReport: []byte{byte(i)},
+				Rs:               [][32]byte{cltest.Random32Byte()},
+				Ss:               [][32]byte{cltest.Random32Byte()},
+				Vs:               cltest.Random32Byte(),
+			}
+			require.NoError(t, odb.StorePendingTransmission(ctx, k, p))
+		}
+
+		// Rows belonging to the other spec are neither deleted nor counted
+		k := ocrtypes.PendingTransmissionKey{ConfigDigest: cd, Epoch: 0, Round: 1}
+		require.NoError(t, odb2.StorePendingTransmission(ctx, k, ocrtypes.PendingTransmission{
+			Time:             time.Unix(100, 0),
+			Median:           ocrtypes.Observation(big.NewInt(1)),
+			SerializedReport: []byte{1},
+			Rs:               [][32]byte{cltest.Random32Byte()},
+			Ss:               [][32]byte{cltest.Random32Byte()},
+			Vs:               cltest.Random32Byte(),
+		}))
+
+		n, err := odb.DeletePendingTransmissionsOlderThanN(ctx, time.Unix(900, 0))
+		require.NoError(t, err)
+		require.Equal(t, int64(3), n)
+
+		m, err := odb.PendingTransmissionsWithConfigDigest(ctx, cd)
+		require.NoError(t, err)
+		require.Len(t, m, 1)
+
+		n, err = odb.DeletePendingTransmissionsOlderThanN(ctx, time.Unix(900, 0))
+		require.NoError(t, err)
+		require.Equal(t, int64(0), n)
+
+		m, err = odb2.PendingTransmissionsWithConfigDigest(ctx, cd)
+		require.NoError(t, err)
+		require.Len(t, m, 1)
+	})
+
+	t.Run("stores multiple pending transmissions at once", func(t *testing.T) {
+		odb := offchainreporting.NewTestDB(t, sqlDB, spec.ID)
+		cd := cltest.MakeConfigDigest(t)
+
+		items := make(map[ocrtypes.PendingTransmissionKey]ocrtypes.PendingTransmission)
+		for i := 0; i < 5; i++ {
+			k := ocrtypes.PendingTransmissionKey{ConfigDigest: cd, Epoch: uint32(i), Round: uint8(i + 1)}
+			items[k] = ocrtypes.PendingTransmission{
+				Time:             time.Now(),
+				Median:           ocrtypes.Observation(big.NewInt(int64(i))),
+				SerializedReport: []byte{byte(i), 2, 3},
+				Rs:               [][32]byte{cltest.Random32Byte()},
+				Ss:               [][32]byte{cltest.Random32Byte()},
+				Vs:               cltest.Random32Byte(),
+			}
+		}
+		require.NoError(t, odb.StorePendingTransmissions(ctx, items))
+
+		m, err := odb.PendingTransmissionsWithConfigDigest(ctx, cd)
+		require.NoError(t, err)
+		require.Len(t, m, len(items))
+		for k, p := range items {
+			assertPendingTransmissionEqual(t, m[k], p)
+		}
+
+		// Storing again updates existing keys rather than failing on conflict
+		k := ocrtypes.PendingTransmissionKey{ConfigDigest: cd, Epoch: 0, Round: 1}
+		p := items[k]
+		p.Median = ocrtypes.Observation(big.NewInt(100))
+		require.NoError(t, odb.StorePendingTransmissions(ctx, map[ocrtypes.PendingTransmissionKey]ocrtypes.PendingTransmission{k: p}))
+
+		m, err = odb.PendingTransmissionsWithConfigDigest(ctx, cd)
+		require.NoError(t, err)
+		require.Len(t, m, len(items))
+		assertPendingTransmissionEqual(t, m[k], p)
+
+		// Scoped to the oracle spec
+		m, err = odb2.PendingTransmissionsWithConfigDigest(ctx, cd)
+		require.NoError(t, err)
+		require.Len(t, m, 0)
+
+		require.NoError(t, odb.StorePendingTransmissions(ctx, nil))
+	})
+
+	t.Run("stores more pending transmissions than fit in one statement", func(t *testing.T) {
+		odb := offchainreporting.NewTestDB(t, sqlDB, spec.ID)
+		cd := cltest.MakeConfigDigest(t)
+
+		// 7,000 rows at ten params each exceeds Postgres's 65535 bind
+		// parameter limit for a single statement
+		const n = 7000
+		items := make(map[ocrtypes.PendingTransmissionKey]ocrtypes.PendingTransmission, n)
+		for i := 0; i < n; i++ {
+			k := ocrtypes.PendingTransmissionKey{ConfigDigest: cd, Epoch: uint32(i), Round: 1}
+			items[k] = ocrtypes.PendingTransmission{
+				Time:             time.Now(),
+				Median:           ocrtypes.Observation(big.NewInt(int64(i))),
+				SerializedReport: []byte{1, 2, 3},
+				Rs:               [][32]byte{cltest.Random32Byte()},
+				Ss:               [][32]byte{cltest.Random32Byte()},
+				Vs:               cltest.Random32Byte(),
+			}
+		}
+		require.NoError(t, odb.StorePendingTransmissions(ctx, items))
+
+		m, err := odb.PendingTransmissionsWithConfigDigest(ctx, cd)
+		require.NoError(t, err)
+		require.Len(t, m, n)
+	})
+
+	t.Run("pages through pending transmissions", func(t *testing.T) {
+		odb := offchainreporting.NewTestDB(t, sqlDB, spec.ID)
+		cd := cltest.MakeConfigDigest(t)
+
+		items := make(map[ocrtypes.PendingTransmissionKey]ocrtypes.PendingTransmission)
+		for i := 0; i < 7; i++ {
+			// Stored out of order to prove the ordering is applied by the query
+			k := ocrtypes.PendingTransmissionKey{ConfigDigest: cd, Epoch: uint32(3 - i/3), Round: uint8(3 - i%3)}
+			items[k] = ocrtypes.PendingTransmission{
+				Time:             time.Now(),
+				Median:           ocrtypes.Observation(big.NewInt(int64(i))),
+				SerializedReport: []byte{byte(i)},
+				Rs:               [][32]byte{cltest.Random32Byte()},
+				Ss:               [][32]byte{cltest.Random32Byte()},
+				Vs:               cltest.Random32Byte(),
+			}
+		}
+		require.NoError(t, odb.StorePendingTransmissions(ctx, items))
+
+		var all []offchainreporting.PendingTransmissionRecord
+		for offset := 0; ; offset += 3 {
+			page, count, err := odb.PendingTransmissionsPage(ctx, cd, offset, 3)
+			require.NoError(t, err)
+			require.Equal(t, len(items), count)
+			if len(page) == 0 {
+				break
+			}
+			require.LessOrEqual(t, len(page), 3)
+			all = append(all, page...)
+		}
+
+		require.Len(t, all, len(items))
+		for i, r := range all {
+			assertPendingTransmissionEqual(t, items[r.Key], r.Transmission)
+			if i > 0 {
+				prev := all[i-1].Key
+				require.True(t, prev.Epoch < r.Key.Epoch || (prev.Epoch == r.Key.Epoch && prev.Round < r.Key.Round), "expected %v to sort before %v", prev, r.Key)
+			}
+		}
+
+		// Scoped to the oracle spec
+		page, count, err := odb2.PendingTransmissionsPage(ctx, cd, 0, 3)
+		require.NoError(t, err)
+		require.Equal(t, 0, count)
+		require.Len(t, page, 0)
+	})
+}
+
+func Test_DB_PendingTransmissions_RoundTrip(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	sqlDB := db.DB
+	ethKeyStore := cltest.NewKeyStore(t, db).Eth()
+	key, _ := cltest.MustInsertRandomKey(t, ethKeyStore)
+
+	spec := cltest.MustInsertOffchainreportingOracleSpec(t, db, key.Address)
+	odb := offchainreporting.NewTestDB(t, sqlDB, spec.ID)
+	configDigest := cltest.MakeConfigDigest(t)
+
+	var max [32]byte
+	for i := range max {
+		max[i] = 0xff
+	}
+
+	tests := []struct {
+		name string
+		p    ocrtypes.PendingTransmission
+		// expected is the transmission read back, if it differs from p
+		expected *ocrtypes.PendingTransmission
+	}{
+		{
+			name: "empty slices",
+			p: ocrtypes.PendingTransmission{
+				SerializedReport: []byte{},
+				Rs:               [][32]byte{},
+				Ss:               [][32]byte{},
+			},
+		},
+		{
+			name: "nil slices are read back as empty",
+			p:    ocrtypes.PendingTransmission{},
+			expected: &ocrtypes.PendingTransmission{
+				SerializedReport: []byte{},
+				Rs:               [][32]byte{},
+				Ss:               [][32]byte{},
+			},
+		},
+		{
+			name: "single element slices",
+			p: ocrtypes.PendingTransmission{
+				SerializedReport: []byte{1},
+				Rs:               [][32]byte{cltest.Random32Byte()},
+				Ss:               [][32]byte{cltest.Random32Byte()},
+				Vs:               cltest.Random32Byte(),
+			},
+		},
+		{
+			name: "maximal values",
+			p: ocrtypes.PendingTransmission{
+				SerializedReport: max[:],
+				Rs:               [][32]byte{max, max},
+				Ss:               [][32]byte{max, max},
+				Vs:               max,
+			},
+		},
+		{
+			name: "zero values",
+			p: ocrtypes.PendingTransmission{
+				SerializedReport: make([]byte, 32),
+				Rs:               [][32]byte{{}, {}},
+				Ss:               [][32]byte{{}, {}},
+			},
+		},
+	}
+
+	for i, test := range tests {
+		test.p.Time = time.Now().Truncate(time.Microsecond)
+		test.p.Median = ocrtypes.Observation(big.NewInt(int64(i)))
+		expected := test.p
+		if test.expected != nil {
+			expected = *test.expected
+			expected.Time, expected.Median, expected.Vs = test.p.Time, test.p.Median, test.p.Vs
+		}
+
+		t.Run(test.name, func(t *testing.T) {
+			k := ocrtypes.PendingTransmissionKey{ConfigDigest: configDigest, Epoch: 1, Round: uint8(i)}
+			require.NoError(t, odb.StorePendingTransmission(ctx, k, test.p))
+
+			m, err := odb.PendingTransmissionsWithConfigDigest(ctx, configDigest)
+			require.NoError(t, err)
+			got, ok := m[k]
+			require.True(t, ok)
+
+			assert.True(t, expected.Time.Equal(got.Time), "expected time %v, got %v", expected.Time, got.Time)
+			assert.Equal(t, 0, (*big.Int)(expected.Median).Cmp(got.Median), "expected median %v, got %v", expected.Median, got.Median)
+			got.Time, got.Median = expected.Time, expected.Median
+			assert.Equal(t, expected, got)
+		})
+	}
 }
 
 func Test_DB_LatestRoundRequested(t *testing.T) {
@@ -437,6 +1115,26 @@ func Test_DB_LatestRoundRequested(t *testing.T) {
 		assert.Equal(t, rr, lrr)
 	})
 
+	t.Run("loads latest rounds requested of all specs", func(t *testing.T) {
+		odb3 := offchainreporting.NewTestDB(t, sqlDB, 3)
+		rr3 := offchainaggregator.OffchainAggregatorRoundRequested{
+			Requester:    cltest.NewAddress(),
+			ConfigDigest: cltest.MakeConfigDigest(t),
+			Epoch:        7,
+			Round:        3,
+			Raw:          rawLog,
+		}
+		require.NoError(t, odb3.SaveLatestRoundRequested(postgres.WrapDbWithSqlx(sqlDB), rr3))
+
+		rrs, err := offchainreporting.LoadAllLatestRoundsRequested(sqlDB)
+		require.NoError(t, err)
+		require.Len(t, rrs, 2)
+		assert.Equal(t, rr, rrs[1])
+		assert.Equal(t, rr3, rrs[3])
+		// There is no round for db2
+		assert.NotContains(t, rrs, int32(2))
+	})
+
 	t.Run("spec with latest round requested can be deleted", func(t *testing.T) {
 		_, err := sqlDB.Exec(`DELETE FROM offchainreporting_oracle_specs`)
 		assert.NoError(t, err)
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/smartcontractkit/sqlx"
 
 	"github.com/smartcontractkit/chainlink/core/chains"
@@ -123,7 +124,7 @@ func (d Delegate) ServicesForSpec(jobSpec job.Job) (services []job.Service, err
 		return nil, errors.Wrap(err, "could not instantiate NewOffchainAggregatorCaller")
 	}
 
-	ocrdb := NewDB(d.db.DB, concreteSpec.ID, d.lggr)
+	ocrdb := NewDB(d.db.DB, concreteSpec.ID, d.lggr, prometheus.DefaultRegisterer)
 
 	tracker := NewOCRContractTracker(
 		contract,
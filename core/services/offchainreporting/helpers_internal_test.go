@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/smartcontractkit/chainlink/core/logger"
 )
 
@@ -12,5 +14,38 @@ func (c *ConfigOverriderImpl) ExportedUpdateFlagsStatus() error {
 }
 
 func NewTestDB(t *testing.T, sqldb *sql.DB, oracleSpecID int32) *db {
-	return NewDB(sqldb, oracleSpecID, logger.TestLogger(t))
+	return NewDB(sqldb, oracleSpecID, logger.TestLogger(t), nil)
+}
+
+func NewTestDBWithRegisterer(t *testing.T, sqldb *sql.DB, oracleSpecID int32, registerer prometheus.Registerer) *db {
+	return NewDB(sqldb, oracleSpecID, logger.TestLogger(t), registerer)
+}
+
+func (p *Pstorewrapper) ExportedGetPeers() ([]P2PPeer, error) {
+	return p.getPeers()
+}
+
+const PeerstoreChurnThreshold = peerstoreChurnThreshold
+
+func (p *Pstorewrapper) ExportedWriteIfChanged() (bool, error) {
+	return p.writeIfChanged(p.ctx, false)
+}
+
+func (p *Pstorewrapper) ExportedRefreshIfDue() (bool, error) {
+	return p.refreshIfDue(p.ctx)
+}
+
+func (p *Pstorewrapper) ExportedRetryWrite(write func() error) error {
+	return p.retryWrite(write)
+}
+
+// ExportedAddConfigDecoder makes ReadConfig of this db decode configs of the
+// given version with decode
+func (d *db) ExportedAddConfigDecoder(version uint64, decode func(encoded []byte) ([]byte, error)) {
+	decoders := make(map[uint64]configDecoder, len(d.configDecoders)+1)
+	for v, dec := range d.configDecoders {
+		decoders[v] = dec
+	}
+	decoders[version] = decode
+	d.configDecoders = decoders
 }
@@ -33,6 +33,8 @@ type NetworkingConfig interface {
 	P2PListenPort() uint16
 	P2PNetworkingStack() ocrnetworking.NetworkingStack
 	P2PPeerID() p2pkey.PeerID
+	P2PPeerstoreAddrTTL() time.Duration
+	P2PPeerstoreRetention() time.Duration
 	P2PPeerstoreWriteInterval() time.Duration
 	P2PV2AnnounceAddresses() []string
 	P2PV2Bootstrappers() []ocrtypes.BootstrapperLocator
@@ -104,10 +106,15 @@ func (p *SingletonPeerWrapper) Start() error {
 		if p.PeerID == "" {
 			return errors.Wrap(err, "could not get peer ID")
 		}
-		p.pstoreWrapper, err = NewPeerstoreWrapper(p.db, p.config.P2PPeerstoreWriteInterval(), p.PeerID, p.lggr)
+		p.pstoreWrapper, err = NewPeerstoreWrapper(p.db, p.config.P2PPeerstoreWriteInterval(), p.config.P2PPeerstoreAddrTTL(), p.PeerID, p.lggr)
 		if err != nil {
 			return errors.Wrap(err, "could not make new pstorewrapper")
 		}
+		if retention := p.config.P2PPeerstoreRetention(); retention > 0 {
+			if err = p.pstoreWrapper.PruneOlderThan(retention); err != nil {
+				return errors.Wrap(err, "could not prune peerstore")
+			}
+		}
 		discovererDB := NewDiscovererDatabase(p.db.DB, p2ppeer.ID(p.PeerID))
 
 		// If the P2PAnnounceIP is set we must also set the P2PAnnouncePort
@@ -2,15 +2,19 @@ package offchainreporting
 
 import (
 	"context"
-	"fmt"
-	"strings"
+	"encoding/json"
+	"sync"
 	"time"
 
+	"github.com/jpillora/backoff"
+	"github.com/lib/pq"
 	p2ppeer "github.com/libp2p/go-libp2p-core/peer"
 	p2ppeerstore "github.com/libp2p/go-libp2p-core/peerstore"
 	"github.com/libp2p/go-libp2p-peerstore/pstoremem"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/smartcontractkit/sqlx"
 
 	"github.com/smartcontractkit/chainlink/core/logger"
@@ -19,13 +23,37 @@ import (
 	"github.com/smartcontractkit/chainlink/core/utils"
 )
 
+var promPeerstorePeerCount = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "peerstore_peer_count",
+	Help: "The number of peers with addresses known to the peerstore",
+}, []string{"peerID"})
+
+const (
+	// peerstoreInsertBatchSize caps the number of rows per INSERT when writing
+	// the peerstore to the database
+	peerstoreInsertBatchSize = 1000
+	// peerstoreWriteChecks is the number of times per write interval that the
+	// write loop checks the peerstore for changes
+	peerstoreWriteChecks = 4
+	// peerstoreChurnThreshold is the number of changed addresses at which the
+	// peerstore is written without waiting for the rest of the write interval
+	peerstoreChurnThreshold = 100
+	// peerstoreWriteRetries is the number of times a failed write is retried
+	// before the write loop gives up until its next check
+	peerstoreWriteRetries = 3
+	// peerstoreWriteBackoffMin and peerstoreWriteBackoffMax bound the delay
+	// between retries of a failed write
+	peerstoreWriteBackoffMin = 100 * time.Millisecond
+	peerstoreWriteBackoffMax = 2 * time.Second
+)
+
 type (
 	P2PPeer struct {
-		ID        string
-		Addr      string
-		PeerID    string
-		CreatedAt time.Time
-		UpdatedAt time.Time
+		ID        string    `json:"id"`
+		Addr      string    `json:"addr"`
+		PeerID    string    `json:"peerId"`
+		CreatedAt time.Time `json:"createdAt"`
+		UpdatedAt time.Time `json:"updatedAt"`
 	}
 
 	Pstorewrapper struct {
@@ -34,10 +62,31 @@ type (
 		peerID        string
 		db            *sqlx.DB
 		writeInterval time.Duration
+		addrTTL       time.Duration
 		ctx           context.Context
 		ctxCancel     context.CancelFunc
 		chDone        chan struct{}
 		lggr          logger.Logger
+
+		writeMu sync.Mutex
+		// written holds the addresses last written to or read from the
+		// database, at writtenAt
+		written   map[peerstoreAddr]struct{}
+		writtenAt time.Time
+		// retention is the age set by PruneOlderThan, and seenAt the time the
+		// persisted addresses were last marked as seen
+		retention time.Duration
+		seenAt    time.Time
+	}
+
+	peerstoreAddr struct {
+		id, addr string
+	}
+
+	// BootstrapPeer is the address of a peer to seed the peerstore with
+	BootstrapPeer struct {
+		PeerID string
+		Addr   string
 	}
 )
 
@@ -47,8 +96,12 @@ func (P2PPeer) TableName() string {
 
 // NewPeerstoreWrapper creates a new database-backed peerstore wrapper scoped to the given jobID
 // Multiple peerstore wrappers should not be instantiated with the same jobID
-func NewPeerstoreWrapper(db *sqlx.DB, writeInterval time.Duration, peerID p2pkey.PeerID, lggr logger.Logger) (*Pstorewrapper, error) {
+// Addresses loaded from the database are given addrTTL, or a permanent TTL if addrTTL is zero
+func NewPeerstoreWrapper(db *sqlx.DB, writeInterval time.Duration, addrTTL time.Duration, peerID p2pkey.PeerID, lggr logger.Logger) (*Pstorewrapper, error) {
 	ctx, cancel := context.WithCancel(context.Background())
+	if addrTTL == 0 {
+		addrTTL = p2ppeerstore.PermanentAddrTTL
+	}
 
 	return &Pstorewrapper{
 		utils.StartStopOnce{},
@@ -56,10 +109,16 @@ func NewPeerstoreWrapper(db *sqlx.DB, writeInterval time.Duration, peerID p2pkey
 		peerID.Raw(),
 		db,
 		writeInterval,
+		addrTTL,
 		ctx,
 		cancel,
 		make(chan struct{}),
 		lggr.Named("PeerStore"),
+		sync.Mutex{},
+		nil,
+		time.Time{},
+		0,
+		time.Time{},
 	}, nil
 }
 
@@ -76,33 +135,165 @@ func (p *Pstorewrapper) Start() error {
 
 func (p *Pstorewrapper) dbLoop() {
 	defer close(p.chDone)
-	ticker := time.NewTicker(utils.WithJitter(p.writeInterval))
+	ticker := time.NewTicker(utils.WithJitter(p.writeInterval / peerstoreWriteChecks))
 	defer ticker.Stop()
 	for {
 		select {
 		case <-p.ctx.Done():
 			return
 		case <-ticker.C:
-			if err := p.WriteToDB(); err != nil {
+			promPeerstorePeerCount.WithLabelValues(p.peerID).Set(float64(p.PeerCount()))
+			err := p.retryWrite(func() error {
+				if _, err := p.writeIfChanged(p.ctx, false); err != nil {
+					return err
+				}
+				_, err := p.refreshIfDue(p.ctx)
+				return err
+			})
+			if err != nil {
 				p.lggr.Errorw("Error writing peerstore to DB", "err", err)
 			}
 		}
 	}
 }
 
+// retryWrite calls write until it succeeds, retrying up to
+// peerstoreWriteRetries times with backoff so that brief database failures do
+// not leave the peerstore unpersisted until the next check. It gives up early,
+// returning the last error, once the wrapper is closing.
+func (p *Pstorewrapper) retryWrite(write func() error) (err error) {
+	b := backoff.Backoff{Min: peerstoreWriteBackoffMin, Max: peerstoreWriteBackoffMax, Jitter: true}
+	for attempt := 0; ; attempt++ {
+		if err = write(); err == nil || attempt == peerstoreWriteRetries {
+			return err
+		}
+		delay := b.Duration()
+		p.lggr.Debugw("Retrying failed peerstore write", "err", err, "delay", delay, "attempt", attempt+1)
+		select {
+		case <-p.ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
+
+// Close stops the write loop and makes a final attempt to persist any changes
+// to the peerstore, bounded by the default query timeout. A failed final write
+// is logged but does not fail shutdown.
 func (p *Pstorewrapper) Close() error {
 	return p.StopOnce("PeerStore", func() error {
 		p.ctxCancel()
 		<-p.chDone
+		if _, err := p.writeIfChanged(context.Background(), true); err != nil {
+			p.lggr.Errorw("Error flushing peerstore to DB on close", "err", err)
+		}
 		return p.Peerstore.Close()
 	})
 }
 
+// PruneOlderThan deletes this peer's persisted addresses that have not been
+// updated within age. It should be called before Start so that stale
+// addresses are not loaded into the peerstore.
+//
+// The write loop then marks the persisted addresses as seen every age/2, so
+// that the addresses of a long-running node whose peerstore has not changed
+// are not pruned on its next start.
+func (p *Pstorewrapper) PruneOlderThan(age time.Duration) error {
+	p.writeMu.Lock()
+	p.retention = age
+	p.writeMu.Unlock()
+	_, err := postgres.NewQ(p.db, postgres.WithParentCtx(p.ctx)).Exec(
+		`DELETE FROM p2p_peers WHERE peer_id = $1 AND updated_at < $2`, p.peerID, time.Now().Add(-age))
+	return errors.Wrap(err, "could not prune p2p_peers")
+}
+
+// SeedBootstrapPeers adds the given peers to the peerstore with a permanent TTL
+// and persists them, so that a new node can find its OCR network before it
+// has learned any other peers. It may be called before Start; persisted
+// addresses not yet read from the database are left untouched. After a
+// restart, seeded peers are loaded from the database with the wrapper's
+// addrTTL like any other.
+func (p *Pstorewrapper) SeedBootstrapPeers(peers []BootstrapPeer) error {
+	type parsedPeer struct {
+		id   p2ppeer.ID
+		addr ma.Multiaddr
+	}
+	parsed := make([]parsedPeer, 0, len(peers))
+	addrs := make(map[peerstoreAddr]struct{}, len(peers))
+	for _, bp := range peers {
+		id, err := p2ppeer.Decode(bp.PeerID)
+		if err != nil {
+			return errors.Wrapf(err, "invalid bootstrap peer ID '%s'", bp.PeerID)
+		}
+		addr, err := ma.NewMultiaddr(bp.Addr)
+		if err != nil {
+			return errors.Wrapf(err, "invalid multiaddr '%s' for bootstrap peer %s", bp.Addr, bp.PeerID)
+		}
+		parsed = append(parsed, parsedPeer{id, addr})
+		addrs[peerstoreAddr{id.String(), addr.String()}] = struct{}{}
+	}
+
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+	err := postgres.NewQ(p.db, postgres.WithParentCtx(p.ctx)).Transaction(p.lggr, func(tx postgres.Queryer) error {
+		now := time.Now()
+		rows := make([][]interface{}, 0, len(addrs))
+		for a := range addrs {
+			_, err := tx.Exec(`DELETE FROM p2p_peers WHERE peer_id = $1 AND id = $2 AND addr = $3`, p.peerID, a.id, a.addr)
+			if err != nil {
+				return errors.Wrap(err, "delete from p2p_peers failed")
+			}
+			rows = append(rows, []interface{}{a.id, a.addr, p.peerID, now, now})
+		}
+		return postgres.BulkInsert(tx, "p2p_peers", []string{"id", "addr", "peer_id", "created_at", "updated_at"}, rows, peerstoreInsertBatchSize)
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not write bootstrap peers to DB")
+	}
+
+	for _, bp := range parsed {
+		p.Peerstore.AddAddr(bp.id, bp.addr, p2ppeerstore.PermanentAddrTTL)
+	}
+	// Once started, the seeded addresses are already persisted, so they must
+	// not count as changes to write
+	if p.written != nil {
+		for a := range addrs {
+			p.written[a] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// ErrCompactInTransaction is returned by Compact if its database connection
+// is inside a transaction, where Postgres cannot VACUUM.
+var ErrCompactInTransaction = errors.New("p2p_peers cannot be compacted inside a transaction")
+
+// Compact vacuums and analyzes p2p_peers, reclaiming the space left by the
+// rows that writes delete and update. Autovacuum can fall behind this churn on
+// long-running nodes, so maintenance jobs should call Compact periodically.
+// The whole table is compacted, not only this peer's rows. It does not block
+// concurrent writes, but may take a while on a bloated table, so it is bounded
+// only by ctx.
+func (p *Pstorewrapper) Compact(ctx context.Context) error {
+	_, err := p.db.ExecContext(ctx, `VACUUM (ANALYZE) p2p_peers`)
+	if postgres.IsActiveTransactionError(err) {
+		return ErrCompactInTransaction
+	}
+	return errors.Wrap(err, "could not compact p2p_peers")
+}
+
+// PeerCount returns the number of peers for which the peerstore holds at least
+// one address. It is safe to call concurrently with WriteToDB.
+func (p *Pstorewrapper) PeerCount() int {
+	return len(p.Peerstore.PeersWithAddrs())
+}
+
 func (p *Pstorewrapper) readFromDB() error {
 	peers, err := p.getPeers()
 	if err != nil {
 		return err
 	}
+	written := make(map[peerstoreAddr]struct{}, len(peers))
 	for _, peer := range peers {
 		peerID, err := p2ppeer.Decode(peer.ID)
 		if err != nil {
@@ -112,8 +303,13 @@ func (p *Pstorewrapper) readFromDB() error {
 		if err != nil {
 			return errors.Wrapf(err, "unexpectedly failed to decode peer multiaddr '%s'", peer.Addr)
 		}
-		p.Peerstore.AddAddr(peerID, peerAddr, p2ppeerstore.PermanentAddrTTL)
+		p.Peerstore.AddAddr(peerID, peerAddr, p.addrTTL)
+		written[peerstoreAddr{peerID.String(), peerAddr.String()}] = struct{}{}
 	}
+
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+	p.written, p.writtenAt = written, time.Now()
 	return nil
 }
 
@@ -140,38 +336,148 @@ func (p *Pstorewrapper) getPeers() (peers []P2PPeer, err error) {
 	return peers, nil
 }
 
+// Peers returns every address persisted for this peer, with the times each
+// was first written and last seen, most recently seen first.
+func (p *Pstorewrapper) Peers() (peers []P2PPeer, err error) {
+	peers = make([]P2PPeer, 0)
+	err = postgres.NewQ(p.db, postgres.WithParentCtx(p.ctx)).Select(&peers,
+		`SELECT * FROM p2p_peers WHERE peer_id = $1 ORDER BY updated_at DESC, id, addr`, p.peerID)
+	return peers, errors.Wrap(err, "error querying peers")
+}
+
+// PeersJSON marshals the addresses returned by Peers, for serving on admin and
+// debug endpoints. Timestamps are formatted as RFC3339.
+func (p *Pstorewrapper) PeersJSON() ([]byte, error) {
+	peers, err := p.Peers()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(peers)
+}
+
+// WriteToDB writes the peerstore to the database, whether or not it has
+// changed since the last write.
 func (p *Pstorewrapper) WriteToDB() error {
-	err := postgres.NewQ(p.db, postgres.WithParentCtx(p.ctx)).Transaction(p.lggr, func(tx postgres.Queryer) error {
-		_, err := tx.Exec(`DELETE FROM p2p_peers WHERE peer_id = $1`, p.peerID)
-		if err != nil {
-			return errors.Wrap(err, "delete from p2p_peers failed")
-		}
-		peers := make([]P2PPeer, 0)
-		for _, pid := range p.Peerstore.PeersWithAddrs() {
-			addrs := p.Peerstore.Addrs(pid)
-			for _, addr := range addrs {
-				p := P2PPeer{
-					ID:     pid.String(),
-					Addr:   addr.String(),
-					PeerID: p.peerID,
-				}
-				peers = append(peers, p)
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+	return p.write(p.ctx, p.currentAddrs())
+}
+
+// writeIfChanged writes the peerstore to the database if its addresses have
+// changed since the last write, reporting whether it did. Unless force is set
+// or at least peerstoreChurnThreshold addresses have changed, the write is held
+// back until writeInterval has passed since the last one. An unchanged
+// peerstore is never written.
+func (p *Pstorewrapper) writeIfChanged(ctx context.Context, force bool) (bool, error) {
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+
+	addrs := p.currentAddrs()
+	changes := countChangedAddrs(p.written, addrs)
+	if changes == 0 || (!force && changes < peerstoreChurnThreshold && time.Since(p.writtenAt) < p.writeInterval) {
+		return false, nil
+	}
+	return true, p.write(ctx, addrs)
+}
+
+// refreshIfDue marks this peer's persisted addresses as seen if half the
+// retention set by PruneOlderThan has passed since they last were, reporting
+// whether it did. It does nothing if PruneOlderThan was not called.
+func (p *Pstorewrapper) refreshIfDue(ctx context.Context) (bool, error) {
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+
+	if p.retention <= 0 || time.Since(p.seenAt) < p.retention/2 {
+		return false, nil
+	}
+	now := time.Now()
+	_, err := postgres.NewQ(p.db, postgres.WithParentCtx(ctx)).Exec(`UPDATE p2p_peers SET updated_at = $2 WHERE peer_id = $1`, p.peerID, now)
+	if err != nil {
+		return false, errors.Wrap(err, "could not refresh p2p_peers")
+	}
+	p.seenAt = now
+	return true, nil
+}
+
+func (p *Pstorewrapper) currentAddrs() map[peerstoreAddr]struct{} {
+	addrs := make(map[peerstoreAddr]struct{})
+	for _, pid := range p.Peerstore.PeersWithAddrs() {
+		for _, addr := range p.Peerstore.Addrs(pid) {
+			addrs[peerstoreAddr{pid.String(), addr.String()}] = struct{}{}
+		}
+	}
+	return addrs
+}
+
+// countChangedAddrs returns the number of addresses added or removed between
+// from and to
+func countChangedAddrs(from, to map[peerstoreAddr]struct{}) (n int) {
+	for a := range to {
+		if _, ok := from[a]; !ok {
+			n++
+		}
+	}
+	for a := range from {
+		if _, ok := to[a]; !ok {
+			n++
+		}
+	}
+	return n
+}
+
+// write persists addrs as this peer's addresses. Vanished addresses are
+// deleted and new ones inserted, while addresses already persisted keep their
+// created_at and are marked as seen now. Must be called with writeMu held.
+func (p *Pstorewrapper) write(ctx context.Context, addrs map[peerstoreAddr]struct{}) error {
+	err := postgres.NewQ(p.db, postgres.WithParentCtx(ctx)).Transaction(p.lggr, func(tx postgres.Queryer) error {
+		var persisted []P2PPeer
+		if err := tx.Select(&persisted, `SELECT id, addr FROM p2p_peers WHERE peer_id = $1`, p.peerID); err != nil {
+			return errors.Wrap(err, "select from p2p_peers failed")
+		}
+		existing := make(map[peerstoreAddr]struct{}, len(persisted))
+		var vanishedIDs, vanishedAddrs pq.StringArray
+		for _, peer := range persisted {
+			a := peerstoreAddr{peer.ID, peer.Addr}
+			if _, ok := addrs[a]; ok {
+				existing[a] = struct{}{}
+				continue
 			}
+			vanishedIDs = append(vanishedIDs, a.id)
+			vanishedAddrs = append(vanishedAddrs, a.addr)
 		}
-		valueStrings := []string{}
-		valueArgs := []interface{}{}
-		for _, p := range peers {
-			valueStrings = append(valueStrings, "(?, ?, ?, NOW(), NOW())")
-			valueArgs = append(valueArgs, p.ID)
-			valueArgs = append(valueArgs, p.Addr)
-			valueArgs = append(valueArgs, p.PeerID)
+
+		if len(vanishedIDs) > 0 {
+			_, err := tx.Exec(`
+DELETE FROM p2p_peers
+WHERE peer_id = $1 AND (id, addr) IN (
+	SELECT * FROM unnest($2::text[], $3::text[])
+)
+`, p.peerID, vanishedIDs, vanishedAddrs)
+			if err != nil {
+				return errors.Wrap(err, "delete from p2p_peers failed")
+			}
+		}
+
+		now := time.Now()
+		if len(existing) > 0 {
+			_, err := tx.Exec(`UPDATE p2p_peers SET updated_at = $2 WHERE peer_id = $1`, p.peerID, now)
+			if err != nil {
+				return errors.Wrap(err, "update p2p_peers failed")
+			}
 		}
 
-		/* #nosec G201 */
-		stmt := fmt.Sprintf("INSERT INTO p2p_peers (id, addr, peer_id, created_at, updated_at) VALUES %s", strings.Join(valueStrings, ","))
-		stmt = sqlx.Rebind(sqlx.DOLLAR, stmt)
-		_, err = tx.Exec(stmt, valueArgs...)
-		return errors.Wrap(err, "insert into p2p_peers failed")
+		rows := make([][]interface{}, 0, len(addrs)-len(existing))
+		for a := range addrs {
+			if _, ok := existing[a]; !ok {
+				rows = append(rows, []interface{}{a.id, a.addr, p.peerID, now, now})
+			}
+		}
+		return postgres.BulkInsert(tx, "p2p_peers", []string{"id", "addr", "peer_id", "created_at", "updated_at"}, rows, peerstoreInsertBatchSize)
 	})
-	return errors.Wrap(err, "could not write peers to DB")
+	if err != nil {
+		return errors.Wrap(err, "could not write peers to DB")
+	}
+	p.written, p.writtenAt = addrs, time.Now()
+	p.seenAt = p.writtenAt
+	return nil
 }
@@ -1,6 +1,10 @@
 package offchainreporting_test
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -8,11 +12,14 @@ import (
 	p2ppeerstore "github.com/libp2p/go-libp2p-core/peerstore"
 	ma "github.com/multiformats/go-multiaddr"
 
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest/heavyweight"
 	"github.com/smartcontractkit/chainlink/core/internal/testutils/pgtest"
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/services/keystore/keys/p2pkey"
 	"github.com/smartcontractkit/chainlink/core/services/offchainreporting"
 	"github.com/smartcontractkit/chainlink/core/utils"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -50,7 +57,7 @@ func Test_Peerstore_Start(t *testing.T) {
 	`, p2pkey.PeerID(peerID), p2pkey.PeerID(peerID), p2pkey.PeerID(nonExistentP2PPeerID)))
 	require.NoError(t, err)
 
-	wrapper, err := offchainreporting.NewPeerstoreWrapper(db, 1*time.Second, p2pkey.PeerID(peerID), logger.TestLogger(t))
+	wrapper, err := offchainreporting.NewPeerstoreWrapper(db, 1*time.Second, 0, p2pkey.PeerID(peerID), logger.TestLogger(t))
 	require.NoError(t, err)
 
 	err = wrapper.Start()
@@ -66,13 +73,264 @@ func Test_Peerstore_Start(t *testing.T) {
 	require.Len(t, maddrs, 2)
 }
 
+func Test_Peerstore_Start_AddrTTL(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+
+	peerID, err := p2ppeer.Decode("12D3KooWPjceQrSwdWXPyLLeABRXmuqt69Rg3sBYbU1Nft9HyQ6X")
+	require.NoError(t, err)
+
+	err = utils.JustError(db.Exec(`INSERT INTO p2p_peers (id, addr, created_at, updated_at, peer_id) VALUES
+	(
+		'12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph',
+		'/ip4/127.0.0.1/tcp/12000/p2p/12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph',
+		NOW(),
+		NOW(),
+		$1
+	)
+	`, p2pkey.PeerID(peerID)))
+	require.NoError(t, err)
+
+	wrapper, err := offchainreporting.NewPeerstoreWrapper(db, 1*time.Second, 500*time.Millisecond, p2pkey.PeerID(peerID), logger.TestLogger(t))
+	require.NoError(t, err)
+
+	require.NoError(t, wrapper.Start())
+	t.Cleanup(func() { require.NoError(t, wrapper.Close()) })
+
+	peerID, err = p2ppeer.Decode("12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph")
+	require.NoError(t, err)
+
+	require.Len(t, wrapper.Peerstore.Addrs(peerID), 1)
+	require.Eventually(t, func() bool {
+		return len(wrapper.Peerstore.Addrs(peerID)) == 0
+	}, 5*time.Second, 50*time.Millisecond, "address should expire after its TTL")
+}
+
+func Test_Peerstore_PruneOlderThan(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+
+	peerID, err := p2ppeer.Decode("12D3KooWPjceQrSwdWXPyLLeABRXmuqt69Rg3sBYbU1Nft9HyQ6X")
+	require.NoError(t, err)
+
+	otherPeerID, err := p2ppeer.Decode("12D3KooWAdCzaesXyezatDzgGvCngqsBqoUqnV9PnVc46jsVt2i9")
+	require.NoError(t, err)
+
+	err = utils.JustError(db.Exec(`INSERT INTO p2p_peers (id, addr, created_at, updated_at, peer_id) VALUES
+	(
+		'12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph',
+		'/ip4/127.0.0.1/tcp/12000/p2p/12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph',
+		NOW() - interval '30 days',
+		NOW() - interval '30 days',
+		$1
+	),
+	(
+		'12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph',
+		'/ip4/127.0.0.2/tcp/12000/p2p/12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph',
+		NOW(),
+		NOW(),
+		$1
+	),
+	(
+		'12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph',
+		'/ip4/127.0.0.3/tcp/12000/p2p/12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph',
+		NOW() - interval '30 days',
+		NOW() - interval '30 days',
+		$2
+	)
+	`, p2pkey.PeerID(peerID), p2pkey.PeerID(otherPeerID)))
+	require.NoError(t, err)
+
+	wrapper, err := offchainreporting.NewPeerstoreWrapper(db, 1*time.Second, 0, p2pkey.PeerID(peerID), logger.TestLogger(t))
+	require.NoError(t, err)
+
+	require.NoError(t, wrapper.PruneOlderThan(24*time.Hour))
+	require.NoError(t, wrapper.Start())
+	t.Cleanup(func() { require.NoError(t, wrapper.Close()) })
+
+	remotePeerID, err := p2ppeer.Decode("12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph")
+	require.NoError(t, err)
+
+	maddrs := wrapper.Peerstore.Addrs(remotePeerID)
+	require.Len(t, maddrs, 1)
+	require.Contains(t, maddrs[0].String(), "/ip4/127.0.0.2/tcp/12000")
+
+	// rows belonging to other peer IDs are left alone
+	var count int
+	require.NoError(t, db.Get(&count, `SELECT count(*) FROM p2p_peers WHERE peer_id = $1`, p2pkey.PeerID(otherPeerID)))
+	require.Equal(t, 1, count)
+}
+
+func Test_Peerstore_Peers(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+
+	peerID, err := p2ppeer.Decode("12D3KooWPjceQrSwdWXPyLLeABRXmuqt69Rg3sBYbU1Nft9HyQ6X")
+	require.NoError(t, err)
+
+	otherPeerID, err := p2ppeer.Decode("12D3KooWAdCzaesXyezatDzgGvCngqsBqoUqnV9PnVc46jsVt2i9")
+	require.NoError(t, err)
+
+	err = utils.JustError(db.Exec(`INSERT INTO p2p_peers (id, addr, created_at, updated_at, peer_id) VALUES
+	(
+		'12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph',
+		'/ip4/127.0.0.1/tcp/12000/p2p/12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph',
+		NOW() - interval '30 days',
+		NOW() - interval '2 days',
+		$1
+	),
+	(
+		'12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph',
+		'/ip4/127.0.0.2/tcp/12000/p2p/12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph',
+		NOW() - interval '1 day',
+		NOW(),
+		$1
+	),
+	(
+		'12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph',
+		'/ip4/127.0.0.3/tcp/12000/p2p/12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph',
+		NOW(),
+		NOW(),
+		$2
+	)
+	`, p2pkey.PeerID(peerID), p2pkey.PeerID(otherPeerID)))
+	require.NoError(t, err)
+
+	wrapper, err := offchainreporting.NewPeerstoreWrapper(db, 1*time.Second, 0, p2pkey.PeerID(peerID), logger.TestLogger(t))
+	require.NoError(t, err)
+
+	peers, err := wrapper.Peers()
+	require.NoError(t, err)
+	require.Len(t, peers, 2)
+
+	// most recently seen first
+	require.Equal(t, "/ip4/127.0.0.2/tcp/12000/p2p/12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph", peers[0].Addr)
+	require.Equal(t, "/ip4/127.0.0.1/tcp/12000/p2p/12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph", peers[1].Addr)
+	require.True(t, peers[0].UpdatedAt.After(peers[1].UpdatedAt))
+	for _, peer := range peers {
+		require.Equal(t, "12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph", peer.ID)
+		require.Equal(t, p2pkey.PeerID(peerID).Raw(), peer.PeerID)
+		require.False(t, peer.CreatedAt.IsZero())
+		require.False(t, peer.UpdatedAt.IsZero())
+		require.True(t, peer.CreatedAt.Before(peer.UpdatedAt))
+	}
+}
+
+func Test_Peerstore_PeersJSON(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+
+	peerID, err := p2ppeer.Decode("12D3KooWPjceQrSwdWXPyLLeABRXmuqt69Rg3sBYbU1Nft9HyQ6X")
+	require.NoError(t, err)
+
+	err = utils.JustError(db.Exec(`INSERT INTO p2p_peers (id, addr, created_at, updated_at, peer_id) VALUES
+	(
+		'12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph',
+		'/ip4/127.0.0.1/tcp/12000/p2p/12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph',
+		'2021-01-01T00:00:00Z',
+		'2021-01-02T00:00:00Z',
+		$1
+	)
+	`, p2pkey.PeerID(peerID)))
+	require.NoError(t, err)
+
+	wrapper, err := offchainreporting.NewPeerstoreWrapper(db, 1*time.Second, 0, p2pkey.PeerID(peerID), logger.TestLogger(t))
+	require.NoError(t, err)
+
+	b, err := wrapper.PeersJSON()
+	require.NoError(t, err)
+
+	var peers []map[string]interface{}
+	require.NoError(t, json.Unmarshal(b, &peers))
+	require.Len(t, peers, 1)
+	assert.Equal(t, "12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph", peers[0]["id"])
+	assert.Equal(t, "/ip4/127.0.0.1/tcp/12000/p2p/12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph", peers[0]["addr"])
+	assert.Equal(t, p2pkey.PeerID(peerID).Raw(), peers[0]["peerId"])
+
+	createdAt, err := time.Parse(time.RFC3339, peers[0]["createdAt"].(string))
+	require.NoError(t, err)
+	assert.True(t, createdAt.Equal(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)))
+	updatedAt, err := time.Parse(time.RFC3339, peers[0]["updatedAt"].(string))
+	require.NoError(t, err)
+	assert.True(t, updatedAt.Equal(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)))
+}
+
+func Test_Peerstore_SeedBootstrapPeers(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+
+	peerID, err := p2ppeer.Decode("12D3KooWPjceQrSwdWXPyLLeABRXmuqt69Rg3sBYbU1Nft9HyQ6X")
+	require.NoError(t, err)
+
+	// An address persisted before seeding is kept
+	err = utils.JustError(db.Exec(`INSERT INTO p2p_peers (id, addr, created_at, updated_at, peer_id) VALUES
+	(
+		'12D3KooWAdCzaesXyezatDzgGvCngqsBqoUqnV9PnVc46jsVt2i9',
+		'/ip4/127.0.0.9/tcp/12000/p2p/12D3KooWAdCzaesXyezatDzgGvCngqsBqoUqnV9PnVc46jsVt2i9',
+		NOW(),
+		NOW(),
+		$1
+	)
+	`, p2pkey.PeerID(peerID)))
+	require.NoError(t, err)
+
+	wrapper, err := offchainreporting.NewPeerstoreWrapper(db, 1*time.Second, time.Hour, p2pkey.PeerID(peerID), logger.TestLogger(t))
+	require.NoError(t, err)
+
+	bootstrapPeerID := "12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph"
+	seeded := []offchainreporting.BootstrapPeer{
+		{PeerID: bootstrapPeerID, Addr: "/ip4/127.0.0.1/tcp/12000/p2p/12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph"},
+		{PeerID: bootstrapPeerID, Addr: "/ip4/127.0.0.2/tcp/12000/p2p/12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph"},
+	}
+
+	t.Run("rejects malformed peers", func(t *testing.T) {
+		err := wrapper.SeedBootstrapPeers([]offchainreporting.BootstrapPeer{{PeerID: bootstrapPeerID, Addr: "not-a-multiaddr"}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid multiaddr 'not-a-multiaddr' for bootstrap peer "+bootstrapPeerID)
+
+		err = wrapper.SeedBootstrapPeers([]offchainreporting.BootstrapPeer{{PeerID: "not-a-peer-id", Addr: seeded[0].Addr}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid bootstrap peer ID 'not-a-peer-id'")
+
+		require.Equal(t, 0, wrapper.PeerCount())
+	})
+
+	t.Run("seeds the peerstore and the database", func(t *testing.T) {
+		require.NoError(t, wrapper.SeedBootstrapPeers(seeded))
+		// seeding twice does not duplicate rows
+		require.NoError(t, wrapper.SeedBootstrapPeers(seeded))
+
+		remotePeerID, err := p2ppeer.Decode(bootstrapPeerID)
+		require.NoError(t, err)
+		require.Len(t, wrapper.Peerstore.Addrs(remotePeerID), 2)
+
+		peers, err := wrapper.Peers()
+		require.NoError(t, err)
+		var addrs []string
+		for _, peer := range peers {
+			addrs = append(addrs, peer.Addr)
+		}
+		require.ElementsMatch(t, []string{
+			seeded[0].Addr,
+			seeded[1].Addr,
+			"/ip4/127.0.0.9/tcp/12000/p2p/12D3KooWAdCzaesXyezatDzgGvCngqsBqoUqnV9PnVc46jsVt2i9",
+		}, addrs)
+	})
+
+	t.Run("survives a restart", func(t *testing.T) {
+		restarted, err := offchainreporting.NewPeerstoreWrapper(db, 1*time.Second, time.Hour, p2pkey.PeerID(peerID), logger.TestLogger(t))
+		require.NoError(t, err)
+		require.NoError(t, restarted.Start())
+		t.Cleanup(func() { require.NoError(t, restarted.Close()) })
+
+		remotePeerID, err := p2ppeer.Decode(bootstrapPeerID)
+		require.NoError(t, err)
+		require.Len(t, restarted.Peerstore.Addrs(remotePeerID), 2)
+	})
+}
+
 func Test_Peerstore_WriteToDB(t *testing.T) {
 	db := pgtest.NewSqlxDB(t)
 
 	peerID, err := p2ppeer.Decode("12D3KooWPjceQrSwdWXPyLLeABRXmuqt69Rg3sBYbU1Nft9HyQ6X")
 	require.NoError(t, err)
 
-	wrapper, err := offchainreporting.NewPeerstoreWrapper(db, 1*time.Second, p2pkey.PeerID(peerID), logger.TestLogger(t))
+	wrapper, err := offchainreporting.NewPeerstoreWrapper(db, 1*time.Second, 0, p2pkey.PeerID(peerID), logger.TestLogger(t))
 	require.NoError(t, err)
 
 	maddr, err := ma.NewMultiaddr("/ip4/127.0.0.2/tcp/12000/p2p/12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph")
@@ -95,3 +353,338 @@ func Test_Peerstore_WriteToDB(t *testing.T) {
 	require.Equal(t, "/ip4/127.0.0.2/tcp/12000/p2p/12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph", peer.Addr)
 	require.Equal(t, p2pkey.PeerID(peerID).Raw(), peer.PeerID)
 }
+
+func Test_Peerstore_WriteToDB_KeepsCreatedAt(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+
+	peerID, err := p2ppeer.Decode("12D3KooWPjceQrSwdWXPyLLeABRXmuqt69Rg3sBYbU1Nft9HyQ6X")
+	require.NoError(t, err)
+
+	wrapper, err := offchainreporting.NewPeerstoreWrapper(db, 1*time.Second, 0, p2pkey.PeerID(peerID), logger.TestLogger(t))
+	require.NoError(t, err)
+
+	kept, vanished, added := cltest.MustRandomP2PPeerID(t), cltest.MustRandomP2PPeerID(t), cltest.MustRandomP2PPeerID(t)
+	wrapper.Peerstore.AddAddr(kept, ma.StringCast("/ip4/127.0.0.1/tcp/12000"), p2ppeerstore.PermanentAddrTTL)
+	wrapper.Peerstore.AddAddr(vanished, ma.StringCast("/ip4/127.0.0.2/tcp/12000"), p2ppeerstore.PermanentAddrTTL)
+	require.NoError(t, wrapper.WriteToDB())
+
+	// pretend the addresses were first and last seen long ago
+	err = utils.JustError(db.Exec(`UPDATE p2p_peers SET created_at = NOW() - interval '30 days', updated_at = NOW() - interval '30 days' WHERE peer_id = $1`, p2pkey.PeerID(peerID)))
+	require.NoError(t, err)
+
+	wrapper.Peerstore.ClearAddrs(vanished)
+	wrapper.Peerstore.AddAddr(added, ma.StringCast("/ip4/127.0.0.3/tcp/12000"), p2ppeerstore.PermanentAddrTTL)
+	require.NoError(t, wrapper.WriteToDB())
+
+	peers, err := wrapper.Peers()
+	require.NoError(t, err)
+	require.Len(t, peers, 2)
+
+	byID := make(map[string]offchainreporting.P2PPeer)
+	for _, peer := range peers {
+		byID[peer.ID] = peer
+	}
+	require.NotContains(t, byID, vanished.String())
+
+	require.Contains(t, byID, kept.String())
+	assert.True(t, byID[kept.String()].CreatedAt.Before(time.Now().Add(-24*time.Hour)), "existing address should keep its created_at")
+	assert.True(t, byID[kept.String()].UpdatedAt.After(time.Now().Add(-time.Hour)), "existing address should be marked as seen")
+
+	require.Contains(t, byID, added.String())
+	assert.True(t, byID[added.String()].CreatedAt.After(time.Now().Add(-time.Hour)))
+}
+
+func Test_Peerstore_WriteToDB_ManyPeers(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+
+	peerID, err := p2ppeer.Decode("12D3KooWPjceQrSwdWXPyLLeABRXmuqt69Rg3sBYbU1Nft9HyQ6X")
+	require.NoError(t, err)
+
+	wrapper, err := offchainreporting.NewPeerstoreWrapper(db, 1*time.Second, 0, p2pkey.PeerID(peerID), logger.TestLogger(t))
+	require.NoError(t, err)
+
+	// 25,000 rows at three params each exceeds Postgres's 65535 bind parameter
+	// limit for a single statement
+	const nPeers, nAddrs = 50, 500
+	for i := 0; i < nPeers; i++ {
+		pid := cltest.MustRandomP2PPeerID(t)
+		for j := 0; j < nAddrs; j++ {
+			maddr, err := ma.NewMultiaddr(fmt.Sprintf("/ip4/10.0.%d.%d/tcp/%d", i, j%256, 12000+j))
+			require.NoError(t, err)
+			wrapper.Peerstore.AddAddr(pid, maddr, p2ppeerstore.PermanentAddrTTL)
+		}
+	}
+
+	require.NoError(t, wrapper.WriteToDB())
+
+	peers, err := wrapper.ExportedGetPeers()
+	require.NoError(t, err)
+	require.Len(t, peers, nPeers*nAddrs)
+}
+
+func Test_Peerstore_Close_FlushesToDB(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+
+	peerID, err := p2ppeer.Decode("12D3KooWPjceQrSwdWXPyLLeABRXmuqt69Rg3sBYbU1Nft9HyQ6X")
+	require.NoError(t, err)
+
+	// long enough that the write loop never ticks during the test
+	wrapper, err := offchainreporting.NewPeerstoreWrapper(db, 1*time.Hour, 0, p2pkey.PeerID(peerID), logger.TestLogger(t))
+	require.NoError(t, err)
+	require.NoError(t, wrapper.Start())
+
+	maddr, err := ma.NewMultiaddr("/ip4/127.0.0.2/tcp/12000/p2p/12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph")
+	require.NoError(t, err)
+	newPeerID, err := p2ppeer.Decode("12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph")
+	require.NoError(t, err)
+	wrapper.Peerstore.AddAddr(newPeerID, maddr, p2ppeerstore.PermanentAddrTTL)
+
+	require.NoError(t, wrapper.Close())
+
+	peers := make([]offchainreporting.P2PPeer, 0)
+	require.NoError(t, db.Select(&peers, `SELECT * FROM p2p_peers WHERE peer_id = $1`, p2pkey.PeerID(peerID).Raw()))
+	require.Len(t, peers, 1)
+	require.Equal(t, "12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph", peers[0].ID)
+}
+
+func Test_Peerstore_PeerCount(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+
+	peerID, err := p2ppeer.Decode("12D3KooWPjceQrSwdWXPyLLeABRXmuqt69Rg3sBYbU1Nft9HyQ6X")
+	require.NoError(t, err)
+
+	wrapper, err := offchainreporting.NewPeerstoreWrapper(db, 1*time.Second, 0, p2pkey.PeerID(peerID), logger.TestLogger(t))
+	require.NoError(t, err)
+
+	require.Equal(t, 0, wrapper.PeerCount())
+
+	maddr, err := ma.NewMultiaddr("/ip4/127.0.0.2/tcp/12000")
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		pid := cltest.MustRandomP2PPeerID(t)
+		// multiple addresses for the same peer are counted once
+		wrapper.Peerstore.AddAddr(pid, maddr, p2ppeerstore.PermanentAddrTTL)
+		wrapper.Peerstore.AddAddr(pid, ma.StringCast("/ip4/127.0.0.3/tcp/12000"), p2ppeerstore.PermanentAddrTTL)
+	}
+
+	require.Equal(t, 2, wrapper.PeerCount())
+}
+
+func Test_Peerstore_WriteIfChanged(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+
+	peerID, err := p2ppeer.Decode("12D3KooWPjceQrSwdWXPyLLeABRXmuqt69Rg3sBYbU1Nft9HyQ6X")
+	require.NoError(t, err)
+
+	// long enough that small changes are always held back
+	wrapper, err := offchainreporting.NewPeerstoreWrapper(db, 1*time.Hour, 0, p2pkey.PeerID(peerID), logger.TestLogger(t))
+	require.NoError(t, err)
+
+	lastUpdated := func() (updatedAt time.Time) {
+		require.NoError(t, db.Get(&updatedAt, `SELECT max(updated_at) FROM p2p_peers WHERE peer_id = $1`, p2pkey.PeerID(peerID)))
+		return
+	}
+
+	pid := cltest.MustRandomP2PPeerID(t)
+	wrapper.Peerstore.AddAddr(pid, ma.StringCast("/ip4/127.0.0.2/tcp/12000"), p2ppeerstore.PermanentAddrTTL)
+
+	t.Run("a change triggers a write", func(t *testing.T) {
+		written, err := wrapper.ExportedWriteIfChanged()
+		require.NoError(t, err)
+		require.True(t, written)
+
+		peers, err := wrapper.ExportedGetPeers()
+		require.NoError(t, err)
+		require.Len(t, peers, 1)
+	})
+
+	t.Run("no write if the peer set is unchanged", func(t *testing.T) {
+		updatedAt := lastUpdated()
+
+		written, err := wrapper.ExportedWriteIfChanged()
+		require.NoError(t, err)
+		require.False(t, written)
+		require.Equal(t, updatedAt, lastUpdated())
+	})
+
+	t.Run("small changes wait for the write interval", func(t *testing.T) {
+		wrapper.Peerstore.AddAddr(pid, ma.StringCast("/ip4/127.0.0.3/tcp/12000"), p2ppeerstore.PermanentAddrTTL)
+
+		written, err := wrapper.ExportedWriteIfChanged()
+		require.NoError(t, err)
+		require.False(t, written)
+
+		peers, err := wrapper.ExportedGetPeers()
+		require.NoError(t, err)
+		require.Len(t, peers, 1)
+	})
+
+	t.Run("many changes are written without waiting", func(t *testing.T) {
+		for i := 0; i < offchainreporting.PeerstoreChurnThreshold; i++ {
+			wrapper.Peerstore.AddAddr(cltest.MustRandomP2PPeerID(t), ma.StringCast(fmt.Sprintf("/ip4/10.0.0.%d/tcp/12000", i)), p2ppeerstore.PermanentAddrTTL)
+		}
+
+		written, err := wrapper.ExportedWriteIfChanged()
+		require.NoError(t, err)
+		require.True(t, written)
+
+		peers, err := wrapper.ExportedGetPeers()
+		require.NoError(t, err)
+		require.Len(t, peers, offchainreporting.PeerstoreChurnThreshold+2)
+	})
+
+	t.Run("addresses loaded on start count as written", func(t *testing.T) {
+		wrapper, err := offchainreporting.NewPeerstoreWrapper(db, 1*time.Hour, 0, p2pkey.PeerID(peerID), logger.TestLogger(t))
+		require.NoError(t, err)
+		require.NoError(t, wrapper.Start())
+		t.Cleanup(func() { require.NoError(t, wrapper.Close()) })
+
+		written, err := wrapper.ExportedWriteIfChanged()
+		require.NoError(t, err)
+		require.False(t, written)
+	})
+}
+
+func Test_Peerstore_WriteIfChanged_SkipsUnchanged(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+
+	peerID, err := p2ppeer.Decode("12D3KooWPjceQrSwdWXPyLLeABRXmuqt69Rg3sBYbU1Nft9HyQ6X")
+	require.NoError(t, err)
+
+	const writeInterval = 100 * time.Millisecond
+	wrapper, err := offchainreporting.NewPeerstoreWrapper(db, writeInterval, 0, p2pkey.PeerID(peerID), logger.TestLogger(t))
+	require.NoError(t, err)
+
+	pid := cltest.MustRandomP2PPeerID(t)
+	wrapper.Peerstore.AddAddr(pid, ma.StringCast("/ip4/127.0.0.2/tcp/12000"), p2ppeerstore.PermanentAddrTTL)
+	require.NoError(t, wrapper.WriteToDB())
+
+	time.Sleep(writeInterval)
+
+	written, err := wrapper.ExportedWriteIfChanged()
+	require.NoError(t, err)
+	require.False(t, written, "unchanged peerstore should not be written once the write interval has passed")
+}
+
+func Test_Peerstore_RefreshIfDue(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+
+	peerID, err := p2ppeer.Decode("12D3KooWPjceQrSwdWXPyLLeABRXmuqt69Rg3sBYbU1Nft9HyQ6X")
+	require.NoError(t, err)
+
+	wrapper, err := offchainreporting.NewPeerstoreWrapper(db, 1*time.Hour, 0, p2pkey.PeerID(peerID), logger.TestLogger(t))
+	require.NoError(t, err)
+
+	pid := cltest.MustRandomP2PPeerID(t)
+	wrapper.Peerstore.AddAddr(pid, ma.StringCast("/ip4/127.0.0.2/tcp/12000"), p2ppeerstore.PermanentAddrTTL)
+	require.NoError(t, wrapper.WriteToDB())
+
+	refreshed, err := wrapper.ExportedRefreshIfDue()
+	require.NoError(t, err)
+	require.False(t, refreshed, "should not refresh without a retention")
+
+	const retention = 200 * time.Millisecond
+	require.NoError(t, wrapper.PruneOlderThan(retention))
+
+	refreshed, err = wrapper.ExportedRefreshIfDue()
+	require.NoError(t, err)
+	require.False(t, refreshed, "should not refresh right after a write")
+
+	// pretend the address was last seen long ago
+	err = utils.JustError(db.Exec(`UPDATE p2p_peers SET updated_at = NOW() - interval '30 days' WHERE peer_id = $1`, p2pkey.PeerID(peerID)))
+	require.NoError(t, err)
+
+	time.Sleep(retention / 2)
+
+	refreshed, err = wrapper.ExportedRefreshIfDue()
+	require.NoError(t, err)
+	require.True(t, refreshed, "should refresh once half the retention has passed")
+
+	require.NoError(t, wrapper.PruneOlderThan(24*time.Hour))
+
+	peers, err := wrapper.ExportedGetPeers()
+	require.NoError(t, err)
+	require.Len(t, peers, 1)
+}
+
+func Test_Peerstore_RetryWrite(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+
+	peerID, err := p2ppeer.Decode("12D3KooWPjceQrSwdWXPyLLeABRXmuqt69Rg3sBYbU1Nft9HyQ6X")
+	require.NoError(t, err)
+
+	wrapper, err := offchainreporting.NewPeerstoreWrapper(db, 1*time.Hour, 0, p2pkey.PeerID(peerID), logger.TestLogger(t))
+	require.NoError(t, err)
+
+	pid := cltest.MustRandomP2PPeerID(t)
+	wrapper.Peerstore.AddAddr(pid, ma.StringCast("/ip4/127.0.0.2/tcp/12000"), p2ppeerstore.PermanentAddrTTL)
+
+	t.Run("retries a failed write", func(t *testing.T) {
+		var attempts int
+		err := wrapper.ExportedRetryWrite(func() error {
+			attempts++
+			if attempts == 1 {
+				return errors.New("connection reset")
+			}
+			return wrapper.WriteToDB()
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, attempts)
+
+		peers, err := wrapper.ExportedGetPeers()
+		require.NoError(t, err)
+		require.Len(t, peers, 1)
+	})
+
+	t.Run("gives up once closing", func(t *testing.T) {
+		require.NoError(t, wrapper.Start())
+		require.NoError(t, wrapper.Close())
+
+		var attempts int
+		err := wrapper.ExportedRetryWrite(func() error {
+			attempts++
+			return errors.New("connection reset")
+		})
+		require.EqualError(t, err, "connection reset")
+		require.Equal(t, 1, attempts)
+	})
+}
+
+func Test_Peerstore_Compact(t *testing.T) {
+	peerID, err := p2ppeer.Decode("12D3KooWPjceQrSwdWXPyLLeABRXmuqt69Rg3sBYbU1Nft9HyQ6X")
+	require.NoError(t, err)
+	newPeerID, err := p2ppeer.Decode("12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph")
+	require.NoError(t, err)
+
+	t.Run("compacts a populated table", func(t *testing.T) {
+		// Needs a real database, since VACUUM cannot run in the transaction
+		// wrapping every pgtest.NewSqlxDB connection
+		_, db := heavyweight.FullTestDB(t, "peerstore_compact", true, false)
+		wrapper, err := offchainreporting.NewPeerstoreWrapper(db, 1*time.Second, 0, p2pkey.PeerID(peerID), logger.TestLogger(t))
+		require.NoError(t, err)
+
+		// Each write deletes the previous rows, leaving dead tuples to reclaim
+		for i := 0; i < 3; i++ {
+			for j := 0; j < 10; j++ {
+				maddr, err := ma.NewMultiaddr(fmt.Sprintf("/ip4/127.0.%d.%d/tcp/12000/p2p/12D3KooWL1yndUw9T2oWXjhfjdwSscWA78YCpUdduA3Cnn4dCtph", i, j))
+				require.NoError(t, err)
+				wrapper.Peerstore.AddAddr(newPeerID, maddr, p2ppeerstore.PermanentAddrTTL)
+			}
+			require.NoError(t, wrapper.WriteToDB())
+		}
+
+		require.NoError(t, wrapper.Compact(context.Background()))
+
+		var count int
+		require.NoError(t, db.Get(&count, `SELECT count(*) FROM p2p_peers`))
+		require.Equal(t, 30, count)
+	})
+
+	t.Run("refuses to run inside a transaction", func(t *testing.T) {
+		db := pgtest.NewSqlxDB(t)
+		wrapper, err := offchainreporting.NewPeerstoreWrapper(db, 1*time.Second, 0, p2pkey.PeerID(peerID), logger.TestLogger(t))
+		require.NoError(t, err)
+
+		require.Equal(t, offchainreporting.ErrCompactInTransaction, wrapper.Compact(context.Background()))
+	})
+}
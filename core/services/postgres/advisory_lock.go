@@ -3,6 +3,11 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
 )
 
 // Locker is an interface for postgresql advisory locks.
@@ -46,3 +51,26 @@ func NewLock(ctx context.Context, id int64, db *sql.DB) (Lock, error) {
 	}
 	return Lock{id: id, conn: conn}, nil
 }
+
+// WithAdvisoryLock runs fc in a transaction on q that holds the transaction
+// level advisory lock identified by key, so that fc never runs concurrently
+// with another caller using the same key, whether in this process or another
+// node sharing the database. The lock is released when the transaction ends.
+//
+// The transaction is bounded by ctx. If the lock cannot be acquired within
+// DefaultQueryTimeout an error is returned and fc is not run; fc itself is
+// subject only to ctx and the usual DefaultLockTimeout for any locks it takes.
+func WithAdvisoryLock(ctx context.Context, q Queryer, lggr logger.Logger, key int64, fc func(Queryer) error) error {
+	return SqlxTransaction(ctx, q, lggr, func(tx Queryer) error {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(`SET LOCAL lock_timeout = %d`, DefaultQueryTimeout.Milliseconds())); err != nil {
+			return errors.Wrap(err, "error setting transaction local lock_timeout")
+		}
+		if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", key); err != nil {
+			return errors.Wrapf(err, "failed to acquire advisory lock %d", key)
+		}
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(`SET LOCAL lock_timeout = %d`, DefaultLockTimeout.Milliseconds())); err != nil {
+			return errors.Wrap(err, "error setting transaction local lock_timeout")
+		}
+		return fc(tx)
+	})
+}
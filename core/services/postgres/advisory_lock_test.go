@@ -0,0 +1,103 @@
+package postgres_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest/heavyweight"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/postgres"
+)
+
+func Test_WithAdvisoryLock(t *testing.T) {
+	_, db := heavyweight.FullTestDB(t, "with_advisory_lock", false, false)
+	const key = 1317
+	ctx := context.Background()
+	lggr := logger.TestLogger(t)
+
+	firstLocked := make(chan struct{})
+	releaseFirst := make(chan struct{})
+	var mu sync.Mutex
+	var events []string
+	record := func(event string) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, postgres.WithAdvisoryLock(ctx, db, lggr, key, func(postgres.Queryer) error {
+			record("first start")
+			close(firstLocked)
+			<-releaseFirst
+			record("first end")
+			return nil
+		}))
+	}()
+
+	<-firstLocked
+	secondDone := make(chan struct{})
+	go func() {
+		defer wg.Done()
+		defer close(secondDone)
+		assert.NoError(t, postgres.WithAdvisoryLock(ctx, db, lggr, key, func(postgres.Queryer) error {
+			record("second start")
+			record("second end")
+			return nil
+		}))
+	}()
+
+	// The second caller must block for as long as the first holds the lock
+	select {
+	case <-secondDone:
+		t.Fatal("second caller ran while the lock was held")
+	case <-time.After(500 * time.Millisecond):
+	}
+	close(releaseFirst)
+	wg.Wait()
+
+	require.Equal(t, []string{"first start", "first end", "second start", "second end"}, events)
+
+	t.Run("a different key does not contend", func(t *testing.T) {
+		err := postgres.WithAdvisoryLock(ctx, db, lggr, key, func(postgres.Queryer) error {
+			return postgres.WithAdvisoryLock(ctx, db, lggr, key+1, func(postgres.Queryer) error { return nil })
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("waiting for the lock is bounded by ctx", func(t *testing.T) {
+		locked := make(chan struct{})
+		release := make(chan struct{})
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			assert.NoError(t, postgres.WithAdvisoryLock(ctx, db, lggr, key, func(postgres.Queryer) error {
+				close(locked)
+				<-release
+				return nil
+			}))
+		}()
+		<-locked
+
+		waitCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+		defer cancel()
+		ran := false
+		err := postgres.WithAdvisoryLock(waitCtx, db, lggr, key, func(postgres.Queryer) error {
+			ran = true
+			return nil
+		})
+		require.Error(t, err)
+		assert.False(t, ran)
+
+		close(release)
+		<-done
+	})
+}
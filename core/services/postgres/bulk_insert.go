@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/sqlx"
+)
+
+// MaxBindParams is the maximum number of bind parameters Postgres accepts in
+// a single statement
+const MaxBindParams = 65535
+
+// BulkInsert inserts rows into the given columns of table using multi-row
+// INSERT statements of at most chunkSize rows each. chunkSize is lowered if
+// necessary to keep each statement within MaxBindParams, and a chunkSize of
+// zero or less means "as many rows as fit".
+//
+// Chunks are inserted one after another, so pass a transaction as q if the
+// insert must be atomic. Inserting no rows is a no-op.
+func BulkInsert(q Queryer, table string, columns []string, rows [][]interface{}, chunkSize int) error {
+	return bulkInsert(q, table, columns, rows, chunkSize, "")
+}
+
+// BulkUpsert is like BulkInsert, but appends onConflict to every statement as
+// its ON CONFLICT clause, e.g. "ON CONFLICT (id) DO UPDATE SET ...". Rows of
+// the same chunk must not conflict with each other.
+func BulkUpsert(q Queryer, table string, columns []string, rows [][]interface{}, chunkSize int, onConflict string) error {
+	if onConflict == "" {
+		return errors.Errorf("cannot bulk upsert into %s without an ON CONFLICT clause", table)
+	}
+	return bulkInsert(q, table, columns, rows, chunkSize, onConflict)
+}
+
+func bulkInsert(q Queryer, table string, columns []string, rows [][]interface{}, chunkSize int, onConflict string) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	if len(columns) == 0 {
+		return errors.Errorf("cannot bulk insert into %s without columns", table)
+	}
+	if max := MaxBindParams / len(columns); chunkSize <= 0 || chunkSize > max {
+		chunkSize = max
+	}
+	for i, row := range rows {
+		if len(row) != len(columns) {
+			return errors.Errorf("cannot bulk insert into %s: row %d has %d values but %d columns were given", table, i, len(row), len(columns))
+		}
+	}
+
+	placeholder := "(" + strings.TrimSuffix(strings.Repeat("?,", len(columns)), ",") + ")"
+	/* #nosec G201 */
+	prefix := fmt.Sprintf("INSERT INTO %s (%s) VALUES ", table, strings.Join(columns, ", "))
+	var suffix string
+	if onConflict != "" {
+		suffix = " " + onConflict
+	}
+	for start := 0; start < len(rows); start += chunkSize {
+		end := start + chunkSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+		chunk := rows[start:end]
+
+		valueStrings := make([]string, len(chunk))
+		valueArgs := make([]interface{}, 0, len(chunk)*len(columns))
+		for i, row := range chunk {
+			valueStrings[i] = placeholder
+			valueArgs = append(valueArgs, row...)
+		}
+		stmt := sqlx.Rebind(sqlx.DOLLAR, prefix+strings.Join(valueStrings, ",")+suffix)
+		if _, err := q.Exec(stmt, valueArgs...); err != nil {
+			return errors.Wrapf(err, "bulk insert into %s failed", table)
+		}
+	}
+	return nil
+}
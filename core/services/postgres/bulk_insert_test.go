@@ -0,0 +1,75 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/core/services/postgres"
+	"github.com/smartcontractkit/chainlink/core/services/postgres/mocks"
+)
+
+func Test_BulkInsert(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	_, err := db.Exec(`CREATE TABLE bulk_insert_test (id int PRIMARY KEY, name text NOT NULL)`)
+	require.NoError(t, err)
+
+	columns := []string{"id", "name"}
+	makeRows := func(from, to int) (rows [][]interface{}) {
+		for i := from; i < to; i++ {
+			rows = append(rows, []interface{}{i, "row"})
+		}
+		return
+	}
+	countRows := func(t *testing.T) (count int) {
+		require.NoError(t, db.Get(&count, `SELECT count(*) FROM bulk_insert_test`))
+		return
+	}
+
+	t.Run("inserts a single chunk", func(t *testing.T) {
+		require.NoError(t, postgres.BulkInsert(db, "bulk_insert_test", columns, makeRows(0, 3), 10))
+		assert.Equal(t, 3, countRows(t))
+	})
+
+	t.Run("inserts multiple chunks", func(t *testing.T) {
+		require.NoError(t, postgres.BulkInsert(db, "bulk_insert_test", columns, makeRows(3, 8), 2))
+		assert.Equal(t, 8, countRows(t))
+
+		var ids []int
+		require.NoError(t, db.Select(&ids, `SELECT id FROM bulk_insert_test ORDER BY id`))
+		assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7}, ids)
+	})
+
+	t.Run("caps chunks to the bind parameter limit", func(t *testing.T) {
+		rows := makeRows(100, 100+postgres.MaxBindParams/len(columns)+1)
+		require.NoError(t, postgres.BulkInsert(db, "bulk_insert_test", columns, rows, 0))
+		assert.Equal(t, 8+len(rows), countRows(t))
+	})
+
+	t.Run("upserts with the on conflict clause", func(t *testing.T) {
+		rows := [][]interface{}{{0, "updated"}, {1, "updated"}, {-2, "new"}}
+		require.NoError(t, postgres.BulkUpsert(db, "bulk_insert_test", columns, rows, 2, "ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name"))
+
+		var names []string
+		require.NoError(t, db.Select(&names, `SELECT name FROM bulk_insert_test WHERE id IN (-2, 0, 1) ORDER BY id`))
+		assert.Equal(t, []string{"new", "updated", "updated"}, names)
+	})
+
+	t.Run("upsert errors without an on conflict clause", func(t *testing.T) {
+		err := postgres.BulkUpsert(db, "bulk_insert_test", columns, makeRows(-3, -2), 10, "")
+		require.EqualError(t, err, "cannot bulk upsert into bulk_insert_test without an ON CONFLICT clause")
+	})
+
+	t.Run("errors on rows that do not match the columns", func(t *testing.T) {
+		err := postgres.BulkInsert(db, "bulk_insert_test", columns, [][]interface{}{{-1}}, 10)
+		require.EqualError(t, err, "cannot bulk insert into bulk_insert_test: row 0 has 1 values but 2 columns were given")
+	})
+
+	t.Run("does nothing without rows", func(t *testing.T) {
+		q := new(mocks.Queryer)
+		require.NoError(t, postgres.BulkInsert(q, "bulk_insert_test", columns, nil, 10))
+		q.AssertExpectations(t)
+	})
+}
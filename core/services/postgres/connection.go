@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"fmt"
+	"time"
 
 	// need to make sure pgx driver is registered before opening connection
 	_ "github.com/jackc/pgx/v4/stdlib"
@@ -17,6 +18,10 @@ type Config struct {
 	LogSQLStatements bool
 	MaxOpenConns     int
 	MaxIdleConns     int
+	// ConnMaxLifetime and ConnMaxIdleTime bound how long connections are
+	// reused. Zero means forever.
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
 }
 
 func NewConnection(uri string, dialect string, config Config) (db *sqlx.DB, err error) {
@@ -45,6 +50,8 @@ func NewConnection(uri string, dialect string, config Config) (db *sqlx.DB, err
 	}
 	db.SetMaxOpenConns(config.MaxOpenConns)
 	db.SetMaxIdleConns(config.MaxIdleConns)
+	db.SetConnMaxLifetime(config.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(config.ConnMaxIdleTime)
 
 	return db, nil
 }
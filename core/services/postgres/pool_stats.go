@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/smartcontractkit/sqlx"
+	"go.uber.org/multierr"
+)
+
+// ExportPoolStats registers gauges with registerer that report the state of
+// db's connection pool. db.Stats() is sampled on every scrape, so the gauges
+// are always current without the need for a background poller.
+func ExportPoolStats(db *sqlx.DB, registerer prometheus.Registerer) (err error) {
+	gauges := []struct {
+		name, help string
+		value      func() float64
+	}{
+		{"db_conns_max", "Maximum number of open connections to the database", func() float64 {
+			return float64(db.Stats().MaxOpenConnections)
+		}},
+		{"db_conns_open", "Number of established connections to the database, both in use and idle", func() float64 {
+			return float64(db.Stats().OpenConnections)
+		}},
+		{"db_conns_used", "Number of connections to the database currently in use", func() float64 {
+			return float64(db.Stats().InUse)
+		}},
+		{"db_conns_idle", "Number of idle connections to the database", func() float64 {
+			return float64(db.Stats().Idle)
+		}},
+		{"db_wait_count", "Total number of connections waited for", func() float64 {
+			return float64(db.Stats().WaitCount)
+		}},
+		{"db_wait_time_seconds", "Total time blocked waiting for a new connection", func() float64 {
+			return db.Stats().WaitDuration.Seconds()
+		}},
+	}
+	for _, g := range gauges {
+		err = multierr.Combine(err, registerer.Register(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: g.name,
+			Help: g.help,
+		}, g.value)))
+	}
+	return err
+}
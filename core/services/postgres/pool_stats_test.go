@@ -0,0 +1,57 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/core/services/postgres"
+)
+
+func Test_ExportPoolStats(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	db.SetMaxOpenConns(7)
+	registry := prometheus.NewRegistry()
+
+	require.NoError(t, postgres.ExportPoolStats(db, registry))
+
+	// Hold a connection so the pool has something in use
+	conn, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, conn.Close()) })
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	values := make(map[string]float64)
+	for _, f := range families {
+		require.Len(t, f.GetMetric(), 1)
+		values[f.GetName()] = f.GetMetric()[0].GetGauge().GetValue()
+	}
+
+	assert.ElementsMatch(t, []string{
+		"db_conns_max",
+		"db_conns_open",
+		"db_conns_used",
+		"db_conns_idle",
+		"db_wait_count",
+		"db_wait_time_seconds",
+	}, keys(values))
+	assert.Equal(t, float64(7), values["db_conns_max"])
+	assert.GreaterOrEqual(t, values["db_conns_open"], float64(1))
+	assert.GreaterOrEqual(t, values["db_conns_used"], float64(1))
+
+	t.Run("fails when the gauges are already registered", func(t *testing.T) {
+		require.Error(t, postgres.ExportPoolStats(db, registry))
+	})
+}
+
+func keys(m map[string]float64) (ks []string) {
+	for k := range m {
+		ks = append(ks, k)
+	}
+	return
+}
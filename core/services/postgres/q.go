@@ -6,6 +6,8 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"reflect"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -61,6 +63,21 @@ func WithParentCtx(ctx context.Context) func(q *Q) {
 	}
 }
 
+// WithLogger sets the logger
+func WithLogger(lggr logger.Logger) func(q *Q) {
+	return func(q *Q) {
+		q.lggr = lggr
+	}
+}
+
+// WithSlowThreshold logs a warning to lggr for any query taking longer than d
+func WithSlowThreshold(lggr logger.Logger, d time.Duration) func(q *Q) {
+	return func(q *Q) {
+		q.lggr = lggr
+		q.slowThreshold = d
+	}
+}
+
 var _ Queryer = Q{}
 
 // Q wraps an underlying queryer (either a *sqlx.DB or a *sqlx.Tx)
@@ -78,8 +95,9 @@ var _ Queryer = Q{}
 // can do.
 type Q struct {
 	Queryer
-	lggr      logger.Logger
-	ParentCtx context.Context
+	lggr          logger.Logger
+	ParentCtx     context.Context
+	slowThreshold time.Duration
 }
 
 // NewQFromOpts is intended to be used in ORMs where the caller may wish to use
@@ -107,6 +125,34 @@ func PrepareQueryRowx(q Queryer, sql string, dest interface{}, arg interface{})
 	return errors.Wrap(stmt.QueryRowx(arg).Scan(dest), "error querying row")
 }
 
+// NamedExecReturning prepares the named query once and runs it for each of
+// args, scanning the RETURNING columns of every row into dest, which must be
+// a pointer to a slice. Elements are scanned as with Get, so dest may hold
+// either scalars or structs.
+func NamedExecReturning(q Queryer, query string, args []interface{}, dest interface{}) error {
+	destValue := reflect.ValueOf(dest)
+	if destValue.Kind() != reflect.Ptr || destValue.Elem().Kind() != reflect.Slice {
+		return errors.Errorf("dest must be a pointer to a slice, got %T", dest)
+	}
+	slice := destValue.Elem()
+	elemType := slice.Type().Elem()
+
+	stmt, err := q.PrepareNamed(query)
+	if err != nil {
+		return errors.Wrap(err, "error preparing named statement")
+	}
+	defer stmt.Close()
+
+	for _, arg := range args {
+		elem := reflect.New(elemType)
+		if err := stmt.Get(elem.Interface(), arg); err != nil {
+			return errors.Wrap(err, "error executing named statement")
+		}
+		slice.Set(reflect.Append(slice, elem.Elem()))
+	}
+	return nil
+}
+
 func (q Q) Context() (context.Context, context.CancelFunc) {
 	if q.ParentCtx == nil {
 		return DefaultQueryCtx()
@@ -120,6 +166,25 @@ func (q Q) Transaction(lggr logger.Logger, fc func(q Queryer) error, txOpts ...T
 	return SqlxTransaction(ctx, q.Queryer, lggr, fc, txOpts...)
 }
 
+// Pinger is implemented by Queryers backed by a connection pool, such as
+// *sqlx.DB. Transactions are not Pingers.
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// Ping checks that the database is reachable, giving up when ctx is done or
+// after DefaultQueryTimeout. A Queryer that is not a Pinger, i.e. a
+// transaction, is checked with a trivial query instead.
+func (q Q) Ping(ctx context.Context) error {
+	ctx, cancel := DefaultQueryCtxWithParent(ctx)
+	defer cancel()
+	if p, ok := q.Queryer.(Pinger); ok {
+		return p.PingContext(ctx)
+	}
+	_, err := q.Queryer.ExecContext(ctx, "SELECT 1")
+	return err
+}
+
 // CAUTION: A subtle problem lurks here, because the following code is buggy:
 //
 //     ctx, cancel := context.WithCancel(context.Background())
@@ -138,6 +203,7 @@ func (q Q) Transaction(lggr logger.Logger, fc func(q Queryer) error, txOpts ...T
 // Generally speaking, it makes more sense to use Get/Select in most cases,
 // which avoids this problem
 func (q Q) ExecQIter(query string, args ...interface{}) (sql.Result, context.CancelFunc, error) {
+	defer q.logSlowQuery(time.Now(), query)
 	ctx, cancel := q.Context()
 	res, err := q.Queryer.ExecContext(ctx, query, args...)
 	return res, cancel, err
@@ -158,11 +224,13 @@ func (q Q) ExecQNamed(query string, arg interface{}) (err error) {
 // Select and Get are safe to wrap the context cancellation because the rows
 // are entirely consumed within the call
 func (q Q) Select(dest interface{}, query string, args ...interface{}) error {
+	defer q.logSlowQuery(time.Now(), query)
 	ctx, cancel := q.Context()
 	defer cancel()
 	return q.Queryer.SelectContext(ctx, dest, query, args...)
 }
 func (q Q) Get(dest interface{}, query string, args ...interface{}) error {
+	defer q.logSlowQuery(time.Now(), query)
 	ctx, cancel := q.Context()
 	defer cancel()
 	return q.Queryer.GetContext(ctx, dest, query, args...)
@@ -172,7 +240,30 @@ func (q Q) GetNamed(sql string, dest interface{}, arg interface{}) error {
 	if err != nil {
 		return errors.Wrap(err, "error binding arg")
 	}
+	defer q.logSlowQuery(time.Now(), query)
 	ctx, cancel := q.Context()
 	defer cancel()
 	return errors.Wrap(q.GetContext(ctx, dest, query, args...), "error in get query")
 }
+
+// Exec and Query pass straight through to the underlying Queryer, and are
+// only wrapped here to be timed
+func (q Q) Exec(query string, args ...interface{}) (sql.Result, error) {
+	defer q.logSlowQuery(time.Now(), query)
+	return q.Queryer.Exec(query, args...)
+}
+func (q Q) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	defer q.logSlowQuery(time.Now(), query)
+	return q.Queryer.Query(query, args...)
+}
+
+func (q Q) logSlowQuery(start time.Time, query string) {
+	if q.slowThreshold <= 0 || q.lggr == nil {
+		return
+	}
+	elapsed := time.Since(start)
+	if elapsed <= q.slowThreshold {
+		return
+	}
+	q.lggr.Warnw("Slow query", "sql", query, "duration", elapsed)
+}
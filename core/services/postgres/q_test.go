@@ -0,0 +1,111 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/postgres"
+	"github.com/smartcontractkit/chainlink/core/services/postgres/mocks"
+)
+
+func Test_Q_SlowThreshold(t *testing.T) {
+	t.Parallel()
+
+	lggr := logger.TestLogger(t)
+	queryer := new(mocks.Queryer)
+	queryer.On("SelectContext", mock.Anything, mock.Anything, "SELECT 'slow query'", mock.Anything).
+		Run(func(mock.Arguments) { time.Sleep(50 * time.Millisecond) }).
+		Return(nil)
+	queryer.On("SelectContext", mock.Anything, mock.Anything, "SELECT 'fast query'", mock.Anything).
+		Return(nil)
+
+	q := postgres.NewQ(queryer, postgres.WithSlowThreshold(lggr, 10*time.Millisecond))
+
+	var dest []int
+	require.NoError(t, q.Select(&dest, "SELECT 'slow query'"))
+	require.NoError(t, q.Select(&dest, "SELECT 'fast query'"))
+
+	logs := logger.MemoryLogTestingOnly().String()
+	assert.Contains(t, logs, "SELECT 'slow query'")
+	assert.NotContains(t, logs, "SELECT 'fast query'")
+
+	queryer.AssertExpectations(t)
+}
+
+func Test_NamedExecReturning(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	_, err := db.Exec(`CREATE TABLE named_exec_returning_test (id serial PRIMARY KEY, name text NOT NULL)`)
+	require.NoError(t, err)
+
+	type row struct {
+		Name string
+	}
+	const query = `INSERT INTO named_exec_returning_test (name) VALUES (:name) RETURNING id`
+	args := []interface{}{row{"foo"}, row{"bar"}, row{"baz"}}
+
+	t.Run("scans returned ids", func(t *testing.T) {
+		var ids []int64
+		require.NoError(t, postgres.NamedExecReturning(db, query, args, &ids))
+		require.Len(t, ids, 3)
+
+		var expected []int64
+		require.NoError(t, db.Select(&expected, `SELECT id FROM named_exec_returning_test ORDER BY id`))
+		assert.Equal(t, expected, ids)
+	})
+
+	t.Run("scans returned rows into structs", func(t *testing.T) {
+		type returned struct {
+			ID   int64
+			Name string
+		}
+		var rows []returned
+		require.NoError(t, postgres.NamedExecReturning(db, `INSERT INTO named_exec_returning_test (name) VALUES (:name) RETURNING id, name`, args[:2], &rows))
+		require.Len(t, rows, 2)
+		assert.Equal(t, "foo", rows[0].Name)
+		assert.Equal(t, "bar", rows[1].Name)
+		assert.Less(t, rows[0].ID, rows[1].ID)
+	})
+
+	t.Run("rejects a non-slice dest", func(t *testing.T) {
+		var id int64
+		require.Error(t, postgres.NamedExecReturning(db, query, args, &id))
+	})
+}
+
+func Test_Q_Ping(t *testing.T) {
+	t.Parallel()
+
+	t.Run("live database", func(t *testing.T) {
+		db := pgtest.NewSqlxDB(t)
+		require.NoError(t, postgres.NewQ(db).Ping(context.Background()))
+	})
+
+	t.Run("closed database", func(t *testing.T) {
+		db := pgtest.NewSqlxDB(t)
+		require.NoError(t, db.Close())
+		require.Error(t, postgres.NewQ(db).Ping(context.Background()))
+	})
+
+	t.Run("respects the context", func(t *testing.T) {
+		db := pgtest.NewSqlxDB(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := postgres.NewQ(db).Ping(ctx)
+		require.True(t, errors.Is(err, context.Canceled), "expected context.Canceled, got %v", err)
+	})
+
+	t.Run("transaction", func(t *testing.T) {
+		queryer := new(mocks.Queryer)
+		queryer.On("ExecContext", mock.Anything, "SELECT 1").Return(nil, nil).Once()
+		require.NoError(t, postgres.NewQ(queryer).Ping(context.Background()))
+		queryer.AssertExpectations(t)
+	})
+}
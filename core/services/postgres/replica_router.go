@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"sync/atomic"
+
+	"github.com/smartcontractkit/sqlx"
+)
+
+var _ Queryer = (*ReplicaRouter)(nil)
+
+// ReplicaRouter is a Queryer that spreads reads across read replicas and
+// sends everything else to the primary.
+//
+// Select, Get, Query and QueryRow (including their x and Context variants) go
+// to a replica chosen round-robin. Since they are routed on method alone,
+// they must not be used for statements that write, such as INSERT ...
+// RETURNING; use the primary for those. Exec, NamedExec, NamedQuery,
+// prepared statements and read-write transactions always go to the primary,
+// while read-only transactions go to a replica.
+//
+// With no replicas configured all queries go to the primary.
+type ReplicaRouter struct {
+	*sqlx.DB
+	replicas []*sqlx.DB
+	next     uint32
+}
+
+// NewReplicaRouter returns a ReplicaRouter writing to primary and reading
+// from replicas
+func NewReplicaRouter(primary *sqlx.DB, replicas ...*sqlx.DB) *ReplicaRouter {
+	return &ReplicaRouter{DB: primary, replicas: replicas}
+}
+
+// Primary returns the primary DB
+func (r *ReplicaRouter) Primary() *sqlx.DB {
+	return r.DB
+}
+
+// Replica returns the next replica in round-robin order, or the primary if
+// there are no replicas
+func (r *ReplicaRouter) Replica() *sqlx.DB {
+	if len(r.replicas) == 0 {
+		return r.DB
+	}
+	i := atomic.AddUint32(&r.next, 1) - 1
+	return r.replicas[i%uint32(len(r.replicas))]
+}
+
+func (r *ReplicaRouter) Select(dest interface{}, query string, args ...interface{}) error {
+	return r.Replica().Select(dest, query, args...)
+}
+func (r *ReplicaRouter) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return r.Replica().SelectContext(ctx, dest, query, args...)
+}
+func (r *ReplicaRouter) Get(dest interface{}, query string, args ...interface{}) error {
+	return r.Replica().Get(dest, query, args...)
+}
+func (r *ReplicaRouter) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return r.Replica().GetContext(ctx, dest, query, args...)
+}
+func (r *ReplicaRouter) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return r.Replica().Query(query, args...)
+}
+func (r *ReplicaRouter) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return r.Replica().QueryContext(ctx, query, args...)
+}
+func (r *ReplicaRouter) Queryx(query string, args ...interface{}) (*sqlx.Rows, error) {
+	return r.Replica().Queryx(query, args...)
+}
+func (r *ReplicaRouter) QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
+	return r.Replica().QueryxContext(ctx, query, args...)
+}
+func (r *ReplicaRouter) QueryRow(query string, args ...interface{}) *sql.Row {
+	return r.Replica().QueryRow(query, args...)
+}
+func (r *ReplicaRouter) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return r.Replica().QueryRowContext(ctx, query, args...)
+}
+func (r *ReplicaRouter) QueryRowx(query string, args ...interface{}) *sqlx.Row {
+	return r.Replica().QueryRowx(query, args...)
+}
+func (r *ReplicaRouter) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	return r.Replica().QueryRowxContext(ctx, query, args...)
+}
+
+// transactionDB returns the DB a transaction with the given options should
+// run against
+func (r *ReplicaRouter) transactionDB(txOpts []TxOptions) *sqlx.DB {
+	if mergeTxOptions(txOpts).ReadOnly {
+		return r.Replica()
+	}
+	return r.DB
+}
@@ -0,0 +1,80 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest/heavyweight"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/postgres"
+)
+
+func newReplicaRouterTestDB(t *testing.T, name string) *sqlx.DB {
+	_, db := heavyweight.FullTestDB(t, name, false, false)
+	_, err := db.Exec(`CREATE TABLE whoami (name text NOT NULL); INSERT INTO whoami (name) VALUES ($1)`, name)
+	require.NoError(t, err)
+	return db
+}
+
+func Test_ReplicaRouter(t *testing.T) {
+	primary := newReplicaRouterTestDB(t, "router_primary")
+	replica := newReplicaRouterTestDB(t, "router_replica")
+	lggr := logger.TestLogger(t)
+
+	whoami := func(t *testing.T, q postgres.Queryer) string {
+		var names []string
+		require.NoError(t, q.Select(&names, `SELECT name FROM whoami`))
+		require.NotEmpty(t, names)
+		return names[0]
+	}
+
+	t.Run("sends reads to the replica and writes to the primary", func(t *testing.T) {
+		router := postgres.NewReplicaRouter(primary, replica)
+
+		var name string
+		require.NoError(t, router.Get(&name, `SELECT name FROM whoami LIMIT 1`))
+		assert.Equal(t, "router_replica", name)
+		assert.Equal(t, "router_replica", whoami(t, router))
+		require.NoError(t, router.QueryRow(`SELECT name FROM whoami LIMIT 1`).Scan(&name))
+		assert.Equal(t, "router_replica", name)
+
+		_, err := router.Exec(`INSERT INTO whoami (name) VALUES ('written')`)
+		require.NoError(t, err)
+
+		var count int
+		require.NoError(t, primary.Get(&count, `SELECT count(*) FROM whoami WHERE name = 'written'`))
+		assert.Equal(t, 1, count)
+		require.NoError(t, replica.Get(&count, `SELECT count(*) FROM whoami WHERE name = 'written'`))
+		assert.Equal(t, 0, count)
+	})
+
+	t.Run("runs read-write transactions on the primary and read-only transactions on a replica", func(t *testing.T) {
+		router := postgres.NewReplicaRouter(primary, replica)
+
+		require.NoError(t, postgres.NewQ(router).Transaction(lggr, func(q postgres.Queryer) error {
+			assert.Equal(t, "router_primary", whoami(t, q))
+			return nil
+		}))
+		require.NoError(t, postgres.NewQ(router).Transaction(lggr, func(q postgres.Queryer) error {
+			assert.Equal(t, "router_replica", whoami(t, q))
+			return nil
+		}, postgres.OptReadOnlyTx()))
+	})
+
+	t.Run("picks replicas round-robin", func(t *testing.T) {
+		router := postgres.NewReplicaRouter(primary, replica, primary)
+
+		assert.Equal(t, "router_replica", whoami(t, router))
+		assert.Equal(t, "router_primary", whoami(t, router))
+		assert.Equal(t, "router_replica", whoami(t, router))
+	})
+
+	t.Run("falls back to the primary without replicas", func(t *testing.T) {
+		router := postgres.NewReplicaRouter(primary)
+
+		assert.Equal(t, "router_primary", whoami(t, router))
+	})
+}
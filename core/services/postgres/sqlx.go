@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/pkg/errors"
 	mapper "github.com/scylladb/go-reflectx"
@@ -11,9 +12,6 @@ import (
 	"github.com/smartcontractkit/sqlx"
 )
 
-// AllowUnknownQueryerTypeInTransaction can be set by tests to allow a mock to be passed as a Queryer
-var AllowUnknownQueryerTypeInTransaction bool
-
 //go:generate mockery --name Queryer --output ./mocks/ --case=underscore
 type Queryer interface {
 	sqlx.Ext
@@ -38,22 +36,69 @@ func WrapDbWithSqlx(rdb *sql.DB) *sqlx.DB {
 	return db
 }
 
+// SqlxOpts configures the connection pool of a database wrapped with
+// WrapDbWithSqlxOpts. Zero values leave the corresponding pool setting as it
+// is.
+type SqlxOpts struct {
+	MaxOpenConns int
+	MaxIdleConns int
+	// ConnMaxLifetime closes connections once they have been open this long,
+	// so that connections behind a load balancer do not go stale
+	ConnMaxLifetime time.Duration
+	// ConnMaxIdleTime closes connections once they have been idle this long
+	ConnMaxIdleTime time.Duration
+}
+
+// WrapDbWithSqlxOpts is like WrapDbWithSqlx, but also applies opts to the
+// connection pool of rdb.
+func WrapDbWithSqlxOpts(rdb *sql.DB, opts SqlxOpts) *sqlx.DB {
+	if opts.MaxOpenConns > 0 {
+		rdb.SetMaxOpenConns(opts.MaxOpenConns)
+	}
+	if opts.MaxIdleConns > 0 {
+		rdb.SetMaxIdleConns(opts.MaxIdleConns)
+	}
+	if opts.ConnMaxLifetime > 0 {
+		rdb.SetConnMaxLifetime(opts.ConnMaxLifetime)
+	}
+	if opts.ConnMaxIdleTime > 0 {
+		rdb.SetConnMaxIdleTime(opts.ConnMaxIdleTime)
+	}
+	return WrapDbWithSqlx(rdb)
+}
+
+// SqlxTransactionWithDefaultCtx runs fc in a transaction bounded by
+// DefaultQueryTimeout. It does not inherit any deadline or cancellation from
+// the caller; use SqlxTransaction for request-scoped work.
 func SqlxTransactionWithDefaultCtx(q Queryer, lggr logger.Logger, fc func(q Queryer) error, txOpts ...TxOptions) (err error) {
 	ctx, cancel := DefaultQueryCtx()
 	defer cancel()
 	return SqlxTransaction(ctx, q, lggr, fc, txOpts...)
 }
 
+// SqlxTransaction runs fc in a transaction on q, committing if fc returns nil
+// and rolling back otherwise. If q is already a transaction, fc is run in it
+// directly, or in a savepoint with OptNestWithSavepoint.
+//
+// The transaction is started with ctx, so it honors the caller's deadline and
+// cancellation end-to-end: if ctx is done before the transaction commits, it
+// is rolled back and the returned error wraps ctx.Err().
 func SqlxTransaction(ctx context.Context, q Queryer, lggr logger.Logger, fc func(q Queryer) error, txOpts ...TxOptions) (err error) {
 	switch db := q.(type) {
 	case *sqlx.Tx:
 		// nested transaction: just use the outer transaction
-		err = fc(db)
+		if mergeTxOptions(txOpts).NestWithSavepoint {
+			err = sqlxSavepoint(db, fc)
+		} else {
+			err = fc(db)
+		}
 	case *sqlx.DB:
 		err = sqlxTransactionQ(ctx, db, lggr, fc, txOpts...)
+	case *ReplicaRouter:
+		err = sqlxTransactionQ(ctx, db.transactionDB(txOpts), lggr, fc, txOpts...)
 	default:
-		if AllowUnknownQueryerTypeInTransaction {
-			err = fc(q)
+		if allowUnknownQueryer(txOpts) {
+			err = retryOnSerializationFailure(ctx, lggr, txOpts, func() error { return fc(q) })
 		} else {
 			err = errors.Errorf("invalid db type")
 		}
@@ -61,3 +106,7 @@ func SqlxTransaction(ctx context.Context, q Queryer, lggr logger.Logger, fc func
 
 	return
 }
+
+func allowUnknownQueryer(txOpts []TxOptions) bool {
+	return mergeTxOptions(txOpts).AllowUnknownQueryer
+}
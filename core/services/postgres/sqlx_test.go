@@ -0,0 +1,58 @@
+package postgres_test
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/services/postgres"
+)
+
+func Test_WrapDbWithSqlxOpts(t *testing.T) {
+	t.Parallel()
+
+	// sql.Open does not connect, so the pool settings can be inspected without
+	// a database
+	open := func(t *testing.T) *sql.DB {
+		rdb, err := sql.Open("pgx", "postgres://localhost/chainlink_test")
+		require.NoError(t, err)
+		t.Cleanup(func() { assert.NoError(t, rdb.Close()) })
+		return rdb
+	}
+	// The lifetimes and idle limit are unexported, so read them by reflection
+	poolField := func(rdb *sql.DB, name string) int64 {
+		return reflect.ValueOf(rdb).Elem().FieldByName(name).Int()
+	}
+
+	t.Run("applies the options", func(t *testing.T) {
+		rdb := open(t)
+		db := postgres.WrapDbWithSqlxOpts(rdb, postgres.SqlxOpts{
+			MaxOpenConns:    7,
+			MaxIdleConns:    3,
+			ConnMaxLifetime: 5 * time.Minute,
+			ConnMaxIdleTime: 1 * time.Minute,
+		})
+
+		assert.Equal(t, 7, db.Stats().MaxOpenConnections)
+		assert.Equal(t, int64(3), poolField(rdb, "maxIdleCount"))
+		assert.Equal(t, int64(5*time.Minute), poolField(rdb, "maxLifetime"))
+		assert.Equal(t, int64(1*time.Minute), poolField(rdb, "maxIdleTime"))
+	})
+
+	t.Run("zero options leave the pool unchanged", func(t *testing.T) {
+		rdb := open(t)
+		rdb.SetMaxOpenConns(7)
+		rdb.SetMaxIdleConns(3)
+		rdb.SetConnMaxLifetime(5 * time.Minute)
+		db := postgres.WrapDbWithSqlxOpts(rdb, postgres.SqlxOpts{})
+
+		assert.Equal(t, 7, db.Stats().MaxOpenConnections)
+		assert.Equal(t, int64(3), poolField(rdb, "maxIdleCount"))
+		assert.Equal(t, int64(5*time.Minute), poolField(rdb, "maxLifetime"))
+		assert.Equal(t, int64(0), poolField(rdb, "maxIdleTime"))
+	})
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sync/atomic"
 	"time"
 
+	"github.com/jpillora/backoff"
 	"github.com/pkg/errors"
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/sqlx"
@@ -16,6 +18,56 @@ type TxOptions struct {
 	sql.TxOptions
 	LockTimeout            time.Duration
 	IdleInTxSessionTimeout time.Duration
+	// RetryAttempts is the number of times a transaction that fails with a
+	// serialization failure or deadlock is retried, with exponential backoff.
+	// Zero disables retrying.
+	RetryAttempts int
+	// AllowUnknownQueryer lets a Queryer other than *sqlx.DB or *sqlx.Tx, such
+	// as a mock, be passed to SqlxTransaction. The callback is then called with
+	// the Queryer directly instead of a transaction. Only meant for tests.
+	AllowUnknownQueryer bool
+	// NestWithSavepoint makes SqlxTransaction, when given a transaction, run
+	// the callback between a savepoint and its release, rolling back to the
+	// savepoint if the callback fails. Only the callback's work is then undone,
+	// and the outer transaction can carry on. Without it, the callback is run
+	// in the outer transaction as is.
+	NestWithSavepoint bool
+	// Observer, if set, is notified as each transaction begins and as it is
+	// committed or rolled back, e.g. to trace it.
+	Observer TxObserver
+}
+
+// TxEvent is a step in the life of a transaction
+type TxEvent int
+
+const (
+	TxBegin TxEvent = iota
+	TxCommit
+	TxRollback
+)
+
+func (e TxEvent) String() string {
+	switch e {
+	case TxBegin:
+		return "begin"
+	case TxCommit:
+		return "commit"
+	case TxRollback:
+		return "rollback"
+	default:
+		return fmt.Sprintf("TxEvent(%d)", int(e))
+	}
+}
+
+// TxObserver is notified of the steps of transactions run with it in their
+// TxOptions. Every TxBegin is followed by exactly one TxCommit or TxRollback,
+// including when the callback fails or panics. A transaction retried after a
+// serialization failure is observed once per attempt.
+type TxObserver interface {
+	// ObserveTx is called with the time elapsed since the transaction began,
+	// which is zero for TxBegin. err is the error that caused a rollback, or
+	// the error returned by a failed commit.
+	ObserveTx(ctx context.Context, event TxEvent, elapsed time.Duration, err error)
 }
 
 // NOTE: In an ideal world the timeouts below would be set to something sane in
@@ -34,41 +86,117 @@ const (
 	// NOTE: This is the default level in Postgres anyway, we just make it
 	// explicit here
 	DefaultIsolation = sql.LevelReadCommitted
+	// retryBackoffMin and retryBackoffMax bound the delay between attempts of
+	// a retried transaction
+	retryBackoffMin = 10 * time.Millisecond
+	retryBackoffMax = 1 * time.Second
 )
 
 func OptReadOnlyTx() TxOptions {
 	return TxOptions{TxOptions: sql.TxOptions{ReadOnly: true}}
 }
 
+// OptAllowUnknownQueryer returns TxOptions that let tests pass a mock Queryer
+// to SqlxTransaction
+func OptAllowUnknownQueryer() TxOptions {
+	return TxOptions{AllowUnknownQueryer: true}
+}
+
+// OptNestWithSavepoint returns TxOptions that isolate a transaction nested in
+// another with a savepoint
+func OptNestWithSavepoint() TxOptions {
+	return TxOptions{NestWithSavepoint: true}
+}
+
+// OptIsolation returns TxOptions that run the transaction at the given
+// isolation level instead of DefaultIsolation
+func OptIsolation(level sql.IsolationLevel) TxOptions {
+	return TxOptions{TxOptions: sql.TxOptions{Isolation: level}}
+}
+
+// OptRetry returns TxOptions that retry a transaction failing with a
+// serialization failure or deadlock up to attempts times
+func OptRetry(attempts int) TxOptions {
+	return TxOptions{RetryAttempts: attempts}
+}
+
+// mergeTxOptions combines optss into one TxOptions, so that options such as
+// OptIsolation and OptRetry can be passed together. Flags set in any of them
+// are set, and for other fields the last non-zero value wins.
+func mergeTxOptions(optss []TxOptions) (merged TxOptions) {
+	for _, opts := range optss {
+		if opts.Isolation != 0 {
+			merged.Isolation = opts.Isolation
+		}
+		merged.ReadOnly = merged.ReadOnly || opts.ReadOnly
+		if opts.LockTimeout != 0 {
+			merged.LockTimeout = opts.LockTimeout
+		}
+		if opts.IdleInTxSessionTimeout != 0 {
+			merged.IdleInTxSessionTimeout = opts.IdleInTxSessionTimeout
+		}
+		if opts.RetryAttempts != 0 {
+			merged.RetryAttempts = opts.RetryAttempts
+		}
+		merged.AllowUnknownQueryer = merged.AllowUnknownQueryer || opts.AllowUnknownQueryer
+		merged.NestWithSavepoint = merged.NestWithSavepoint || opts.NestWithSavepoint
+		if opts.Observer != nil {
+			merged.Observer = opts.Observer
+		}
+	}
+	return merged
+}
+
 var (
 	ErrNoDeadlineSet = errors.New("no deadline set")
 )
 
+// savepointSeq numbers savepoints, so that nested savepoints never share a name
+var savepointSeq uint64
+
 func applyDefaults(optss []TxOptions) (lockTimeout, idleInTxSessionTimeout time.Duration, txOpts sql.TxOptions) {
 	lockTimeout = DefaultLockTimeout
 	idleInTxSessionTimeout = DefaultIdleInTxSessionTimeout
 	txIsolation := DefaultIsolation
-	readOnly := false
-	if len(optss) > 0 {
-		opts := optss[0]
-		if opts.LockTimeout != 0 {
-			lockTimeout = opts.LockTimeout
-		}
-		if opts.IdleInTxSessionTimeout != 0 {
-			idleInTxSessionTimeout = opts.IdleInTxSessionTimeout
-		}
-		if opts.Isolation != 0 {
-			txIsolation = opts.Isolation
-		}
-		readOnly = opts.ReadOnly
+	opts := mergeTxOptions(optss)
+	if opts.LockTimeout != 0 {
+		lockTimeout = opts.LockTimeout
+	}
+	if opts.IdleInTxSessionTimeout != 0 {
+		idleInTxSessionTimeout = opts.IdleInTxSessionTimeout
+	}
+	if opts.Isolation != 0 {
+		txIsolation = opts.Isolation
 	}
 	txOpts = sql.TxOptions{
 		Isolation: txIsolation,
-		ReadOnly:  readOnly,
+		ReadOnly:  opts.ReadOnly,
 	}
 	return
 }
 
+// retryOnSerializationFailure calls fn, calling it again with exponential
+// backoff for as long as it fails with a retryable error and attempts remain.
+// The last error is returned once attempts are exhausted.
+func retryOnSerializationFailure(ctx context.Context, lggr logger.Logger, optss []TxOptions, fn func() error) (err error) {
+	attempts := mergeTxOptions(optss).RetryAttempts
+	b := backoff.Backoff{Min: retryBackoffMin, Max: retryBackoffMax, Jitter: true}
+	for {
+		err = fn()
+		if err == nil || attempts <= 0 || !IsRetryableTxError(err) {
+			return err
+		}
+		attempts--
+		delay := b.Duration()
+		lggr.Debugw("Retrying transaction after serialization failure", "err", err, "delay", delay, "attemptsRemaining", attempts)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
+
 func SqlTransaction(ctx context.Context, rdb *sql.DB, lggr logger.Logger, fn func(tx *sqlx.Tx) error, optss ...TxOptions) (err error) {
 	db := WrapDbWithSqlx(rdb)
 	return sqlxTransaction(ctx, db, lggr, fn, optss...)
@@ -86,15 +214,32 @@ func sqlxTransaction(ctx context.Context, db *sqlx.DB, lggr logger.Logger, fn fu
 }
 
 func sqlxTransactionQ(ctx context.Context, db *sqlx.DB, lggr logger.Logger, fn func(q Queryer) error, optss ...TxOptions) (err error) {
+	return retryOnSerializationFailure(ctx, lggr, optss, func() error {
+		return sqlxTransactionQOnce(ctx, db, lggr, fn, optss...)
+	})
+}
+
+func sqlxTransactionQOnce(ctx context.Context, db *sqlx.DB, lggr logger.Logger, fn func(q Queryer) error, optss ...TxOptions) (err error) {
 	lockTimeout, idleInTxSessionTimeout, txOpts := applyDefaults(optss)
 
 	tx, err := db.BeginTxx(ctx, &txOpts)
 	if err != nil {
 		return errors.Wrap(err, "failed to begin transaction")
 	}
+	observer := mergeTxOptions(optss).Observer
+	began := time.Now()
+	observe := func(event TxEvent, err error) {
+		if observer != nil {
+			observer.ObserveTx(ctx, event, time.Since(began), err)
+		}
+	}
+	if observer != nil {
+		observer.ObserveTx(ctx, TxBegin, 0, nil)
+	}
 
 	defer func() {
 		if p := recover(); p != nil {
+			observe(TxRollback, errors.Errorf("panic in transaction: %v", p))
 			// A panic occurred, rollback and repanic
 			lggr.Errorf("Panic in transaction, rolling back: %s", p)
 			done := make(chan struct{})
@@ -112,13 +257,16 @@ func sqlxTransactionQ(ctx context.Context, db *sqlx.DB, lggr logger.Logger, fn f
 			}
 		} else if err != nil {
 			lggr.Debugf("Error in transaction, rolling back: %s", err)
-			// An error occurred, rollback and return error
-			if rerr := tx.Rollback(); rerr != nil {
+			// An error occurred, rollback and return error. If ctx is done the
+			// transaction has already been rolled back by database/sql.
+			if rerr := tx.Rollback(); rerr != nil && !errors.Is(rerr, sql.ErrTxDone) {
 				err = multierr.Combine(err, errors.WithStack(rerr))
 			}
+			observe(TxRollback, err)
 		} else {
 			// All good! Time to commit.
 			err = errors.WithStack(tx.Commit())
+			observe(TxCommit, err)
 		}
 	}()
 
@@ -136,6 +284,29 @@ func sqlxTransactionQ(ctx context.Context, db *sqlx.DB, lggr logger.Logger, fn f
 	}
 
 	err = fn(tx)
+	if err == nil {
+		// Don't attempt to commit if ctx was canceled or timed out while fn
+		// was running, so that the caller sees why the transaction failed
+		err = errors.Wrap(ctx.Err(), "transaction context done")
+	}
 
 	return
 }
+
+// sqlxSavepoint runs fn in tx between a savepoint and its release. If fn
+// fails, the transaction is rolled back to the savepoint, undoing only the
+// work done by fn.
+func sqlxSavepoint(tx *sqlx.Tx, fn func(q Queryer) error) (err error) {
+	name := fmt.Sprintf("chainlink_savepoint_%d", atomic.AddUint64(&savepointSeq, 1))
+	if _, err = tx.Exec("SAVEPOINT " + name); err != nil {
+		return errors.Wrap(err, "failed to create savepoint")
+	}
+	if err = fn(tx); err != nil {
+		if _, rerr := tx.Exec("ROLLBACK TO SAVEPOINT " + name); rerr != nil {
+			err = multierr.Combine(err, errors.Wrap(rerr, "failed to roll back to savepoint"))
+		}
+		return err
+	}
+	_, err = tx.Exec("RELEASE SAVEPOINT " + name)
+	return errors.Wrap(err, "failed to release savepoint")
+}
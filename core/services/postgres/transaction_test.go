@@ -0,0 +1,331 @@
+package postgres_test
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest/heavyweight"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/postgres"
+	"github.com/smartcontractkit/chainlink/core/services/postgres/mocks"
+)
+
+func Test_IsRetryableTxError(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, postgres.IsRetryableTxError(nil))
+	assert.False(t, postgres.IsRetryableTxError(errors.New("foo")))
+	assert.False(t, postgres.IsRetryableTxError(&pgconn.PgError{Code: "23505"}))
+	assert.True(t, postgres.IsRetryableTxError(&pgconn.PgError{Code: "40001"}))
+	assert.True(t, postgres.IsRetryableTxError(&pgconn.PgError{Code: "40P01"}))
+	assert.True(t, postgres.IsRetryableTxError(errors.Wrap(&pgconn.PgError{Code: "40001"}, "wrapped")))
+	assert.True(t, postgres.IsRetryableTxError(&pq.Error{Code: "40001"}))
+	assert.True(t, postgres.IsRetryableTxError(&pq.Error{Code: "40P01"}))
+}
+
+func Test_ClassifyError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		err      error
+		expected postgres.PGErrorClass
+	}{
+		{"nil", nil, postgres.Other},
+		{"not a postgres error", errors.New("foo"), postgres.Other},
+		{"unclassified code", &pq.Error{Code: "22001"}, postgres.Other},
+		{"unique violation", &pq.Error{Code: "23505"}, postgres.UniqueViolation},
+		{"foreign key violation", &pq.Error{Code: "23503"}, postgres.ForeignKeyViolation},
+		{"not null violation", &pq.Error{Code: "23502"}, postgres.NotNullViolation},
+		{"serialization failure", &pq.Error{Code: "40001"}, postgres.SerializationFailure},
+		{"deadlock", &pq.Error{Code: "40P01"}, postgres.Deadlock},
+		{"pgconn unique violation", &pgconn.PgError{Code: "23505"}, postgres.UniqueViolation},
+		{"wrapped pq error", errors.Wrap(&pq.Error{Code: "23503"}, "wrapped"), postgres.ForeignKeyViolation},
+		{"wrapped pgconn error", errors.Wrap(&pgconn.PgError{Code: "23502"}, "wrapped"), postgres.NotNullViolation},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, postgres.ClassifyError(test.err))
+		})
+	}
+
+	assert.Equal(t, "UniqueViolation", postgres.UniqueViolation.String())
+	assert.Equal(t, "Other", postgres.Other.String())
+}
+
+func Test_SqlxTransaction_RetryAttempts(t *testing.T) {
+	t.Parallel()
+
+	serializationFailure := &pgconn.PgError{Code: "40001"}
+	q := new(mocks.Queryer)
+	lggr := logger.TestLogger(t)
+
+	t.Run("retries serialization failures until the callback succeeds", func(t *testing.T) {
+		var calls int
+		err := postgres.SqlxTransaction(context.Background(), q, lggr, func(postgres.Queryer) error {
+			calls++
+			if calls <= 2 {
+				return serializationFailure
+			}
+			return nil
+		}, postgres.TxOptions{RetryAttempts: 3, AllowUnknownQueryer: true})
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("combines OptRetry with other options", func(t *testing.T) {
+		var calls int
+		err := postgres.SqlxTransaction(context.Background(), q, lggr, func(postgres.Queryer) error {
+			calls++
+			if calls <= 2 {
+				return serializationFailure
+			}
+			return nil
+		}, postgres.OptAllowUnknownQueryer(), postgres.OptRetry(3))
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("returns the last error once attempts are exhausted", func(t *testing.T) {
+		var calls int
+		err := postgres.SqlxTransaction(context.Background(), q, lggr, func(postgres.Queryer) error {
+			calls++
+			return serializationFailure
+		}, postgres.TxOptions{RetryAttempts: 2, AllowUnknownQueryer: true})
+		require.Equal(t, serializationFailure, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("does not retry other errors", func(t *testing.T) {
+		var calls int
+		err := postgres.SqlxTransaction(context.Background(), q, lggr, func(postgres.Queryer) error {
+			calls++
+			return errors.New("boom")
+		}, postgres.TxOptions{RetryAttempts: 3, AllowUnknownQueryer: true})
+		require.EqualError(t, err, "boom")
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("does not retry by default", func(t *testing.T) {
+		var calls int
+		err := postgres.SqlxTransaction(context.Background(), q, lggr, func(postgres.Queryer) error {
+			calls++
+			return serializationFailure
+		}, postgres.OptAllowUnknownQueryer())
+		require.Equal(t, serializationFailure, err)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func Test_SqlxTransaction_AllowUnknownQueryer(t *testing.T) {
+	t.Parallel()
+
+	q := new(mocks.Queryer)
+	lggr := logger.TestLogger(t)
+
+	t.Run("rejects unknown Queryers by default", func(t *testing.T) {
+		err := postgres.SqlxTransaction(context.Background(), q, lggr, func(postgres.Queryer) error {
+			t.Fatal("callback must not be called")
+			return nil
+		})
+		require.EqualError(t, err, "invalid db type")
+	})
+
+	t.Run("passes unknown Queryers through with OptAllowUnknownQueryer", func(t *testing.T) {
+		var got postgres.Queryer
+		err := postgres.SqlxTransaction(context.Background(), q, lggr, func(tx postgres.Queryer) error {
+			got = tx
+			return nil
+		}, postgres.OptAllowUnknownQueryer())
+		require.NoError(t, err)
+		assert.Same(t, q, got)
+	})
+
+	q.AssertExpectations(t)
+}
+
+func Test_SqlxTransaction_Isolation(t *testing.T) {
+	_, db := heavyweight.FullTestDB(t, "transaction_isolation", false, false)
+	lggr := logger.TestLogger(t)
+
+	isolationLevel := func(t *testing.T, txOpts ...postgres.TxOptions) (level string) {
+		require.NoError(t, postgres.SqlxTransaction(context.Background(), db, lggr, func(q postgres.Queryer) error {
+			return q.Get(&level, `SELECT current_setting('transaction_isolation')`)
+		}, txOpts...))
+		return
+	}
+
+	assert.Equal(t, "read committed", isolationLevel(t))
+	assert.Equal(t, "repeatable read", isolationLevel(t, postgres.OptIsolation(sql.LevelRepeatableRead)))
+	assert.Equal(t, "serializable", isolationLevel(t, postgres.OptIsolation(sql.LevelSerializable)))
+}
+
+func Test_SqlxTransaction_ContextCanceled(t *testing.T) {
+	_, db := heavyweight.FullTestDB(t, "transaction_context_canceled", false, false)
+	lggr := logger.TestLogger(t)
+	_, err := db.Exec(`CREATE TABLE canceled_tx_test (id int PRIMARY KEY)`)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err = postgres.SqlxTransaction(ctx, db, lggr, func(q postgres.Queryer) error {
+		if _, err2 := q.Exec(`INSERT INTO canceled_tx_test (id) VALUES (1)`); err2 != nil {
+			return err2
+		}
+		cancel()
+		return nil
+	})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.Canceled), "expected context.Canceled, got: %v", err)
+
+	var count int
+	require.NoError(t, db.Get(&count, `SELECT count(*) FROM canceled_tx_test`))
+	assert.Equal(t, 0, count)
+}
+
+func Test_SqlxTransaction_NestWithSavepoint(t *testing.T) {
+	_, db := heavyweight.FullTestDB(t, "transaction_savepoint", false, false)
+	lggr := logger.TestLogger(t)
+	_, err := db.Exec(`CREATE TABLE savepoint_test (id int PRIMARY KEY)`)
+	require.NoError(t, err)
+
+	ids := func(t *testing.T) (ids []int) {
+		require.NoError(t, db.Select(&ids, `SELECT id FROM savepoint_test ORDER BY id`))
+		return
+	}
+	innerErr := errors.New("inner failed")
+
+	t.Run("rolls back only the failed inner transaction", func(t *testing.T) {
+		err := postgres.SqlxTransaction(context.Background(), db, lggr, func(q postgres.Queryer) error {
+			if _, err := q.Exec(`INSERT INTO savepoint_test (id) VALUES (1)`); err != nil {
+				return err
+			}
+			err := postgres.SqlxTransaction(context.Background(), q, lggr, func(q postgres.Queryer) error {
+				if _, err := q.Exec(`INSERT INTO savepoint_test (id) VALUES (2)`); err != nil {
+					return err
+				}
+				return innerErr
+			}, postgres.OptNestWithSavepoint())
+			require.Equal(t, innerErr, err)
+
+			err = postgres.SqlxTransaction(context.Background(), q, lggr, func(q postgres.Queryer) error {
+				_, err := q.Exec(`INSERT INTO savepoint_test (id) VALUES (3)`)
+				return err
+			}, postgres.OptNestWithSavepoint())
+			require.NoError(t, err)
+
+			_, err = q.Exec(`INSERT INTO savepoint_test (id) VALUES (4)`)
+			return err
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 3, 4}, ids(t))
+	})
+
+	_, err = db.Exec(`DELETE FROM savepoint_test`)
+	require.NoError(t, err)
+
+	t.Run("keeps the inner writes by default", func(t *testing.T) {
+		err := postgres.SqlxTransaction(context.Background(), db, lggr, func(q postgres.Queryer) error {
+			if _, err := q.Exec(`INSERT INTO savepoint_test (id) VALUES (1)`); err != nil {
+				return err
+			}
+			err := postgres.SqlxTransaction(context.Background(), q, lggr, func(q postgres.Queryer) error {
+				if _, err := q.Exec(`INSERT INTO savepoint_test (id) VALUES (2)`); err != nil {
+					return err
+				}
+				return innerErr
+			})
+			require.Equal(t, innerErr, err)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2}, ids(t))
+	})
+
+	_, err = db.Exec(`DELETE FROM savepoint_test`)
+	require.NoError(t, err)
+
+	t.Run("recovers from a failed statement in the inner transaction", func(t *testing.T) {
+		err := postgres.SqlxTransaction(context.Background(), db, lggr, func(q postgres.Queryer) error {
+			if _, err := q.Exec(`INSERT INTO savepoint_test (id) VALUES (1)`); err != nil {
+				return err
+			}
+			err := postgres.SqlxTransaction(context.Background(), q, lggr, func(q postgres.Queryer) error {
+				// violates the primary key, aborting the transaction up to the savepoint
+				_, err := q.Exec(`INSERT INTO savepoint_test (id) VALUES (1)`)
+				return err
+			}, postgres.OptNestWithSavepoint())
+			require.Error(t, err)
+
+			_, err = q.Exec(`INSERT INTO savepoint_test (id) VALUES (2)`)
+			return err
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2}, ids(t))
+	})
+}
+
+type recordingTxObserver struct {
+	mu     sync.Mutex
+	events []postgres.TxEvent
+	errs   []error
+}
+
+func (o *recordingTxObserver) ObserveTx(_ context.Context, event postgres.TxEvent, _ time.Duration, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.events = append(o.events, event)
+	o.errs = append(o.errs, err)
+}
+
+func Test_SqlxTransaction_Observer(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+	lggr := logger.TestLogger(t)
+
+	t.Run("observes begin and commit on success", func(t *testing.T) {
+		o := &recordingTxObserver{}
+		err := postgres.SqlxTransaction(context.Background(), db, lggr, func(q postgres.Queryer) error {
+			_, err := q.Exec(`SELECT 1`)
+			return err
+		}, postgres.TxOptions{Observer: o})
+		require.NoError(t, err)
+		assert.Equal(t, []postgres.TxEvent{postgres.TxBegin, postgres.TxCommit}, o.events)
+		assert.Equal(t, []error{nil, nil}, o.errs)
+	})
+
+	t.Run("observes begin and rollback on callback error", func(t *testing.T) {
+		o := &recordingTxObserver{}
+		callbackErr := errors.New("callback failed")
+		err := postgres.SqlxTransaction(context.Background(), db, lggr, func(q postgres.Queryer) error {
+			return callbackErr
+		}, postgres.TxOptions{Observer: o})
+		require.Equal(t, callbackErr, err)
+		assert.Equal(t, []postgres.TxEvent{postgres.TxBegin, postgres.TxRollback}, o.events)
+		require.Len(t, o.errs, 2)
+		assert.True(t, errors.Is(o.errs[1], callbackErr))
+	})
+
+	t.Run("observes begin and rollback on panic", func(t *testing.T) {
+		o := &recordingTxObserver{}
+		require.Panics(t, func() {
+			_ = postgres.SqlxTransaction(context.Background(), db, lggr, func(q postgres.Queryer) error {
+				panic("boom")
+			}, postgres.TxOptions{Observer: o})
+		})
+		assert.Equal(t, []postgres.TxEvent{postgres.TxBegin, postgres.TxRollback}, o.events)
+	})
+}
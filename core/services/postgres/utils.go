@@ -5,6 +5,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jackc/pgconn"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 )
 
@@ -21,9 +23,95 @@ func DefaultQueryCtxWithParent(ctx context.Context) (context.Context, context.Ca
 	return context.WithTimeout(ctx, DefaultQueryTimeout)
 }
 
+const (
+	pgErrNotNullViolation     = "23502"
+	pgErrForeignKeyViolation  = "23503"
+	pgErrUniqueViolation      = "23505"
+	pgErrSerializationFailure = "40001"
+	pgErrDeadlockDetected     = "40P01"
+	pgErrActiveSQLTransaction = "25001"
+)
+
+// PGErrorClass is the kind of a Postgres error, as returned by ClassifyError
+type PGErrorClass int
+
+const (
+	// Other is any error not in one of the classes below, including errors
+	// that did not come from Postgres
+	Other PGErrorClass = iota
+	UniqueViolation
+	ForeignKeyViolation
+	NotNullViolation
+	SerializationFailure
+	Deadlock
+)
+
+func (c PGErrorClass) String() string {
+	switch c {
+	case UniqueViolation:
+		return "UniqueViolation"
+	case ForeignKeyViolation:
+		return "ForeignKeyViolation"
+	case NotNullViolation:
+		return "NotNullViolation"
+	case SerializationFailure:
+		return "SerializationFailure"
+	case Deadlock:
+		return "Deadlock"
+	default:
+		return "Other"
+	}
+}
+
+var pgErrorClasses = map[string]PGErrorClass{
+	pgErrUniqueViolation:      UniqueViolation,
+	pgErrForeignKeyViolation:  ForeignKeyViolation,
+	pgErrNotNullViolation:     NotNullViolation,
+	pgErrSerializationFailure: SerializationFailure,
+	pgErrDeadlockDetected:     Deadlock,
+}
+
+// ClassifyError returns the class of the Postgres error wrapped by err, from
+// either the pgx or the pq driver, so that callers can react to e.g. a unique
+// constraint violation without matching on error strings.
+func ClassifyError(err error) PGErrorClass {
+	if class, ok := pgErrorClasses[pgErrorCode(err)]; ok {
+		return class
+	}
+	return Other
+}
+
+// IsRetryableTxError returns true if err is a Postgres serialization failure
+// or deadlock, either of which may succeed if the transaction is run again.
+func IsRetryableTxError(err error) bool {
+	return IsSerializationAnomaly(err) || ClassifyError(err) == Deadlock
+}
+
+// IsActiveTransactionError returns true if err is Postgres refusing to run a
+// statement, such as VACUUM, inside a transaction block.
+func IsActiveTransactionError(err error) bool {
+	return pgErrorCode(err) == pgErrActiveSQLTransaction
+}
+
+func pgErrorCode(err error) string {
+	var pgErr *pgconn.PgError
+	var pqErr *pq.Error
+	if errors.As(err, &pgErr) {
+		return pgErr.Code
+	} else if errors.As(err, &pqErr) {
+		return string(pqErr.Code)
+	}
+	return ""
+}
+
+// IsSerializationAnomaly returns true if err is a Postgres serialization
+// failure
 func IsSerializationAnomaly(err error) bool {
 	if err == nil {
 		return false
 	}
+	if ClassifyError(err) == SerializationFailure {
+		return true
+	}
 	return strings.Contains(errors.Cause(err).Error(), "could not serialize access due to concurrent update")
 }
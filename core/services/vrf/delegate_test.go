@@ -71,13 +71,14 @@ func buildVrfUni(t *testing.T, db *sqlx.DB, cfg *configtest.TestGeneralConfig) v
 	// Don't mock db interactions
 	prm := pipeline.NewORM(db, lggr)
 	txm := new(bptxmmocks.TxManager)
-	ks := keystore.New(db, utils.FastScryptParams, lggr)
+	ks, err := keystore.New(db, utils.FastScryptParams, lggr)
+	require.NoError(t, err)
 	cc := evmtest.NewChainSet(t, evmtest.TestChainOpts{LogBroadcaster: lb, KeyStore: ks.Eth(), Client: ec, DB: db, GeneralConfig: cfg, TxManager: txm})
 	jrm := job.NewORM(db, cc, prm, ks, lggr)
 	t.Cleanup(func() { jrm.Close() })
 	pr := pipeline.NewRunner(prm, cfg, cc, ks.Eth(), ks.VRF(), lggr)
 	require.NoError(t, ks.Unlock("p4SsW0rD1!@#_"))
-	_, err := ks.Eth().Create(big.NewInt(0))
+	_, err = ks.Eth().Create(big.NewInt(0))
 	require.NoError(t, err)
 	submitter, err := ks.Eth().GetRoundRobinAddress()
 	require.NoError(t, err)
@@ -178,8 +179,9 @@ func TestStartingCounts(t *testing.T) {
 	lggr := logger.TestLogger(t)
 	counts := getStartingResponseCounts(db, lggr)
 	assert.Equal(t, 0, len(counts))
-	ks := keystore.New(db, utils.FastScryptParams, lggr)
-	err := ks.Unlock("p4SsW0rD1!@#_")
+	ks, err := keystore.New(db, utils.FastScryptParams, lggr)
+	require.NoError(t, err)
+	err = ks.Unlock("p4SsW0rD1!@#_")
 	require.NoError(t, err)
 	k, err := ks.Eth().Create(big.NewInt(0))
 	require.NoError(t, err)
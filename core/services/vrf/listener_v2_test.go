@@ -64,7 +64,8 @@ func addConfirmedEthTx(t *testing.T, db *sqlx.DB, from common.Address, maxLink s
 func TestMaybeSubtractReservedLink(t *testing.T) {
 	db := pgtest.NewSqlxDB(t)
 	lggr := logger.TestLogger(t)
-	ks := keystore.New(db, utils.FastScryptParams, lggr)
+	ks, err := keystore.New(db, utils.FastScryptParams, lggr)
+	require.NoError(t, err)
 	require.NoError(t, ks.Unlock("blah"))
 	k, err := ks.Eth().Create(big.NewInt(1337))
 	require.NoError(t, err)
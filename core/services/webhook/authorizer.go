@@ -3,7 +3,10 @@ package webhook
 import (
 	"context"
 	"database/sql"
+	"sync"
+	"time"
 
+	"github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
 	"github.com/smartcontractkit/chainlink/core/bridges"
 	"github.com/smartcontractkit/chainlink/core/sessions"
@@ -15,6 +18,29 @@ type AuthorizerConfig interface {
 
 type Authorizer interface {
 	CanRun(ctx context.Context, config AuthorizerConfig, jobUUID uuid.UUID) (bool, error)
+	// CanRunWithReason is like CanRun, but also reports why the run was or
+	// was not authorized
+	CanRunWithReason(ctx context.Context, config AuthorizerConfig, jobUUID uuid.UUID) (bool, DenyReason, error)
+}
+
+// DenyReason is the reason for an authorization decision
+type DenyReason string
+
+const (
+	// Allowed means the run was authorized
+	Allowed DenyReason = "allowed"
+	// FeatureDisabled means external initiators are disabled on this node
+	FeatureDisabled DenyReason = "feature_disabled"
+	// NotLinked means the external initiator is not linked to the job, or
+	// the job does not exist
+	NotLinked DenyReason = "not_linked"
+	// NoUserOrEI means the request was made by neither a user nor an external
+	// initiator
+	NoUserOrEI DenyReason = "no_user_or_ei"
+)
+
+func (r DenyReason) String() string {
+	return string(r)
 }
 
 var (
@@ -23,51 +49,244 @@ var (
 	_ Authorizer = &neverAuthorizer{}
 )
 
+// authorizationCacheTTL is how long the authorizers returned by NewAuthorizer
+// remember a decision
+const authorizationCacheTTL = 10 * time.Second
+
+// sharedAuthorizationCache is shared by the authorizers returned by
+// NewAuthorizer, and invalidated by the ORM as links change
+var sharedAuthorizationCache = NewAuthorizationCache(authorizationCacheTTL)
+
 func NewAuthorizer(db *sql.DB, user *sessions.User, ei *bridges.ExternalInitiator) Authorizer {
 	if user != nil {
 		return &alwaysAuthorizer{}
 	} else if ei != nil {
-		return NewEIAuthorizer(db, *ei)
+		return NewCachingEIAuthorizer(db, *ei, sharedAuthorizationCache)
 	}
 	return &neverAuthorizer{}
 }
 
+// QueryRower is satisfied by *sql.DB
+type QueryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Querier is satisfied by *sql.DB
+type Querier interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
 type eiAuthorizer struct {
-	db *sql.DB
-	ei bridges.ExternalInitiator
+	db    QueryRower
+	ei    bridges.ExternalInitiator
+	cache *AuthorizationCache
+}
+
+func NewEIAuthorizer(db QueryRower, ei bridges.ExternalInitiator) *eiAuthorizer {
+	return &eiAuthorizer{db, ei, nil}
+}
+
+// NewCachingEIAuthorizer is like NewEIAuthorizer, but remembers its decisions
+// in cache, which should be shared by the authorizers of all requests.
+func NewCachingEIAuthorizer(db QueryRower, ei bridges.ExternalInitiator, cache *AuthorizationCache) *eiAuthorizer {
+	return &eiAuthorizer{db, ei, cache}
 }
 
-func NewEIAuthorizer(db *sql.DB, ei bridges.ExternalInitiator) *eiAuthorizer {
-	return &eiAuthorizer{db, ei}
+func (ea *eiAuthorizer) CanRun(ctx context.Context, config AuthorizerConfig, jobUUID uuid.UUID) (bool, error) {
+	can, _, err := ea.CanRunWithReason(ctx, config, jobUUID)
+	return can, err
 }
 
-func (ea *eiAuthorizer) CanRun(ctx context.Context, config AuthorizerConfig, jobUUID uuid.UUID) (can bool, err error) {
+func (ea *eiAuthorizer) CanRunWithReason(ctx context.Context, config AuthorizerConfig, jobUUID uuid.UUID) (bool, DenyReason, error) {
 	if !config.FeatureExternalInitiators() {
+		return false, FeatureDisabled, nil
+	}
+	can, err := ea.isLinked(ctx, jobUUID)
+	if err != nil {
+		return false, "", err
+	}
+	if !can {
+		return false, NotLinked, nil
+	}
+	return true, Allowed, nil
+}
+
+func (ea *eiAuthorizer) isLinked(ctx context.Context, jobUUID uuid.UUID) (can bool, err error) {
+	key := authorizationKey{ea.ei.ID, jobUUID}
+	if can, ok := ea.cache.get(key); ok {
+		return can, nil
+	}
+	webhookSpecID, err := WebhookSpecIDForJob(ctx, ea.db, jobUUID)
+	if errors.Is(err, sql.ErrNoRows) {
+		ea.cache.set(key, false)
 		return false, nil
+	} else if err != nil {
+		return false, err
 	}
 	row := ea.db.QueryRowContext(ctx, `
 SELECT EXISTS (
 	SELECT 1 FROM external_initiator_webhook_specs
-	JOIN jobs ON external_initiator_webhook_specs.webhook_spec_id = jobs.webhook_spec_id
-	AND jobs.external_job_id = $1
-	AND external_initiator_webhook_specs.external_initiator_id = $2
-)`, jobUUID, ea.ei.ID)
+	WHERE webhook_spec_id = $1 AND external_initiator_id = $2
+)`, webhookSpecID, ea.ei.ID)
 
 	err = row.Scan(&can)
 	if err != nil {
 		return false, err
 	}
+	ea.cache.set(key, can)
 	return can, nil
 }
 
+type authorizationKey struct {
+	eiID    int64
+	jobUUID uuid.UUID
+}
+
+type authorizationEntry struct {
+	can       bool
+	expiresAt time.Time
+}
+
+// AuthorizationCache holds EI authorization decisions for a short time, so
+// that frequent webhook runs by the same EI do not each query the database.
+// A nil *AuthorizationCache caches nothing.
+type AuthorizationCache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[authorizationKey]authorizationEntry
+}
+
+// NewAuthorizationCache returns a cache whose decisions expire after ttl
+func NewAuthorizationCache(ttl time.Duration) *AuthorizationCache {
+	return &AuthorizationCache{ttl: ttl, entries: make(map[authorizationKey]authorizationEntry)}
+}
+
+func (c *AuthorizationCache) get(key authorizationKey) (can bool, ok bool) {
+	if c == nil {
+		return false, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return false, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		return false, false
+	}
+	return entry.can, true
+}
+
+func (c *AuthorizationCache) set(key authorizationKey, can bool) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	// Drop expired entries so pairs that are not looked up again do not pile up
+	for k, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = authorizationEntry{can, now.Add(c.ttl)}
+}
+
+// invalidateEI forgets all decisions made for the external initiator
+func (c *AuthorizationCache) invalidateEI(eiID int64) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k := range c.entries {
+		if k.eiID == eiID {
+			delete(c.entries, k)
+		}
+	}
+}
+
+// CanRunForEIName is like the CanRun of an EI authorizer, but looks up the
+// external initiator by name. Unknown names are not authorized.
+func CanRunForEIName(ctx context.Context, db QueryRower, config AuthorizerConfig, jobUUID uuid.UUID, eiName string) (can bool, err error) {
+	if !config.FeatureExternalInitiators() {
+		return false, nil
+	}
+	webhookSpecID, err := WebhookSpecIDForJob(ctx, db, jobUUID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	row := db.QueryRowContext(ctx, `
+SELECT EXISTS (
+	SELECT 1 FROM external_initiator_webhook_specs
+	JOIN external_initiators ON external_initiator_webhook_specs.external_initiator_id = external_initiators.id
+	WHERE external_initiator_webhook_specs.webhook_spec_id = $1
+	AND external_initiators.name = $2
+)`, webhookSpecID, eiName)
+
+	err = row.Scan(&can)
+	if err != nil {
+		return false, err
+	}
+	return can, nil
+}
+
+// WebhookSpecIDForJob returns the ID of the webhook spec of the job with the
+// given external job ID. It returns a wrapped sql.ErrNoRows if there is no such
+// job, or the job is not a webhook job.
+func WebhookSpecIDForJob(ctx context.Context, db QueryRower, externalJobID uuid.UUID) (webhookSpecID int32, err error) {
+	row := db.QueryRowContext(ctx, `SELECT webhook_spec_id FROM jobs WHERE external_job_id = $1 AND webhook_spec_id IS NOT NULL`, externalJobID)
+	if err = row.Scan(&webhookSpecID); err != nil {
+		return 0, errors.Wrapf(err, "failed to find webhook spec for job %s", externalJobID)
+	}
+	return webhookSpecID, nil
+}
+
+// AuthorizedJobsForEI returns the external job IDs of the webhook jobs that
+// the external initiator is linked to, and so may run, ordered by job ID. An
+// external initiator linked to no jobs gets an empty slice.
+func AuthorizedJobsForEI(ctx context.Context, db Querier, eiID int64) (jobUUIDs []uuid.UUID, err error) {
+	rows, err := db.QueryContext(ctx, `
+SELECT jobs.external_job_id FROM external_initiator_webhook_specs
+JOIN jobs ON external_initiator_webhook_specs.webhook_spec_id = jobs.webhook_spec_id
+WHERE external_initiator_webhook_specs.external_initiator_id = $1
+ORDER BY jobs.id`, eiID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobUUIDs = []uuid.UUID{}
+	for rows.Next() {
+		var jobUUID uuid.UUID
+		if err = rows.Scan(&jobUUID); err != nil {
+			return nil, err
+		}
+		jobUUIDs = append(jobUUIDs, jobUUID)
+	}
+	return jobUUIDs, rows.Err()
+}
+
 type alwaysAuthorizer struct{}
 
 func (*alwaysAuthorizer) CanRun(context.Context, AuthorizerConfig, uuid.UUID) (bool, error) {
 	return true, nil
 }
 
+func (*alwaysAuthorizer) CanRunWithReason(context.Context, AuthorizerConfig, uuid.UUID) (bool, DenyReason, error) {
+	return true, Allowed, nil
+}
+
 type neverAuthorizer struct{}
 
 func (*neverAuthorizer) CanRun(context.Context, AuthorizerConfig, uuid.UUID) (bool, error) {
 	return false, nil
 }
+
+func (*neverAuthorizer) CanRunWithReason(context.Context, AuthorizerConfig, uuid.UUID) (bool, DenyReason, error) {
+	return false, NoUserOrEI, nil
+}
@@ -2,7 +2,10 @@ package webhook_test
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/smartcontractkit/chainlink/core/bridges"
 	"github.com/smartcontractkit/chainlink/core/logger"
@@ -18,7 +21,7 @@ import (
 )
 
 func newBridgeORM(t *testing.T, db *sqlx.DB) bridges.ORM {
-	return bridges.NewORM(db, logger.TestLogger(t))
+	return bridges.NewORM(db, nil, logger.TestLogger(t))
 }
 
 type eiEnabledCfg struct{}
@@ -29,6 +32,17 @@ type eiDisabledCfg struct{}
 
 func (eiDisabledCfg) FeatureExternalInitiators() bool { return false }
 
+// countingDB counts the queries made through it
+type countingDB struct {
+	*sql.DB
+	queries int
+}
+
+func (db *countingDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	db.queries++
+	return db.DB.QueryRowContext(ctx, query, args...)
+}
+
 func Test_Authorizer(t *testing.T) {
 	db := pgtest.NewSqlxDB(t)
 	borm := newBridgeORM(t, db)
@@ -95,3 +109,209 @@ func Test_Authorizer(t *testing.T) {
 		assert.False(t, can)
 	})
 }
+
+func Test_Authorizer_CanRunWithReason(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	borm := newBridgeORM(t, db)
+
+	eiFoo := cltest.MustInsertExternalInitiator(t, borm)
+	eiBar := cltest.MustInsertExternalInitiator(t, borm)
+
+	jobWithFooEI, webhookSpecWithFooEI := cltest.MustInsertWebhookSpec(t, db)
+	_, err := db.Exec(`INSERT INTO external_initiator_webhook_specs (external_initiator_id, webhook_spec_id, spec) VALUES ($1,$2,$3)`, eiFoo.ID, webhookSpecWithFooEI.ID, `{"ei": "foo", "name": "webhookSpecWithFooEI"}`)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		authorizer webhook.Authorizer
+		cfg        webhook.AuthorizerConfig
+		jobUUID    uuid.UUID
+		can        bool
+		reason     webhook.DenyReason
+	}{
+		{"user", webhook.NewAuthorizer(db.DB, &sessions.User{}, nil), nil, jobWithFooEI.ExternalJobID, true, webhook.Allowed},
+		{"no user no ei", webhook.NewAuthorizer(db.DB, nil, nil), nil, jobWithFooEI.ExternalJobID, false, webhook.NoUserOrEI},
+		{"linked ei", webhook.NewAuthorizer(db.DB, nil, &eiFoo), eiEnabledCfg{}, jobWithFooEI.ExternalJobID, true, webhook.Allowed},
+		{"feature disabled", webhook.NewAuthorizer(db.DB, nil, &eiFoo), eiDisabledCfg{}, jobWithFooEI.ExternalJobID, false, webhook.FeatureDisabled},
+		{"ei not linked", webhook.NewAuthorizer(db.DB, nil, &eiBar), eiEnabledCfg{}, jobWithFooEI.ExternalJobID, false, webhook.NotLinked},
+		{"job does not exist", webhook.NewAuthorizer(db.DB, nil, &eiFoo), eiEnabledCfg{}, uuid.NewV4(), false, webhook.NotLinked},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			can, reason, err := test.authorizer.CanRunWithReason(context.Background(), test.cfg, test.jobUUID)
+			require.NoError(t, err)
+			assert.Equal(t, test.can, can)
+			assert.Equal(t, test.reason, reason)
+
+			can, err = test.authorizer.CanRun(context.Background(), test.cfg, test.jobUUID)
+			require.NoError(t, err)
+			assert.Equal(t, test.can, can)
+		})
+	}
+}
+
+func Test_CanRunForEIName(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	borm := newBridgeORM(t, db)
+
+	eiFoo := cltest.MustInsertExternalInitiator(t, borm)
+	eiBar := cltest.MustInsertExternalInitiator(t, borm)
+
+	jobWithFooAndBarEI, webhookSpecWithFooAndBarEI := cltest.MustInsertWebhookSpec(t, db)
+	jobWithBarEI, webhookSpecWithBarEI := cltest.MustInsertWebhookSpec(t, db)
+	jobWithNoEI, _ := cltest.MustInsertWebhookSpec(t, db)
+
+	_, err := db.Exec(`INSERT INTO external_initiator_webhook_specs (external_initiator_id, webhook_spec_id, spec) VALUES ($1,$2,$3)`, eiFoo.ID, webhookSpecWithFooAndBarEI.ID, `{"ei": "foo", "name": "webhookSpecWithFooAndBarEI"}`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO external_initiator_webhook_specs (external_initiator_id, webhook_spec_id, spec) VALUES ($1,$2,$3)`, eiBar.ID, webhookSpecWithFooAndBarEI.ID, `{"ei": "bar", "name": "webhookSpecWithFooAndBarEI"}`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO external_initiator_webhook_specs (external_initiator_id, webhook_spec_id, spec) VALUES ($1,$2,$3)`, eiBar.ID, webhookSpecWithBarEI.ID, `{"ei": "bar", "name": "webhookSpecTwoEIs"}`)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	can, err := webhook.CanRunForEIName(ctx, db.DB, eiEnabledCfg{}, jobWithFooAndBarEI.ExternalJobID, eiFoo.Name)
+	require.NoError(t, err)
+	assert.True(t, can)
+	can, err = webhook.CanRunForEIName(ctx, db.DB, eiDisabledCfg{}, jobWithFooAndBarEI.ExternalJobID, eiFoo.Name)
+	require.NoError(t, err)
+	assert.False(t, can)
+	can, err = webhook.CanRunForEIName(ctx, db.DB, eiEnabledCfg{}, jobWithBarEI.ExternalJobID, eiFoo.Name)
+	require.NoError(t, err)
+	assert.False(t, can)
+	can, err = webhook.CanRunForEIName(ctx, db.DB, eiEnabledCfg{}, jobWithBarEI.ExternalJobID, eiBar.Name)
+	require.NoError(t, err)
+	assert.True(t, can)
+	can, err = webhook.CanRunForEIName(ctx, db.DB, eiEnabledCfg{}, jobWithNoEI.ExternalJobID, eiFoo.Name)
+	require.NoError(t, err)
+	assert.False(t, can)
+	can, err = webhook.CanRunForEIName(ctx, db.DB, eiEnabledCfg{}, uuid.NewV4(), eiFoo.Name)
+	require.NoError(t, err)
+	assert.False(t, can)
+	can, err = webhook.CanRunForEIName(ctx, db.DB, eiEnabledCfg{}, jobWithFooAndBarEI.ExternalJobID, "unknown-ei")
+	require.NoError(t, err)
+	assert.False(t, can)
+}
+
+func Test_AuthorizedJobsForEI(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	borm := newBridgeORM(t, db)
+
+	eiFoo := cltest.MustInsertExternalInitiator(t, borm)
+	eiBar := cltest.MustInsertExternalInitiator(t, borm)
+	eiBaz := cltest.MustInsertExternalInitiator(t, borm)
+
+	jobWithFooAndBarEI, webhookSpecWithFooAndBarEI := cltest.MustInsertWebhookSpec(t, db)
+	jobWithBarEI, webhookSpecWithBarEI := cltest.MustInsertWebhookSpec(t, db)
+	cltest.MustInsertWebhookSpec(t, db)
+
+	_, err := db.Exec(`INSERT INTO external_initiator_webhook_specs (external_initiator_id, webhook_spec_id, spec) VALUES ($1,$2,$3)`, eiFoo.ID, webhookSpecWithFooAndBarEI.ID, `{"ei": "foo", "name": "webhookSpecWithFooAndBarEI"}`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO external_initiator_webhook_specs (external_initiator_id, webhook_spec_id, spec) VALUES ($1,$2,$3)`, eiBar.ID, webhookSpecWithFooAndBarEI.ID, `{"ei": "bar", "name": "webhookSpecWithFooAndBarEI"}`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO external_initiator_webhook_specs (external_initiator_id, webhook_spec_id, spec) VALUES ($1,$2,$3)`, eiBar.ID, webhookSpecWithBarEI.ID, `{"ei": "bar", "name": "webhookSpecTwoEIs"}`)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	jobUUIDs, err := webhook.AuthorizedJobsForEI(ctx, db.DB, eiFoo.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{jobWithFooAndBarEI.ExternalJobID}, jobUUIDs)
+
+	jobUUIDs, err = webhook.AuthorizedJobsForEI(ctx, db.DB, eiBar.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{jobWithFooAndBarEI.ExternalJobID, jobWithBarEI.ExternalJobID}, jobUUIDs)
+
+	jobUUIDs, err = webhook.AuthorizedJobsForEI(ctx, db.DB, eiBaz.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, jobUUIDs)
+	assert.Empty(t, jobUUIDs)
+}
+
+func Test_WebhookSpecIDForJob(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+
+	jb, webhookSpec := cltest.MustInsertWebhookSpec(t, db)
+
+	ctx := context.Background()
+
+	webhookSpecID, err := webhook.WebhookSpecIDForJob(ctx, db.DB, jb.ExternalJobID)
+	require.NoError(t, err)
+	assert.Equal(t, webhookSpec.ID, webhookSpecID)
+
+	_, err = webhook.WebhookSpecIDForJob(ctx, db.DB, uuid.NewV4())
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, sql.ErrNoRows))
+}
+
+func Test_CachingEIAuthorizer(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	borm := newBridgeORM(t, db)
+
+	eiFoo := cltest.MustInsertExternalInitiator(t, borm)
+	eiBar := cltest.MustInsertExternalInitiator(t, borm)
+
+	jobWithFooEI, webhookSpecWithFooEI := cltest.MustInsertWebhookSpec(t, db)
+	_, err := db.Exec(`INSERT INTO external_initiator_webhook_specs (external_initiator_id, webhook_spec_id, spec) VALUES ($1,$2,$3)`, eiFoo.ID, webhookSpecWithFooEI.ID, `{"ei": "foo", "name": "webhookSpecWithFooEI"}`)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	t.Run("a cache hit avoids the database", func(t *testing.T) {
+		cdb := &countingDB{DB: db.DB}
+		cache := webhook.NewAuthorizationCache(time.Hour)
+
+		for i := 0; i < 3; i++ {
+			// A new authorizer per request, sharing the cache
+			can, err := webhook.NewCachingEIAuthorizer(cdb, eiFoo, cache).CanRun(ctx, eiEnabledCfg{}, jobWithFooEI.ExternalJobID)
+			require.NoError(t, err)
+			assert.True(t, can)
+		}
+		// A miss looks up the webhook spec, then the link
+		assert.Equal(t, 2, cdb.queries)
+
+		// Denials are cached too, separately for each EI
+		for i := 0; i < 3; i++ {
+			can, err := webhook.NewCachingEIAuthorizer(cdb, eiBar, cache).CanRun(ctx, eiEnabledCfg{}, jobWithFooEI.ExternalJobID)
+			require.NoError(t, err)
+			assert.False(t, can)
+		}
+		assert.Equal(t, 4, cdb.queries)
+
+		// The feature flag is always respected
+		can, err := webhook.NewCachingEIAuthorizer(cdb, eiFoo, cache).CanRun(ctx, eiDisabledCfg{}, jobWithFooEI.ExternalJobID)
+		require.NoError(t, err)
+		assert.False(t, can)
+		assert.Equal(t, 4, cdb.queries)
+	})
+
+	t.Run("expiry forces a refresh", func(t *testing.T) {
+		cdb := &countingDB{DB: db.DB}
+		cache := webhook.NewAuthorizationCache(time.Millisecond)
+		a := webhook.NewCachingEIAuthorizer(cdb, eiFoo, cache)
+
+		can, err := a.CanRun(ctx, eiEnabledCfg{}, jobWithFooEI.ExternalJobID)
+		require.NoError(t, err)
+		assert.True(t, can)
+		assert.Equal(t, 2, cdb.queries)
+
+		time.Sleep(10 * time.Millisecond)
+
+		can, err = a.CanRun(ctx, eiEnabledCfg{}, jobWithFooEI.ExternalJobID)
+		require.NoError(t, err)
+		assert.True(t, can)
+		assert.Equal(t, 4, cdb.queries)
+	})
+
+	t.Run("without a cache every call queries the database", func(t *testing.T) {
+		cdb := &countingDB{DB: db.DB}
+		a := webhook.NewEIAuthorizer(cdb, eiFoo)
+
+		for i := 0; i < 2; i++ {
+			_, err := a.CanRun(ctx, eiEnabledCfg{}, jobWithFooEI.ExternalJobID)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 4, cdb.queries)
+	})
+}
@@ -0,0 +1,41 @@
+// Code generated by mockery v2.8.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	webhook "github.com/smartcontractkit/chainlink/core/services/webhook"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ORM is an autogenerated mock type for the ORM type
+type ORM struct {
+	mock.Mock
+}
+
+// LinkExternalInitiators provides a mock function with given fields: webhookSpecID, links
+func (_m *ORM) LinkExternalInitiators(webhookSpecID int32, links []webhook.EILink) error {
+	ret := _m.Called(webhookSpecID, links)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int32, []webhook.EILink) error); ok {
+		r0 = rf(webhookSpecID, links)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnlinkExternalInitiator provides a mock function with given fields: webhookSpecID, eiID
+func (_m *ORM) UnlinkExternalInitiator(webhookSpecID int32, eiID int64) error {
+	ret := _m.Called(webhookSpecID, eiID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int32, int64) error); ok {
+		r0 = rf(webhookSpecID, eiID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"database/sql"
+
+	"github.com/jackc/pgconn"
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/sqlx"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/postgres"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+// ErrEIAlreadyLinked is returned when linking an external initiator that is
+// already linked to the webhook spec
+var ErrEIAlreadyLinked = errors.New("external initiator is already linked to the webhook spec")
+
+//go:generate mockery --name ORM --output ./mocks/ --case=underscore
+
+// ORM manages the links between webhook specs and the external initiators
+// allowed to run them
+type ORM interface {
+	LinkExternalInitiators(webhookSpecID int32, links []EILink) error
+	UnlinkExternalInitiator(webhookSpecID int32, eiID int64) error
+}
+
+// EILink links an external initiator to a webhook spec, with the spec that is
+// sent to the external initiator
+type EILink struct {
+	ExternalInitiatorID int64
+	Spec                models.JSON
+}
+
+type orm struct {
+	db    *sqlx.DB
+	cache *AuthorizationCache
+	lggr  logger.Logger
+}
+
+var _ ORM = (*orm)(nil)
+
+// NewORM returns an ORM that invalidates the decisions cached by the
+// authorizers of NewAuthorizer whenever it changes a link.
+func NewORM(db *sqlx.DB, lggr logger.Logger) ORM {
+	return &orm{db, sharedAuthorizationCache, lggr.Named("WebhookORM")}
+}
+
+// LinkExternalInitiators links all the given external initiators to the
+// webhook spec in a single transaction. If any of them is already linked,
+// none are linked and ErrEIAlreadyLinked is returned.
+func (o *orm) LinkExternalInitiators(webhookSpecID int32, links []EILink) error {
+	err := postgres.NewQ(o.db).Transaction(o.lggr, func(tx postgres.Queryer) error {
+		for _, link := range links {
+			_, err := tx.Exec(`INSERT INTO external_initiator_webhook_specs (external_initiator_id, webhook_spec_id, spec) VALUES ($1, $2, $3)`,
+				link.ExternalInitiatorID, webhookSpecID, link.Spec)
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.ConstraintName == "external_initiator_webhook_specs_pkey" {
+				return errors.Wrapf(ErrEIAlreadyLinked, "external initiator %d, webhook spec %d", link.ExternalInitiatorID, webhookSpecID)
+			}
+			if err != nil {
+				return errors.Wrapf(err, "failed to link external initiator %d", link.ExternalInitiatorID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "LinkExternalInitiators failed")
+	}
+	// Cached denials for the newly linked EIs are now stale
+	for _, link := range links {
+		o.cache.invalidateEI(link.ExternalInitiatorID)
+	}
+	return nil
+}
+
+// UnlinkExternalInitiator removes the link between the external initiator and
+// the webhook spec, returning sql.ErrNoRows if they were not linked.
+func (o *orm) UnlinkExternalInitiator(webhookSpecID int32, eiID int64) error {
+	result, err := postgres.NewQ(o.db).Exec(`DELETE FROM external_initiator_webhook_specs WHERE webhook_spec_id = $1 AND external_initiator_id = $2`, webhookSpecID, eiID)
+	if err != nil {
+		return errors.Wrap(err, "UnlinkExternalInitiator failed")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	o.cache.invalidateEI(eiID)
+	return nil
+}
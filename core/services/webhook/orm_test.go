@@ -0,0 +1,94 @@
+package webhook_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/bridges"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest/heavyweight"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/webhook"
+)
+
+func Test_ORM_LinkExternalInitiators(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	borm := newBridgeORM(t, db)
+	orm := webhook.NewORM(db, logger.TestLogger(t))
+
+	eiFoo := cltest.MustInsertExternalInitiator(t, borm)
+	eiBar := cltest.MustInsertExternalInitiator(t, borm)
+	eiBaz := cltest.MustInsertExternalInitiator(t, borm)
+
+	jb, webhookSpec := cltest.MustInsertWebhookSpec(t, db)
+
+	// Authorizers from NewAuthorizer cache their decisions, so these also check
+	// that the ORM invalidates them
+	canRun := func(ei bridges.ExternalInitiator) bool {
+		can, err := webhook.NewAuthorizer(db.DB, nil, &ei).CanRun(context.Background(), eiEnabledCfg{}, jb.ExternalJobID)
+		require.NoError(t, err)
+		return can
+	}
+
+	t.Run("links several external initiators", func(t *testing.T) {
+		require.NoError(t, orm.LinkExternalInitiators(webhookSpec.ID, []webhook.EILink{
+			{ExternalInitiatorID: eiFoo.ID, Spec: cltest.JSONFromString(t, `{}`)},
+			{ExternalInitiatorID: eiBar.ID, Spec: cltest.JSONFromString(t, `{"foo": 42}`)},
+		}))
+
+		assert.True(t, canRun(eiFoo))
+		assert.True(t, canRun(eiBar))
+		assert.False(t, canRun(eiBaz))
+	})
+
+	t.Run("unlinks an external initiator", func(t *testing.T) {
+		require.NoError(t, orm.UnlinkExternalInitiator(webhookSpec.ID, eiFoo.ID))
+
+		assert.False(t, canRun(eiFoo))
+		assert.True(t, canRun(eiBar))
+
+		err := orm.UnlinkExternalInitiator(webhookSpec.ID, eiFoo.ID)
+		assert.Equal(t, sql.ErrNoRows, err)
+	})
+
+	t.Run("links a previously denied external initiator", func(t *testing.T) {
+		require.NoError(t, orm.LinkExternalInitiators(webhookSpec.ID, []webhook.EILink{
+			{ExternalInitiatorID: eiBaz.ID, Spec: cltest.JSONFromString(t, `{}`)},
+		}))
+
+		assert.True(t, canRun(eiBaz))
+	})
+}
+
+func Test_ORM_LinkExternalInitiators_Duplicate(t *testing.T) {
+	// Needs a real database, since transactions are no-ops in pgtest.NewSqlxDB
+	_, db := heavyweight.FullTestDB(t, "webhook_link_external_initiators", true, false)
+	borm := newBridgeORM(t, db)
+	orm := webhook.NewORM(db, logger.TestLogger(t))
+
+	eiFoo := cltest.MustInsertExternalInitiator(t, borm)
+	eiBar := cltest.MustInsertExternalInitiator(t, borm)
+
+	jb, webhookSpec := cltest.MustInsertWebhookSpec(t, db)
+	require.NoError(t, orm.LinkExternalInitiators(webhookSpec.ID, []webhook.EILink{
+		{ExternalInitiatorID: eiFoo.ID, Spec: cltest.JSONFromString(t, `{}`)},
+	}))
+
+	err := orm.LinkExternalInitiators(webhookSpec.ID, []webhook.EILink{
+		{ExternalInitiatorID: eiBar.ID, Spec: cltest.JSONFromString(t, `{}`)},
+		{ExternalInitiatorID: eiFoo.ID, Spec: cltest.JSONFromString(t, `{}`)},
+	})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, webhook.ErrEIAlreadyLinked))
+
+	// None of the links were made
+	can, err := webhook.NewEIAuthorizer(db.DB, eiBar).CanRun(context.Background(), eiEnabledCfg{}, jb.ExternalJobID)
+	require.NoError(t, err)
+	assert.False(t, can)
+}
@@ -0,0 +1,69 @@
+package sessions
+
+import (
+	"crypto/subtle"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/auth"
+)
+
+// APIToken is an API token with limited permissions, letting external
+// automation use the API without the user's credentials.
+type APIToken struct {
+	ID           int64
+	Name         string
+	AccessKey    string
+	Salt         string
+	HashedSecret string
+	Scopes       pq.StringArray
+	CreatedAt    time.Time
+}
+
+// Scopes of API tokens. A scope is either AllResources or the name of a
+// resource group, which is the first segment of a path of the API after /v2,
+// e.g. "bridge_types" or "jobs". It grants both reads and writes, unless it
+// ends with ReadOnlySuffix.
+const (
+	AllResources   = "*"
+	ReadOnlySuffix = ":read"
+)
+
+var resourceGroupRegexp = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// ValidateScope returns an error if scope is not a valid API token scope.
+func ValidateScope(scope string) error {
+	group := strings.TrimSuffix(scope, ReadOnlySuffix)
+	if group != AllResources && !resourceGroupRegexp.MatchString(group) {
+		return errors.Errorf("invalid scope %q: must be %q or a resource group, optionally followed by %q", scope, AllResources, ReadOnlySuffix)
+	}
+	return nil
+}
+
+// Allows returns whether the token may access the resource group, with write
+// set for requests that modify it.
+func (t APIToken) Allows(group string, write bool) bool {
+	for _, scope := range t.Scopes {
+		scopeGroup := strings.TrimSuffix(scope, ReadOnlySuffix)
+		if scopeGroup != AllResources && scopeGroup != group {
+			continue
+		}
+		if !write || scopeGroup == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthenticateAPIToken returns true if token is the one hashed in apiToken.
+func AuthenticateAPIToken(token *auth.Token, apiToken APIToken) (bool, error) {
+	hashedSecret, err := auth.HashedSecret(token, apiToken.Salt)
+	if err != nil {
+		return false, err
+	}
+	return subtle.ConstantTimeCompare([]byte(hashedSecret), []byte(apiToken.HashedSecret)) == 1, nil
+}
@@ -0,0 +1,47 @@
+package sessions_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/smartcontractkit/chainlink/core/sessions"
+)
+
+func TestAPIToken_Allows(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		scopes []string
+		group  string
+		write  bool
+		want   bool
+	}{
+		{"all read", []string{"*:read"}, "bridge_types", false, true},
+		{"all read write", []string{"*:read"}, "bridge_types", true, false},
+		{"all", []string{"*"}, "jobs", true, true},
+		{"group read", []string{"bridge_types:read"}, "bridge_types", false, true},
+		{"group read write", []string{"bridge_types:read"}, "bridge_types", true, false},
+		{"group", []string{"bridge_types"}, "bridge_types", true, true},
+		{"other group", []string{"bridge_types"}, "jobs", false, false},
+		{"several", []string{"*:read", "bridge_types"}, "bridge_types", true, true},
+		{"several other group", []string{"*:read", "bridge_types"}, "jobs", true, false},
+		{"none", nil, "jobs", false, false},
+	}
+	for _, test := range tests {
+		token := sessions.APIToken{Scopes: test.scopes}
+		assert.Equal(t, test.want, token.Allows(test.group, test.write), test.name)
+	}
+}
+
+func TestValidateScope(t *testing.T) {
+	t.Parallel()
+
+	for _, scope := range []string{"*", "*:read", "bridge_types", "jobs:read"} {
+		assert.NoError(t, sessions.ValidateScope(scope), scope)
+	}
+	for _, scope := range []string{"", ":read", "bridges:write", "Jobs", "*:*", "jobs/runs"} {
+		assert.Error(t, sessions.ValidateScope(scope), scope)
+	}
+}
@@ -0,0 +1,8 @@
+package sessions
+
+import "github.com/smartcontractkit/chainlink/core/utils"
+
+// SetClock sets the clock the ORM creates and expires sessions by
+func SetClock(o ORM, clock utils.Nower) {
+	o.(*orm).clock = clock
+}
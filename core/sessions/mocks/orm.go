@@ -16,6 +16,29 @@ type ORM struct {
 	mock.Mock
 }
 
+// APITokens provides a mock function with given fields:
+func (_m *ORM) APITokens() ([]sessions.APIToken, error) {
+	ret := _m.Called()
+
+	var r0 []sessions.APIToken
+	if rf, ok := ret.Get(0).(func() []sessions.APIToken); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sessions.APIToken)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AuthorizedUserWithSession provides a mock function with given fields: sessionID
 func (_m *ORM) AuthorizedUserWithSession(sessionID string) (sessions.User, error) {
 	ret := _m.Called(sessionID)
@@ -51,6 +74,50 @@ func (_m *ORM) ClearNonCurrentSessions(sessionID string) error {
 	return r0
 }
 
+// ConfirmTOTP provides a mock function with given fields: user, code
+func (_m *ORM) ConfirmTOTP(user *sessions.User, code string) error {
+	ret := _m.Called(user, code)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*sessions.User, string) error); ok {
+		r0 = rf(user, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateAPIToken provides a mock function with given fields: name, scopes
+func (_m *ORM) CreateAPIToken(name string, scopes []string) (*auth.Token, sessions.APIToken, error) {
+	ret := _m.Called(name, scopes)
+
+	var r0 *auth.Token
+	if rf, ok := ret.Get(0).(func(string, []string) *auth.Token); ok {
+		r0 = rf(name, scopes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*auth.Token)
+		}
+	}
+
+	var r1 sessions.APIToken
+	if rf, ok := ret.Get(1).(func(string, []string) sessions.APIToken); ok {
+		r1 = rf(name, scopes)
+	} else {
+		r1 = ret.Get(1).(sessions.APIToken)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, []string) error); ok {
+		r2 = rf(name, scopes)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // CreateSession provides a mock function with given fields: sr
 func (_m *ORM) CreateSession(sr sessions.SessionRequest) (string, error) {
 	ret := _m.Called(sr)
@@ -86,6 +153,20 @@ func (_m *ORM) CreateUser(user *sessions.User) error {
 	return r0
 }
 
+// DeleteAPIToken provides a mock function with given fields: id
+func (_m *ORM) DeleteAPIToken(id int64) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteAuthToken provides a mock function with given fields: user
 func (_m *ORM) DeleteAuthToken(user *sessions.User) error {
 	ret := _m.Called(user)
@@ -128,6 +209,62 @@ func (_m *ORM) DeleteUserSession(sessionID string) error {
 	return r0
 }
 
+// DisableTOTP provides a mock function with given fields: user
+func (_m *ORM) DisableTOTP(user *sessions.User) error {
+	ret := _m.Called(user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*sessions.User) error); ok {
+		r0 = rf(user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnrollTOTP provides a mock function with given fields: user
+func (_m *ORM) EnrollTOTP(user *sessions.User) (string, error) {
+	ret := _m.Called(user)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(*sessions.User) string); ok {
+		r0 = rf(user)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*sessions.User) error); ok {
+		r1 = rf(user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindAPIToken provides a mock function with given fields: accessKey
+func (_m *ORM) FindAPIToken(accessKey string) (sessions.APIToken, error) {
+	ret := _m.Called(accessKey)
+
+	var r0 sessions.APIToken
+	if rf, ok := ret.Get(0).(func(string) sessions.APIToken); ok {
+		r0 = rf(accessKey)
+	} else {
+		r0 = ret.Get(0).(sessions.APIToken)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(accessKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindExternalInitiator provides a mock function with given fields: eia
 func (_m *ORM) FindExternalInitiator(eia *auth.Token) (*bridges.ExternalInitiator, error) {
 	ret := _m.Called(eia)
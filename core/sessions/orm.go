@@ -4,9 +4,11 @@ import (
 	"crypto/subtle"
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"strings"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 
 	"github.com/smartcontractkit/chainlink/core/auth"
@@ -17,6 +19,10 @@ import (
 	"github.com/smartcontractkit/sqlx"
 )
 
+// ErrTOTPRequired is returned when creating a session for a user enrolled in
+// TOTP two-factor authentication without a TOTP code
+var ErrTOTPRequired = errors.New("TOTP code required")
+
 //go:generate mockery --name ORM --output ./mocks/ --case=underscore
 
 type ORM interface {
@@ -29,6 +35,13 @@ type ORM interface {
 	CreateUser(user *User) error
 	SetAuthToken(user *User, token *auth.Token) error
 	DeleteAuthToken(user *User) error
+	EnrollTOTP(user *User) (provisioningURI string, err error)
+	ConfirmTOTP(user *User, code string) error
+	DisableTOTP(user *User) error
+	CreateAPIToken(name string, scopes []string) (*auth.Token, APIToken, error)
+	FindAPIToken(accessKey string) (APIToken, error)
+	APITokens() ([]APIToken, error)
+	DeleteAPIToken(id int64) error
 	SetPassword(user *User, newPassword string) error
 	Sessions(offset, limit int) ([]Session, error)
 	GetUserWebAuthn(email string) ([]WebAuthn, error)
@@ -40,13 +53,17 @@ type ORM interface {
 type orm struct {
 	db              *sqlx.DB
 	sessionDuration time.Duration
+	absoluteTimeout bool
+	clock           utils.Nower
 	lggr            logger.Logger
 }
 
 var _ ORM = (*orm)(nil)
 
-func NewORM(db *sqlx.DB, sessionDuration time.Duration, lggr logger.Logger) ORM {
-	return &orm{db, sessionDuration, lggr.Named("SessionsORM")}
+// NewORM returns an ORM whose sessions expire once unused for sessionDuration,
+// or, if absoluteTimeout is set, sessionDuration after they were created.
+func NewORM(db *sqlx.DB, sessionDuration time.Duration, absoluteTimeout bool, lggr logger.Logger) ORM {
+	return &orm{db, sessionDuration, absoluteTimeout, utils.Clock{}, lggr.Named("SessionsORM")}
 }
 
 // FindUser will return the one API user, or an error.
@@ -67,7 +84,12 @@ func (o *orm) AuthorizedUserWithSession(sessionID string) (User, error) {
 		return User{}, errors.New("Session ID cannot be empty")
 	}
 
-	result, err := o.db.Exec("UPDATE sessions SET last_used = now() WHERE id = $1 AND last_used + $2 >= now()", sessionID, o.sessionDuration)
+	expiresFrom := "last_used"
+	if o.absoluteTimeout {
+		expiresFrom = "created_at"
+	}
+	stmt := fmt.Sprintf("UPDATE sessions SET last_used = $3 WHERE id = $1 AND %s + $2 >= $3", expiresFrom)
+	result, err := o.db.Exec(stmt, sessionID, o.sessionDuration, o.clock.Now())
 	if err != nil {
 		return User{}, err
 	}
@@ -148,9 +170,7 @@ func (o *orm) CreateSession(sr SessionRequest) (string, error) {
 	// No webauthn tokens registered for the current user, so normal authentication is now complete
 	if len(uwas) == 0 {
 		lggr.Infof("No MFA for user. Creating Session")
-		session := NewSession()
-		_, err = o.db.Exec("INSERT INTO sessions (id, last_used, created_at) VALUES ($1, now(), now())", session.ID)
-		return session.ID, err
+		return o.createSession(lggr, user, sr)
 	}
 
 	// Next check if this session request includes the required WebAuthn challenge data
@@ -186,8 +206,21 @@ func (o *orm) CreateSession(sr SessionRequest) (string, error) {
 
 	lggr.Infof("User passed MFA authentication and login will proceed")
 	// This is a success so we can create the sessions
+	return o.createSession(lggr, user, sr)
+}
+
+// createSession creates a session for the user, once they have passed any
+// TOTP two-factor authentication.
+func (o *orm) createSession(lggr logger.Logger, user User, sr SessionRequest) (string, error) {
+	if user.TOTPSecret.Valid {
+		if err := o.checkTOTP(user, user.TOTPSecret.String, sr.TOTPCode); err != nil {
+			lggr.Warnf("TOTP check failed: %v", err)
+			return "", err
+		}
+	}
 	session := NewSession()
-	_, err = o.db.Exec("INSERT INTO sessions (id, last_used, created_at) VALUES ($1, now(), now())", session.ID)
+	now := o.clock.Now()
+	_, err := o.db.Exec("INSERT INTO sessions (id, last_used, created_at) VALUES ($1, $2, $3)", session.ID, now, now)
 	return session.ID, err
 }
 
@@ -235,12 +268,136 @@ func (o *orm) SetAuthToken(user *User, token *auth.Token) error {
 	return o.db.Get(user, sql, salt, token.AccessKey, hashedSecret, user.Email)
 }
 
+// EnrollTOTP generates a new TOTP secret for the user and returns the URI that
+// provisions it in an authenticator app. The secret is pending until confirmed
+// with ConfirmTOTP, so a user who fails to provision it is not locked out; any
+// previous pending secret is replaced.
+func (o *orm) EnrollTOTP(user *User) (string, error) {
+	secret, err := NewTOTPSecret()
+	if err != nil {
+		return "", err
+	}
+	sql := "UPDATE users SET totp_pending_secret = $1, updated_at = now() WHERE email = $2 RETURNING *"
+	if err := o.db.Get(user, sql, secret, user.Email); err != nil {
+		return "", errors.Wrap(err, "EnrollTOTP failed")
+	}
+	return TOTPProvisioningURI(user.Email, secret), nil
+}
+
+// ConfirmTOTP checks code against the user's pending TOTP secret and, if it is
+// valid, makes it the user's TOTP secret, enabling TOTP two-factor
+// authentication. Any previous secret is replaced.
+func (o *orm) ConfirmTOTP(user *User, code string) error {
+	if !user.TOTPPendingSecret.Valid {
+		return errors.New("no pending TOTP enrollment")
+	}
+	pending := user.TOTPPendingSecret.String
+	if err := o.checkTOTP(*user, pending, code); err != nil {
+		return err
+	}
+	query := "UPDATE users SET totp_secret = totp_pending_secret, totp_pending_secret = NULL, updated_at = now() WHERE email = $1 AND totp_pending_secret = $2 RETURNING *"
+	err := o.db.Get(user, query, user.Email, pending)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errors.New("pending TOTP enrollment was replaced")
+	}
+	return errors.Wrap(err, "ConfirmTOTP failed")
+}
+
+// DisableTOTP clears the user's TOTP secret and any pending one, disabling
+// TOTP two-factor authentication.
+func (o *orm) DisableTOTP(user *User) error {
+	sql := "UPDATE users SET totp_secret = NULL, totp_pending_secret = NULL, totp_last_used_step = NULL, updated_at = now() WHERE email = $1 RETURNING *"
+	return o.db.Get(user, sql, user.Email)
+}
+
+// checkTOTP checks code against the given TOTP secret of the user. A code is
+// accepted only once: it is rejected if a code of the same or a later step has
+// already been used.
+func (o *orm) checkTOTP(user User, secret, code string) error {
+	if code == "" {
+		return ErrTOTPRequired
+	}
+	step, ok, err := validateTOTP(secret, code, o.clock.Now())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("Invalid TOTP code")
+	}
+	result, err := o.db.Exec("UPDATE users SET totp_last_used_step = $1 WHERE email = $2 AND (totp_last_used_step IS NULL OR totp_last_used_step < $1)", step, user.Email)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("TOTP code already used")
+	}
+	return nil
+}
+
 // DeleteAuthToken clears and disables the users Authentication Token.
 func (o *orm) DeleteAuthToken(user *User) error {
 	sql := "UPDATE users SET token_salt = '', token_key = '', token_hashed_secret = '', updated_at = now() WHERE email = $1 RETURNING *"
 	return o.db.Get(user, sql, user.Email)
 }
 
+// CreateAPIToken creates an API token with the given scopes. The returned
+// auth.Token holds the secret, which is only stored hashed.
+func (o *orm) CreateAPIToken(name string, scopes []string) (*auth.Token, APIToken, error) {
+	if len(scopes) == 0 {
+		return nil, APIToken{}, errors.New("API token must have at least one scope")
+	}
+	for _, scope := range scopes {
+		if err := ValidateScope(scope); err != nil {
+			return nil, APIToken{}, err
+		}
+	}
+	token := auth.NewToken()
+	salt := utils.NewSecret(utils.DefaultSecretSize)
+	hashedSecret, err := auth.HashedSecret(token, salt)
+	if err != nil {
+		return nil, APIToken{}, errors.Wrap(err, "CreateAPIToken failed")
+	}
+	var apiToken APIToken
+	sql := "INSERT INTO api_tokens (name, access_key, salt, hashed_secret, scopes, created_at) VALUES ($1, $2, $3, $4, $5, now()) RETURNING *"
+	if err := o.db.Get(&apiToken, sql, name, token.AccessKey, salt, hashedSecret, pq.StringArray(scopes)); err != nil {
+		return nil, APIToken{}, errors.Wrap(err, "CreateAPIToken failed")
+	}
+	return token, apiToken, nil
+}
+
+// FindAPIToken returns the API token with the given access key.
+func (o *orm) FindAPIToken(accessKey string) (apiToken APIToken, err error) {
+	err = o.db.Get(&apiToken, "SELECT * FROM api_tokens WHERE access_key = $1", accessKey)
+	return
+}
+
+// APITokens returns all API tokens.
+func (o *orm) APITokens() (apiTokens []APIToken, err error) {
+	err = o.db.Select(&apiTokens, "SELECT * FROM api_tokens ORDER BY id")
+	return
+}
+
+// DeleteAPIToken deletes the API token, returning sql.ErrNoRows if it does
+// not exist.
+func (o *orm) DeleteAPIToken(id int64) error {
+	result, err := o.db.Exec("DELETE FROM api_tokens WHERE id = $1", id)
+	if err != nil {
+		return errors.Wrap(err, "DeleteAPIToken failed")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
 // SaveWebAuthn saves new WebAuthn token information.
 func (o *orm) SaveWebAuthn(token *WebAuthn) error {
 	sql := "INSERT INTO web_authns (email, public_key_data) VALUES ($1, $2)"
@@ -1,6 +1,7 @@
 package sessions_test
 
 import (
+	"net/url"
 	"testing"
 	"time"
 
@@ -18,7 +19,7 @@ func setupORM(t *testing.T) (*sqlx.DB, sessions.ORM) {
 	t.Helper()
 
 	db := pgtest.NewSqlxDB(t)
-	orm := sessions.NewORM(db, time.Minute, logger.TestLogger(t))
+	orm := sessions.NewORM(db, time.Minute, false, logger.TestLogger(t))
 
 	return db, orm
 }
@@ -60,7 +61,7 @@ func TestORM_AuthorizedUserWithSession(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			db := pgtest.NewSqlxDB(t)
-			orm := sessions.NewORM(db, test.sessionDuration, logger.TestLogger(t))
+			orm := sessions.NewORM(db, test.sessionDuration, false, logger.TestLogger(t))
 
 			user := cltest.MustNewUser(t, "have@email", "password")
 			require.NoError(t, orm.CreateUser(&user))
@@ -86,6 +87,57 @@ func TestORM_AuthorizedUserWithSession(t *testing.T) {
 	}
 }
 
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func TestORM_AuthorizedUserWithSession_Expiry(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		absoluteTimeout bool
+	}{
+		{"from last use", false},
+		{"from creation", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db := pgtest.NewSqlxDB(t)
+			orm := sessions.NewORM(db, time.Hour, test.absoluteTimeout, logger.TestLogger(t))
+			clock := &fakeClock{now: time.Now()}
+			sessions.SetClock(orm, clock)
+
+			user := cltest.MustNewUser(t, "have@email", "password")
+			require.NoError(t, orm.CreateUser(&user))
+			sessionID, err := orm.CreateSession(sessions.SessionRequest{Email: "have@email", Password: "password"})
+			require.NoError(t, err)
+
+			clock.Advance(45 * time.Minute)
+			_, err = orm.AuthorizedUserWithSession(sessionID)
+			require.NoError(t, err)
+
+			// Past the TTL from creation, but not from last use
+			clock.Advance(30 * time.Minute)
+			_, err = orm.AuthorizedUserWithSession(sessionID)
+			if test.absoluteTimeout {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			clock.Advance(time.Hour + time.Second)
+			_, err = orm.AuthorizedUserWithSession(sessionID)
+			require.Error(t, err)
+		})
+	}
+}
+
 func TestORM_DeleteUser(t *testing.T) {
 	t.Parallel()
 	_, orm := setupORM(t)
@@ -158,3 +210,108 @@ func TestORM_CreateSession(t *testing.T) {
 		})
 	}
 }
+
+func TestORM_CreateSession_TOTP(t *testing.T) {
+	t.Parallel()
+
+	_, orm := setupORM(t)
+	clock := &fakeClock{now: time.Now()}
+	sessions.SetClock(orm, clock)
+
+	user := cltest.MustRandomUser(t)
+	require.NoError(t, orm.CreateUser(&user))
+
+	uri, err := orm.EnrollTOTP(&user)
+	require.NoError(t, err)
+	require.False(t, user.TOTPSecret.Valid)
+	require.True(t, user.TOTPPendingSecret.Valid)
+	secret := user.TOTPPendingSecret.String
+	parsed, err := url.Parse(uri)
+	require.NoError(t, err)
+	assert.Equal(t, secret, parsed.Query().Get("secret"))
+
+	codeAt := func(at time.Time) string {
+		code, err := sessions.TOTPCode(secret, at)
+		require.NoError(t, err)
+		return code
+	}
+	invalidCode := func() string {
+		invalid := "000000"
+		for _, d := range []time.Duration{-30 * time.Second, 0, 30 * time.Second} {
+			if codeAt(clock.Now().Add(d)) == invalid {
+				invalid = "111111"
+			}
+		}
+		return invalid
+	}
+	createSession := func(code string) (string, error) {
+		return orm.CreateSession(sessions.SessionRequest{
+			Email:    user.Email,
+			Password: cltest.Password,
+			TOTPCode: code,
+		})
+	}
+
+	t.Run("is not required while pending", func(t *testing.T) {
+		sessionID, err := createSession("")
+		require.NoError(t, err)
+		assert.NotEmpty(t, sessionID)
+	})
+
+	t.Run("confirmation rejects an invalid code", func(t *testing.T) {
+		require.Error(t, orm.ConfirmTOTP(&user, invalidCode()))
+		assert.False(t, user.TOTPSecret.Valid)
+		assert.True(t, user.TOTPPendingSecret.Valid)
+	})
+
+	t.Run("confirmation with a valid code enables it", func(t *testing.T) {
+		require.NoError(t, orm.ConfirmTOTP(&user, codeAt(clock.Now())))
+		assert.Equal(t, secret, user.TOTPSecret.String)
+		assert.False(t, user.TOTPPendingSecret.Valid)
+
+		require.Error(t, orm.ConfirmTOTP(&user, codeAt(clock.Now())), "nothing is left to confirm")
+
+		// move past the step of the confirmation code, which cannot be reused
+		// to log in
+		clock.Advance(time.Minute)
+	})
+
+	t.Run("requires a code", func(t *testing.T) {
+		_, err := createSession("")
+		assert.Equal(t, sessions.ErrTOTPRequired, err)
+	})
+
+	t.Run("rejects an invalid code", func(t *testing.T) {
+		_, err := createSession(invalidCode())
+		require.Error(t, err)
+	})
+
+	t.Run("rejects an expired code", func(t *testing.T) {
+		_, err := createSession(codeAt(clock.Now().Add(-2 * time.Minute)))
+		require.Error(t, err)
+	})
+
+	t.Run("accepts a valid code once", func(t *testing.T) {
+		code := codeAt(clock.Now())
+		sessionID, err := createSession(code)
+		require.NoError(t, err)
+		assert.NotEmpty(t, sessionID)
+
+		_, err = createSession(code)
+		require.Error(t, err)
+
+		clock.Advance(time.Minute)
+		sessionID, err = createSession(codeAt(clock.Now()))
+		require.NoError(t, err)
+		assert.NotEmpty(t, sessionID)
+	})
+
+	t.Run("is not required once disabled", func(t *testing.T) {
+		require.NoError(t, orm.DisableTOTP(&user))
+		assert.False(t, user.TOTPSecret.Valid)
+
+		sessionID, err := createSession("")
+		require.NoError(t, err)
+		assert.NotEmpty(t, sessionID)
+	})
+}
@@ -31,7 +31,7 @@ func TestSessionReaper_ReapSessions(t *testing.T) {
 	db := pgtest.NewSqlxDB(t)
 	config := sessionReaperConfig{}
 	lggr := logger.TestLogger(t)
-	orm := sessions.NewORM(db, config.SessionTimeout().Duration(), lggr)
+	orm := sessions.NewORM(db, config.SessionTimeout().Duration(), false, lggr)
 
 	r := sessions.NewSessionReaper(db.DB, config, lggr)
 	defer r.Stop()
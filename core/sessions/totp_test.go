@@ -0,0 +1,66 @@
+package sessions_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/sessions"
+)
+
+func TestTOTPCode(t *testing.T) {
+	t.Parallel()
+
+	// Test vectors of RFC 6238 for SHA1, truncated to 6 digits. The secret is
+	// the ASCII string "12345678901234567890".
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+	for _, test := range tests {
+		code, err := sessions.TOTPCode(secret, time.Unix(test.unix, 0))
+		require.NoError(t, err)
+		assert.Equal(t, test.code, code, test.unix)
+	}
+
+	_, err := sessions.TOTPCode("not base32!", time.Now())
+	require.Error(t, err)
+}
+
+func TestNewTOTPSecret(t *testing.T) {
+	t.Parallel()
+
+	secret, err := sessions.NewTOTPSecret()
+	require.NoError(t, err)
+	other, err := sessions.NewTOTPSecret()
+	require.NoError(t, err)
+	assert.NotEqual(t, secret, other)
+
+	_, err = sessions.TOTPCode(secret, time.Now())
+	require.NoError(t, err)
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	t.Parallel()
+
+	uri, err := url.Parse(sessions.TOTPProvisioningURI("have@email", "GEZDGNBV"))
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/Chainlink:have@email", uri.Path)
+	assert.Equal(t, "GEZDGNBV", uri.Query().Get("secret"))
+	assert.Equal(t, "Chainlink", uri.Query().Get("issuer"))
+	assert.Equal(t, "6", uri.Query().Get("digits"))
+	assert.Equal(t, "30", uri.Query().Get("period"))
+}
@@ -23,6 +23,11 @@ type User struct {
 	TokenSalt         null.String
 	TokenHashedSecret null.String
 	UpdatedAt         time.Time
+	TOTPSecret        null.String
+	TOTPLastUsedStep  null.Int
+	// TOTPPendingSecret is a TOTP secret that has been enrolled but not yet
+	// confirmed with a valid code
+	TOTPPendingSecret null.String
 }
 
 // https://davidcel.is/posts/stop-validating-email-addresses-with-regex/
@@ -61,9 +66,12 @@ func NewUser(email, plainPwd string) (User, error) {
 // SessionRequest encapsulates the fields needed to generate a new SessionID,
 // including the hashed password.
 type SessionRequest struct {
-	Email          string `json:"email"`
-	Password       string `json:"password"`
-	WebAuthnData   string `json:"webauthndata"`
+	Email        string `json:"email"`
+	Password     string `json:"password"`
+	WebAuthnData string `json:"webauthndata"`
+	// TOTPCode is the current TOTP code, required when the user has enrolled
+	// in TOTP two-factor authentication
+	TOTPCode       string `json:"totpcode"`
 	WebAuthnConfig WebAuthnConfiguration
 	SessionStore   *WebAuthnSessionStore
 	RequestContext *gin.Context
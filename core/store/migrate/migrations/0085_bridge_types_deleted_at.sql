@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE bridge_types
+    ADD COLUMN deleted_at timestamptz;
+
+-- +goose Down
+ALTER TABLE bridge_types
+    DROP COLUMN deleted_at;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE eth_key_states
+    ADD COLUMN disabled boolean NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE eth_key_states
+    DROP COLUMN disabled;
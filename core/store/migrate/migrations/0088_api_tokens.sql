@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE api_tokens (
+    id BIGSERIAL PRIMARY KEY,
+    name text NOT NULL,
+    access_key text NOT NULL UNIQUE,
+    salt text NOT NULL,
+    hashed_secret text NOT NULL,
+    scopes text[] NOT NULL,
+    created_at timestamptz NOT NULL
+);
+
+-- +goose Down
+DROP TABLE api_tokens;
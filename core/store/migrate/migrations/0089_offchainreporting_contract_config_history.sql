@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE offchainreporting_contract_config_history (
+    id BIGSERIAL PRIMARY KEY,
+    offchainreporting_oracle_spec_id integer NOT NULL REFERENCES offchainreporting_oracle_specs (id) ON DELETE CASCADE,
+    config_digest bytea NOT NULL CHECK (octet_length(config_digest) = 16),
+    signers bytea[],
+    transmitters bytea[],
+    threshold integer,
+    encoded_config_version bigint,
+    encoded bytea,
+    created_at timestamptz NOT NULL,
+    superseded_at timestamptz NOT NULL
+);
+
+CREATE INDEX idx_offchainreporting_contract_config_history_spec_id ON offchainreporting_contract_config_history (offchainreporting_oracle_spec_id, id);
+
+-- +goose Down
+DROP TABLE offchainreporting_contract_config_history;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE users ADD COLUMN totp_pending_secret text;
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN totp_pending_secret;
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/pkg/errors"
 )
 
 const (
@@ -9,11 +10,23 @@ const (
 	FastN = 2
 	// FastP is a shorter P parameter for testing
 	FastP = 1
+
+	// MinScryptN is the lowest N parameter accepted outside of insecure fast
+	// mode. It matches geth's "light" level of encryption.
+	MinScryptN = keystore.LightScryptN
+	// MinScryptP is the lowest P parameter accepted outside of insecure fast
+	// mode.
+	MinScryptP = 1
 )
 
 type (
-	// ScryptParams represents two integers, N and P.
-	ScryptParams struct{ N, P int }
+	// ScryptParams represents two integers, N and P. InsecureFast exempts
+	// them from the minimums enforced by Validate, and must only be set in
+	// tests or dev mode.
+	ScryptParams struct {
+		N, P         int
+		InsecureFast bool
+	}
 	// ScryptConfigReader can check for an insecure, fast flag
 	ScryptConfigReader interface {
 		InsecureFastScrypt() bool
@@ -28,7 +41,22 @@ var DefaultScryptParams = ScryptParams{N: keystore.StandardScryptN, P: keystore.
 // FastScryptParams is for use in tests, where you don't want to wear out your
 // CPU with expensive key derivations, do not use it in production, or your
 // encrypted keys will be easy to brute-force!
-var FastScryptParams = ScryptParams{N: FastN, P: FastP}
+var FastScryptParams = ScryptParams{N: FastN, P: FastP, InsecureFast: true}
+
+// Validate returns an error if the params are too weak to protect encrypted
+// keys, unless they are explicitly flagged as InsecureFast.
+func (p ScryptParams) Validate() error {
+	if p.InsecureFast {
+		return nil
+	}
+	if p.N < MinScryptN {
+		return errors.Errorf("scrypt N parameter %d is below the minimum of %d", p.N, MinScryptN)
+	}
+	if p.P < MinScryptP {
+		return errors.Errorf("scrypt P parameter %d is below the minimum of %d", p.P, MinScryptP)
+	}
+	return nil
+}
 
 // GetScryptParams fetches ScryptParams from a ScryptConfigReader
 func GetScryptParams(config ScryptConfigReader) ScryptParams {
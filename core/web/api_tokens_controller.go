@@ -0,0 +1,101 @@
+package web
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/sessions"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+// APITokensController manages the scoped API tokens that external automation
+// can use instead of the user's credentials
+type APITokensController struct {
+	App chainlink.Application
+}
+
+// CreateAPITokenRequest represents a JSONAPI request for creating a scoped API
+// token
+type CreateAPITokenRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+// Create creates a new API token. The response holds its secret, which
+// cannot be retrieved later.
+// Example:
+// "POST <application>/api_tokens"
+func (atc *APITokensController) Create(c *gin.Context) {
+	request := CreateAPITokenRequest{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	if request.Name == "" {
+		jsonAPIError(c, http.StatusBadRequest, errors.New("name is required"))
+		return
+	}
+	if len(request.Scopes) == 0 {
+		jsonAPIError(c, http.StatusBadRequest, errors.New("at least one scope is required"))
+		return
+	}
+	for _, scope := range request.Scopes {
+		if err := sessions.ValidateScope(scope); err != nil {
+			jsonAPIError(c, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	token, apiToken, err := atc.App.SessionORM().CreateAPIToken(request.Name, request.Scopes)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponseWithStatus(c,
+		presenters.NewAPITokenAuthentication(apiToken, *token),
+		"api_tokens",
+		http.StatusCreated,
+	)
+}
+
+// Index lists the API tokens, without their secrets
+// Example:
+// "GET <application>/api_tokens"
+func (atc *APITokensController) Index(c *gin.Context) {
+	apiTokens, err := atc.App.SessionORM().APITokens()
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponse(c, presenters.NewAPITokenResources(apiTokens), "api_tokens")
+}
+
+// Destroy deletes an API token, revoking it
+// Example:
+// "DELETE <application>/api_tokens/:ID"
+func (atc *APITokensController) Destroy(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	err = atc.App.SessionORM().DeleteAPIToken(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("API token not found"))
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponseWithStatus(c, nil, "api_tokens", http.StatusNoContent)
+}
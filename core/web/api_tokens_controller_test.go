@@ -0,0 +1,132 @@
+package web_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/auth"
+	"github.com/smartcontractkit/chainlink/core/bridges"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/web"
+	webauth "github.com/smartcontractkit/chainlink/core/web/auth"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+func TestAPITokensController_Create(t *testing.T) {
+	t.Parallel()
+
+	app := cltest.NewApplicationEVMDisabled(t)
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	body, err := json.Marshal(web.CreateAPITokenRequest{Name: "automation", Scopes: []string{"*:read"}})
+	require.NoError(t, err)
+	resp, cleanup := client.Post("/v2/api_tokens", bytes.NewBuffer(body))
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusCreated)
+
+	var created presenters.APITokenResource
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &created))
+	assert.Equal(t, "automation", created.Name)
+	assert.Equal(t, []string{"*:read"}, created.Scopes)
+	assert.NotEmpty(t, created.AccessKey)
+	assert.NotEmpty(t, created.Secret)
+
+	resp, cleanup = client.Get("/v2/api_tokens")
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var listed []presenters.APITokenResource
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &listed))
+	require.Len(t, listed, 1)
+	assert.Equal(t, created.AccessKey, listed[0].AccessKey)
+	assert.Empty(t, listed[0].Secret)
+
+	resp, cleanup = client.Delete("/v2/api_tokens/" + created.ID)
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusNoContent)
+
+	_, err = app.SessionORM().FindAPIToken(created.AccessKey)
+	require.Error(t, err)
+}
+
+func TestAPITokensController_Create_InvalidScope(t *testing.T) {
+	t.Parallel()
+
+	app := cltest.NewApplicationEVMDisabled(t)
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	body, err := json.Marshal(web.CreateAPITokenRequest{Name: "automation", Scopes: []string{"bridges:admin"}})
+	require.NoError(t, err)
+	resp, cleanup := client.Post("/v2/api_tokens", bytes.NewBuffer(body))
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusBadRequest)
+}
+
+func TestAPITokens_Scopes(t *testing.T) {
+	t.Parallel()
+
+	app := cltest.NewApplicationEVMDisabled(t)
+	require.NoError(t, app.Start())
+
+	_, bt := cltest.MustCreateBridge(t, app.GetSqlxDB(), cltest.BridgeOpts{})
+	bridgeJSON := cltest.MustReadFile(t, "../testdata/apiresponses/create_random_number_bridge_type.json")
+
+	request := func(token *auth.Token, method, path string, body []byte) int {
+		req, err := http.NewRequest(method, app.Server.URL+path, bytes.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", web.MediaType)
+		req.Header.Set(webauth.APIKey, token.AccessKey)
+		req.Header.Set(webauth.APISecret, token.Secret)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		_, err = io.Copy(io.Discard, resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp.StatusCode
+	}
+
+	t.Run("read-only token can list but not create or delete bridges", func(t *testing.T) {
+		token, _, err := app.SessionORM().CreateAPIToken("read only", []string{"*:read"})
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, request(token, http.MethodGet, "/v2/bridge_types", nil))
+		assert.Equal(t, http.StatusOK, request(token, http.MethodGet, "/v2/bridge_types/"+bt.Name.String(), nil))
+		assert.Equal(t, http.StatusForbidden, request(token, http.MethodPost, "/v2/bridge_types", bridgeJSON))
+		assert.Equal(t, http.StatusForbidden, request(token, http.MethodDelete, "/v2/bridge_types/"+bt.Name.String(), nil))
+
+		_, err = app.BridgeORM().FindBridge(bt.Name)
+		require.NoError(t, err)
+		_, err = app.BridgeORM().FindBridge(bridges.MustNewTaskType("randomnumber"))
+		require.Error(t, err)
+	})
+
+	t.Run("resource group token only accesses its group", func(t *testing.T) {
+		token, _, err := app.SessionORM().CreateAPIToken("bridges", []string{"bridge_types"})
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, request(token, http.MethodGet, "/v2/bridge_types", nil))
+		assert.Equal(t, http.StatusNoContent, request(token, http.MethodDelete, "/v2/bridge_types/"+bt.Name.String(), nil))
+		assert.Equal(t, http.StatusForbidden, request(token, http.MethodGet, "/v2/jobs", nil))
+	})
+
+	t.Run("tokens cannot manage tokens", func(t *testing.T) {
+		token, _, err := app.SessionORM().CreateAPIToken("everything", []string{"*"})
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusForbidden, request(token, http.MethodGet, "/v2/api_tokens", nil))
+		assert.Equal(t, http.StatusForbidden, request(token, http.MethodPost, "/v2/api_tokens", []byte(`{"name":"x","scopes":["*"]}`)))
+	})
+
+	t.Run("unknown token is unauthorized", func(t *testing.T) {
+		token := auth.NewToken()
+		assert.Equal(t, http.StatusUnauthorized, request(token, http.MethodGet, "/v2/bridge_types", nil))
+	})
+}
@@ -3,6 +3,7 @@ package auth
 import (
 	"database/sql"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/contrib/sessions"
 	"github.com/gin-gonic/gin"
@@ -32,14 +33,26 @@ const (
 
 	// SessionExternalInitiatorKey is the External Initiator key in the session map
 	SessionExternalInitiatorKey = "external_initiator"
+
+	// SessionAPITokenKey is the API Token key in the session map
+	SessionAPITokenKey = "api_token"
 )
 
+// userOnlyResourceGroups are the resource groups that only the user can
+// access, whatever the scopes of an API token
+var userOnlyResourceGroups = map[string]bool{
+	"api_tokens":      true,
+	"enroll_webauthn": true,
+	"user":            true,
+}
+
 // Authenticator defines the interface to authenticate requests against a
 // datastore.
 type Authenticator interface {
 	AuthorizedUserWithSession(sessionID string) (clsessions.User, error)
 	FindExternalInitiator(eia *auth.Token) (*bridges.ExternalInitiator, error)
 	FindUser() (clsessions.User, error)
+	FindAPIToken(accessKey string) (clsessions.APIToken, error)
 }
 
 // authMethod defines a method which can be used to authenticate a request. This
@@ -102,6 +115,68 @@ func AuthenticateByToken(c *gin.Context, authr Authenticator) error {
 
 var _ authMethod = AuthenticateByToken
 
+// AuthenticateByAPIToken authenticates the request by a scoped API token, sent
+// in the same headers as the user's API token, and checks that its scopes
+// allow the request.
+//
+// Implements authMethod
+func AuthenticateByAPIToken(c *gin.Context, authr Authenticator) error {
+	token := &auth.Token{
+		AccessKey: c.GetHeader(APIKey),
+		Secret:    c.GetHeader(APISecret),
+	}
+	if token.AccessKey == "" {
+		return auth.ErrorAuthFailed
+	}
+
+	apiToken, err := authr.FindAPIToken(token.AccessKey)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return auth.ErrorAuthFailed
+		}
+
+		return errors.Wrap(err, "finding API token")
+	}
+
+	ok, err := clsessions.AuthenticateAPIToken(token, apiToken)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return auth.ErrorAuthFailed
+	}
+
+	group := resourceGroup(c.FullPath())
+	if userOnlyResourceGroups[group] || !apiToken.Allows(group, isWrite(c.Request.Method)) {
+		return auth.ErrorForbidden
+	}
+
+	c.Set(SessionAPITokenKey, &apiToken)
+
+	return nil
+}
+
+var _ authMethod = AuthenticateByAPIToken
+
+// resourceGroup returns the first segment of path after the API version, e.g.
+// "bridge_types" for /v2/bridge_types/:BridgeName.
+func resourceGroup(path string) string {
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if len(segments) < 2 {
+		return ""
+	}
+	return segments[1]
+}
+
+func isWrite(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	default:
+		return true
+	}
+}
+
 // AuthenticateExternalInitiator authenticates an external initiator request.
 //
 // Implements authMethod
@@ -146,6 +221,12 @@ func Authenticate(store Authenticator, methods ...authMethod) gin.HandlerFunc {
 				break
 			}
 		}
+		if errors.Is(err, auth.ErrorForbidden) {
+			c.Abort()
+			jsonAPIError(c, http.StatusForbidden, err)
+
+			return
+		}
 		if err != nil {
 			c.Abort()
 			jsonAPIError(c, http.StatusUnauthorized, err)
@@ -169,6 +250,18 @@ func GetAuthenticatedUser(c *gin.Context) (*clsessions.User, bool) {
 	return user, ok
 }
 
+// GetAuthenticatedAPIToken extracts the API token from the context.
+func GetAuthenticatedAPIToken(c *gin.Context) (*clsessions.APIToken, bool) {
+	obj, ok := c.Get(SessionAPITokenKey)
+	if !ok {
+		return nil, false
+	}
+
+	apiToken, ok := obj.(*clsessions.APIToken)
+
+	return apiToken, ok
+}
+
 // GetAuthenticatedExternalInitiator extracts the external initiator from the
 // context.
 func GetAuthenticatedExternalInitiator(c *gin.Context) (*bridges.ExternalInitiator, bool) {
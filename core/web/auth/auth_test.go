@@ -1,6 +1,7 @@
 package auth_test
 
 import (
+	"database/sql"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -164,3 +165,63 @@ func TestRequireAuth_Error(t *testing.T) {
 	assert.False(t, called)
 	assert.Equal(t, http.StatusText(http.StatusUnauthorized), http.StatusText(w.Code))
 }
+
+type apiTokenFinder struct {
+	sessions.ORM
+	apiToken sessions.APIToken
+}
+
+func (f apiTokenFinder) FindAPIToken(accessKey string) (sessions.APIToken, error) {
+	if accessKey != f.apiToken.AccessKey {
+		return sessions.APIToken{}, sql.ErrNoRows
+	}
+	return f.apiToken, nil
+}
+
+func TestAuthenticateByAPIToken(t *testing.T) {
+	token := auth.Token{AccessKey: cltest.APIKey, Secret: cltest.APISecret}
+	hashedSecret, err := auth.HashedSecret(&token, "salt")
+	require.NoError(t, err)
+	authr := apiTokenFinder{apiToken: sessions.APIToken{
+		AccessKey:    token.AccessKey,
+		Salt:         "salt",
+		HashedSecret: hashedSecret,
+		Scopes:       []string{"*:read"},
+	}}
+
+	router := gin.New()
+	router.Use(webauth.Authenticate(authr, webauth.AuthenticateByAPIToken))
+	handler := func(c *gin.Context) {
+		apiToken, ok := webauth.GetAuthenticatedAPIToken(c)
+		assert.True(t, ok)
+		assert.Equal(t, cltest.APIKey, apiToken.AccessKey)
+		c.String(http.StatusOK, "")
+	}
+	router.GET("/v2/bridge_types", handler)
+	router.POST("/v2/bridge_types", handler)
+	router.GET("/v2/user/token", handler)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		secret string
+		want   int
+	}{
+		{"read", "GET", "/v2/bridge_types", cltest.APISecret, http.StatusOK},
+		{"write", "POST", "/v2/bridge_types", cltest.APISecret, http.StatusForbidden},
+		{"user only", "GET", "/v2/user/token", cltest.APISecret, http.StatusForbidden},
+		{"bad secret", "GET", "/v2/bridge_types", "bad-secret", http.StatusUnauthorized},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(test.method, test.path, nil)
+			req.Header.Set(webauth.APIKey, cltest.APIKey)
+			req.Header.Set(webauth.APISecret, test.secret)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusText(test.want), http.StatusText(w.Code))
+		})
+	}
+}
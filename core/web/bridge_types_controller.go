@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/jackc/pgconn"
@@ -25,15 +26,16 @@ func ValidateBridgeTypeNotExist(bt *bridges.BridgeTypeRequest, orm bridges.ORM)
 	if err == nil {
 		fe.Add(fmt.Sprintf("Bridge Type %v already exists", bt.Name))
 	}
-	if err != nil && err != sql.ErrNoRows {
+	if err != nil && !errors.Is(err, bridges.ErrBridgeNotFound) {
 		fe.Add(fmt.Sprintf("Error determining if bridge type %v already exists", bt.Name))
 	}
 	return fe.CoerceEmptyToNil()
 }
 
 // ValidateBridgeType checks that the bridge type doesn't have a duplicate
-// or invalid name or invalid url
-func ValidateBridgeType(bt *bridges.BridgeTypeRequest, orm bridges.ORM) error {
+// or invalid name or invalid url, and that its MinimumContractPayment does not
+// exceed maxMinimumContractPayment
+func ValidateBridgeType(bt *bridges.BridgeTypeRequest, orm bridges.ORM, maxMinimumContractPayment *assets.Link) error {
 	fe := models.NewJSONAPIErrors()
 	if len(bt.Name.String()) < 1 {
 		fe.Add("No name specified")
@@ -44,10 +46,11 @@ func ValidateBridgeType(bt *bridges.BridgeTypeRequest, orm bridges.ORM) error {
 	u := bt.URL.String()
 	if len(strings.TrimSpace(u)) == 0 {
 		fe.Add("URL must be present")
+	} else if err := bridges.ValidateURL((*url.URL)(&bt.URL)); err != nil {
+		fe.Add(err.Error())
 	}
-	if bt.MinimumContractPayment != nil &&
-		bt.MinimumContractPayment.Cmp(assets.NewLinkFromJuels(0)) < 0 {
-		fe.Add("MinimumContractPayment must be positive")
+	if err := bridges.ValidateMinimumContractPayment(bt.MinimumContractPayment, maxMinimumContractPayment); err != nil {
+		fe.Add(err.Error())
 	}
 	return fe.CoerceEmptyToNil()
 }
@@ -71,7 +74,7 @@ func (btc *BridgeTypesController) Create(c *gin.Context) {
 		return
 	}
 	orm := btc.App.BridgeORM()
-	if e := ValidateBridgeType(btr, orm); e != nil {
+	if e := ValidateBridgeType(btr, orm, btc.App.GetConfig().BridgeMaxMinimumContractPayment()); e != nil {
 		jsonAPIError(c, http.StatusBadRequest, e)
 		return
 	}
@@ -79,10 +82,16 @@ func (btc *BridgeTypesController) Create(c *gin.Context) {
 		jsonAPIError(c, http.StatusBadRequest, e)
 		return
 	}
-	if e := orm.CreateBridgeType(bt); e != nil {
+	if e := orm.CreateBridgeType(bt); errors.Is(e, bridges.ErrBridgeExists) {
+		jsonAPIError(c, http.StatusBadRequest, fmt.Errorf("Bridge Type %v already exists, but is archived", bt.Name))
+		return
+	} else if e != nil {
 		jsonAPIError(c, http.StatusInternalServerError, e)
 		return
 	}
+	if btc.App.GetConfig().BridgeURLReachabilityCheck() {
+		bridges.WarnIfURLUnreachable(btc.App.GetLogger(), bt.Name, (*url.URL)(&bt.URL))
+	}
 	switch e := err.(type) {
 	case *pgconn.PgError:
 		var apiErr error
@@ -103,7 +112,7 @@ func (btc *BridgeTypesController) Create(c *gin.Context) {
 
 // Index lists Bridges, one page at a time.
 func (btc *BridgeTypesController) Index(c *gin.Context, size, page, offset int) {
-	bridges, count, err := btc.App.BridgeORM().BridgeTypes(offset, size)
+	bridges, count, err := btc.App.BridgeORM().BridgeTypes(offset, size, bridges.BridgeTypesSort{})
 
 	var resources []presenters.BridgeResource
 	for _, bridge := range bridges {
@@ -162,7 +171,7 @@ func (btc *BridgeTypesController) Update(c *gin.Context) {
 		jsonAPIError(c, http.StatusUnprocessableEntity, err)
 		return
 	}
-	if err := ValidateBridgeType(btr, orm); err != nil {
+	if err := ValidateBridgeType(btr, orm, btc.App.GetConfig().BridgeMaxMinimumContractPayment()); err != nil {
 		jsonAPIError(c, http.StatusBadRequest, err)
 		return
 	}
@@ -203,7 +212,12 @@ func (btc *BridgeTypesController) Destroy(c *gin.Context) {
 		jsonAPIError(c, http.StatusConflict, fmt.Errorf("can't remove the bridge because jobs %v are associated with it", jobsUsingBridge))
 		return
 	}
-	if err = orm.DeleteBridgeType(&bt); err != nil {
+	if err = orm.DeleteBridgeType(&bt, false); err != nil {
+		var inUse bridges.BridgeInUseError
+		if errors.As(err, &inUse) {
+			jsonAPIError(c, http.StatusConflict, fmt.Errorf("can't remove the bridge because jobs %v are associated with it", inUse.JobIDs))
+			return
+		}
 		jsonAPIError(c, http.StatusInternalServerError, fmt.Errorf("failed to delete bridge: %+v", err))
 		return
 	}
@@ -2,6 +2,7 @@ package web_test
 
 import (
 	"bytes"
+	"math/big"
 	"net/http"
 	"testing"
 
@@ -22,7 +23,7 @@ func TestValidateBridgeType(t *testing.T) {
 	t.Parallel()
 
 	db := pgtest.NewSqlxDB(t)
-	orm := bridges.NewORM(db, logger.TestLogger(t))
+	orm := bridges.NewORM(db, nil, logger.TestLogger(t))
 
 	tests := []struct {
 		description string
@@ -56,10 +57,34 @@ func TestValidateBridgeType(t *testing.T) {
 			"valid url",
 			bridges.BridgeTypeRequest{
 				Name: "adapterwithvalidurl",
-				URL:  cltest.WebURL(t, "//denergy"),
+				URL:  cltest.WebURL(t, "https://denergy"),
 			},
 			nil,
 		},
+		{
+			"invalid url without scheme",
+			bridges.BridgeTypeRequest{
+				Name: "adapterwithoutscheme",
+				URL:  cltest.WebURL(t, "//denergy"),
+			},
+			models.NewJSONAPIErrorsWith("URL must be absolute and start with http:// or https://"),
+		},
+		{
+			"invalid url scheme",
+			bridges.BridgeTypeRequest{
+				Name: "adapterwithftpurl",
+				URL:  cltest.WebURL(t, "ftp://denergy"),
+			},
+			models.NewJSONAPIErrorsWith("URL scheme must be http or https, got ftp"),
+		},
+		{
+			"invalid relative url",
+			bridges.BridgeTypeRequest{
+				Name: "adapterwithrelativeurl",
+				URL:  cltest.WebURL(t, "denergy/adapter"),
+			},
+			models.NewJSONAPIErrorsWith("URL must be absolute and start with http:// or https://"),
+		},
 		{
 			"valid docker url",
 			bridges.BridgeTypeRequest{
@@ -86,6 +111,33 @@ func TestValidateBridgeType(t *testing.T) {
 			},
 			models.NewJSONAPIErrorsWith("MinimumContractPayment must be positive"),
 		},
+		{
+			"valid MinimumContractPayment zero",
+			bridges.BridgeTypeRequest{
+				Name:                   "adapterwithdockerurl",
+				URL:                    cltest.WebURL(t, "http://chainlink_cmc-adapter_1:8080"),
+				MinimumContractPayment: assets.NewLinkFromJuels(0),
+			},
+			nil,
+		},
+		{
+			"valid MinimumContractPayment total LINK supply",
+			bridges.BridgeTypeRequest{
+				Name:                   "adapterwithdockerurl",
+				URL:                    cltest.WebURL(t, "http://chainlink_cmc-adapter_1:8080"),
+				MinimumContractPayment: assets.LinkTotalSupply(),
+			},
+			nil,
+		},
+		{
+			"invalid MinimumContractPayment exceeds total LINK supply",
+			bridges.BridgeTypeRequest{
+				Name:                   "adapterwithdockerurl",
+				URL:                    cltest.WebURL(t, "http://chainlink_cmc-adapter_1:8080"),
+				MinimumContractPayment: (*assets.Link)(new(big.Int).Add(assets.LinkTotalSupply().ToInt(), big.NewInt(1))),
+			},
+			models.NewJSONAPIErrorsWith("MinimumContractPayment must not exceed 1000000000000000000000000000 juels, got 1000000000000000000000000001"),
+		},
 		{
 			"existing core adapter (no longer fails since core adapters no longer exist)",
 			bridges.BridgeTypeRequest{
@@ -105,7 +157,7 @@ func TestValidateBridgeType(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.description, func(t *testing.T) {
-			result := web.ValidateBridgeType(&test.request, orm)
+			result := web.ValidateBridgeType(&test.request, orm, assets.LinkTotalSupply())
 			assert.Equal(t, test.want, result)
 		})
 	}
@@ -115,7 +167,7 @@ func TestValidateBridgeNotExist(t *testing.T) {
 	t.Parallel()
 
 	db := pgtest.NewSqlxDB(t)
-	orm := bridges.NewORM(db, logger.TestLogger(t))
+	orm := bridges.NewORM(db, nil, logger.TestLogger(t))
 
 	// Create a duplicate
 	bt := bridges.BridgeType{}
@@ -23,7 +23,7 @@ func TestValidateExternalInitiator(t *testing.T) {
 	t.Parallel()
 
 	db := pgtest.NewSqlxDB(t)
-	orm := bridges.NewORM(db, logger.TestLogger(t))
+	orm := bridges.NewORM(db, nil, logger.TestLogger(t))
 
 	url := cltest.WebURL(t, "https://a.web.url")
 
@@ -70,7 +70,7 @@ func TestExternalInitiatorsController_Index(t *testing.T) {
 	client := app.NewHTTPClient()
 
 	db := app.GetSqlxDB()
-	borm := bridges.NewORM(db, logger.TestLogger(t))
+	borm := bridges.NewORM(db, nil, logger.TestLogger(t))
 
 	eiFoo := cltest.MustInsertExternalInitiatorWithOpts(t, borm, cltest.ExternalInitiatorOpts{
 		NamePrefix:    "foo",
@@ -0,0 +1,65 @@
+package loader
+
+import (
+	"context"
+
+	"github.com/graph-gophers/dataloader"
+
+	"github.com/smartcontractkit/chainlink/core/bridges"
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+)
+
+type bridgeBatcher struct {
+	app chainlink.Application
+}
+
+func (b *bridgeBatcher) loadByNames(_ context.Context, keys dataloader.Keys) []*dataloader.Result {
+	// Collect the keys to search for
+	names := make([]bridges.TaskType, len(keys))
+	for ix, key := range keys {
+		names[ix] = bridges.TaskType(key.String())
+	}
+
+	// Fetch the bridges in a single query
+	bts, err := b.app.BridgeORM().FindBridges(names)
+	if err != nil {
+		return []*dataloader.Result{{Data: nil, Error: err}}
+	}
+
+	// Construct the output array of dataloader results. Bridges which do not
+	// exist (e.g. because they were archived) resolve to nil rather than an
+	// error, so that one missing bridge does not fail the whole query.
+	results := make([]*dataloader.Result, len(keys))
+	for ix, name := range names {
+		if bt, ok := bts[name]; ok {
+			results[ix] = &dataloader.Result{Data: bt, Error: nil}
+		} else {
+			results[ix] = &dataloader.Result{Data: nil, Error: nil}
+		}
+	}
+
+	return results
+}
+
+func (b *bridgeBatcher) loadJobIDsByNames(_ context.Context, keys dataloader.Keys) []*dataloader.Result {
+	// Collect the keys to search for
+	names := make([]string, len(keys))
+	for ix, key := range keys {
+		names[ix] = key.String()
+	}
+
+	// Find the jobs calling any of the bridges in a single query
+	jids, err := b.app.JobORM().FindJobIDsWithBridges(names)
+	if err != nil {
+		return []*dataloader.Result{{Data: nil, Error: err}}
+	}
+
+	// Construct the output array of dataloader results. Bridges which no job
+	// calls resolve to no job IDs.
+	results := make([]*dataloader.Result, len(keys))
+	for ix, name := range names {
+		results[ix] = &dataloader.Result{Data: jids[name], Error: nil}
+	}
+
+	return results
+}
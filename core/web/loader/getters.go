@@ -5,7 +5,9 @@ import (
 	"errors"
 
 	"github.com/graph-gophers/dataloader"
+	"go.uber.org/multierr"
 
+	"github.com/smartcontractkit/chainlink/core/bridges"
 	"github.com/smartcontractkit/chainlink/core/chains/evm/types"
 	"github.com/smartcontractkit/chainlink/core/services/feeds"
 	"github.com/smartcontractkit/chainlink/core/services/pipeline"
@@ -82,3 +84,56 @@ func GetJobRunsByPipelineSpecID(ctx context.Context, id string) ([]pipeline.Run,
 
 	return jbRuns, nil
 }
+
+// GetBridgesByNames fetches the bridges with the given names. Lookups made
+// while resolving the same request are batched into a single query and
+// cached, so duplicate names are only fetched once. Bridges which do not exist
+// are omitted from the result.
+func GetBridgesByNames(ctx context.Context, names []string) ([]bridges.BridgeType, error) {
+	if len(names) == 0 {
+		return []bridges.BridgeType{}, nil
+	}
+
+	ldr := For(ctx)
+
+	thunk := ldr.BridgesByNameLoader.LoadMany(ctx, dataloader.NewKeysFromStrings(names))
+	results, errs := thunk()
+	if err := multierr.Combine(errs...); err != nil {
+		return nil, err
+	}
+
+	bts := []bridges.BridgeType{}
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+
+		bt, ok := result.(bridges.BridgeType)
+		if !ok {
+			return nil, errors.New("invalid type")
+		}
+		bts = append(bts, bt)
+	}
+
+	return bts, nil
+}
+
+// GetJobIDsByBridgeName fetches the IDs of the jobs whose pipelines call the
+// named bridge. Lookups made while resolving the same request are batched
+// into a single query.
+func GetJobIDsByBridgeName(ctx context.Context, name string) ([]int32, error) {
+	ldr := For(ctx)
+
+	thunk := ldr.JobIDsByBridgeNameLoader.Load(ctx, dataloader.StringKey(name))
+	result, err := thunk()
+	if err != nil {
+		return nil, err
+	}
+
+	jids, ok := result.([]int32)
+	if !ok {
+		return nil, errors.New("invalid type")
+	}
+
+	return jids, nil
+}
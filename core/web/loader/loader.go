@@ -18,6 +18,8 @@ type Dataloader struct {
 	ChainsByIDLoader          *dataloader.Loader
 	FeedsManagersByIDLoader   *dataloader.Loader
 	JobRunsByPipelineIDLoader *dataloader.Loader
+	BridgesByNameLoader       *dataloader.Loader
+	JobIDsByBridgeNameLoader  *dataloader.Loader
 }
 
 func New(app chainlink.Application) *Dataloader {
@@ -25,6 +27,7 @@ func New(app chainlink.Application) *Dataloader {
 	chains := &chainBatcher{app: app}
 	mgrs := &feedsBatcher{app: app}
 	jbRuns := &jobRunBatcher{app: app}
+	bridges := &bridgeBatcher{app: app}
 
 	return &Dataloader{
 		app: app,
@@ -33,6 +36,8 @@ func New(app chainlink.Application) *Dataloader {
 		ChainsByIDLoader:          dataloader.NewBatchedLoader(chains.loadByIDs),
 		FeedsManagersByIDLoader:   dataloader.NewBatchedLoader(mgrs.loadByIDs),
 		JobRunsByPipelineIDLoader: dataloader.NewBatchedLoader(jbRuns.loadByPipelineSpecIDs),
+		BridgesByNameLoader:       dataloader.NewBatchedLoader(bridges.loadByNames),
+		JobIDsByBridgeNameLoader:  dataloader.NewBatchedLoader(bridges.loadJobIDsByNames),
 	}
 }
 
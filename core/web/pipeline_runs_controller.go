@@ -110,7 +110,7 @@ func (prc *PipelineRunsController) Create(c *gin.Context) {
 	// Is it a UUID? Then process it as a webhook job
 	jobUUID, err := uuid.FromString(idStr)
 	if err == nil {
-		canRun, err2 := authorizer.CanRun(c.Request.Context(), prc.App.GetConfig(), jobUUID)
+		canRun, reason, err2 := authorizer.CanRunWithReason(c.Request.Context(), prc.App.GetConfig(), jobUUID)
 		if err2 != nil {
 			jsonAPIError(c, http.StatusInternalServerError, err2)
 			return
@@ -126,7 +126,7 @@ func (prc *PipelineRunsController) Create(c *gin.Context) {
 			}
 			respondWithPipelineRun(jobRunID)
 		} else {
-			jsonAPIError(c, http.StatusUnauthorized, errors.Errorf("external initiator %s is not allowed to run job %s", ei.Name, jobUUID))
+			jsonAPIError(c, http.StatusUnauthorized, errors.Errorf("external initiator %s is not allowed to run job %s: %s", ei.Name, jobUUID, reason))
 		}
 		return
 	}
@@ -0,0 +1,53 @@
+package presenters
+
+import (
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/auth"
+	"github.com/smartcontractkit/chainlink/core/sessions"
+)
+
+// APITokenResource represents a scoped API token JSONAPI resource. The secret
+// is only included when the token is created.
+type APITokenResource struct {
+	JAID
+	Name      string    `json:"name"`
+	AccessKey string    `json:"accessKey"`
+	Secret    string    `json:"secret,omitempty"`
+	Scopes    []string  `json:"scopes"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r APITokenResource) GetName() string {
+	return "apiTokens"
+}
+
+// NewAPITokenResource constructs a new APITokenResource.
+func NewAPITokenResource(t sessions.APIToken) *APITokenResource {
+	return &APITokenResource{
+		JAID:      NewJAIDInt64(t.ID),
+		Name:      t.Name,
+		AccessKey: t.AccessKey,
+		Scopes:    t.Scopes,
+		CreatedAt: t.CreatedAt,
+	}
+}
+
+// NewAPITokenResources initializes a slice of JSONAPI API token resources
+func NewAPITokenResources(ts []sessions.APIToken) []APITokenResource {
+	rs := []APITokenResource{}
+	for _, t := range ts {
+		rs = append(rs, *NewAPITokenResource(t))
+	}
+
+	return rs
+}
+
+// NewAPITokenAuthentication constructs an APITokenResource including the
+// secret of the newly created token.
+func NewAPITokenAuthentication(t sessions.APIToken, token auth.Token) *APITokenResource {
+	r := NewAPITokenResource(t)
+	r.Secret = token.Secret
+	return r
+}
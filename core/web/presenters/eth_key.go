@@ -17,6 +17,7 @@ type ETHKeyResource struct {
 	EthBalance     *assets.Eth  `json:"ethBalance"`
 	LinkBalance    *assets.Link `json:"linkBalance"`
 	IsFunding      bool         `json:"isFunding"`
+	Disabled       bool         `json:"disabled"`
 	CreatedAt      time.Time    `json:"createdAt"`
 	UpdatedAt      time.Time    `json:"updatedAt"`
 	MaxGasPriceWei utils.Big    `json:"maxGasPriceWei"`
@@ -45,6 +46,7 @@ func NewETHKeyResource(k ethkey.KeyV2, state ethkey.State, opts ...NewETHKeyOpti
 		EthBalance:  nil,
 		LinkBalance: nil,
 		IsFunding:   state.IsFunding,
+		Disabled:    state.Disabled,
 		CreatedAt:   state.CreatedAt,
 		UpdatedAt:   state.UpdatedAt,
 	}
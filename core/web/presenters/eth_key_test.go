@@ -34,6 +34,7 @@ func TestETHKeyResource(t *testing.T) {
 		UpdatedAt:  now,
 		NextNonce:  nextNonce,
 		IsFunding:  true,
+		Disabled:   true,
 	}
 
 	r, err := NewETHKeyResource(key, state,
@@ -61,6 +62,7 @@ func TestETHKeyResource(t *testing.T) {
 			  "ethBalance":"1",
 			  "linkBalance":"1",
 			  "isFunding":true,
+			  "disabled":true,
 			  "createdAt":"2000-01-01T00:00:00Z",
 			  "updatedAt":"2000-01-01T00:00:00Z",
 			  "maxGasPriceWei":"12345"
@@ -91,6 +93,7 @@ func TestETHKeyResource(t *testing.T) {
 				"ethBalance":"1",
 				"linkBalance":"1",
 				"isFunding":true,
+				"disabled":true,
 				"createdAt":"2000-01-01T00:00:00Z",
 				"updatedAt":"2000-01-01T00:00:00Z",
 				"maxGasPriceWei":"12345"
@@ -28,3 +28,23 @@ func NewUserResource(u sessions.User) *UserResource {
 		CreatedAt: u.CreatedAt,
 	}
 }
+
+// TOTPEnrollmentResource represents the result of enrolling a User in TOTP
+// two-factor authentication.
+type TOTPEnrollmentResource struct {
+	JAID
+	ProvisioningURI string `json:"provisioningURI"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r TOTPEnrollmentResource) GetName() string {
+	return "totpEnrollments"
+}
+
+// NewTOTPEnrollmentResource constructs a new TOTPEnrollmentResource.
+func NewTOTPEnrollmentResource(u sessions.User, provisioningURI string) *TOTPEnrollmentResource {
+	return &TOTPEnrollmentResource{
+		JAID:            NewJAID(u.Email),
+		ProvisioningURI: provisioningURI,
+	}
+}
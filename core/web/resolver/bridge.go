@@ -1,12 +1,15 @@
 package resolver
 
 import (
+	"context"
 	"database/sql"
+	"sort"
 
 	"github.com/graph-gophers/graphql-go"
 	"github.com/pkg/errors"
 
 	"github.com/smartcontractkit/chainlink/core/bridges"
+	"github.com/smartcontractkit/chainlink/core/web/loader"
 )
 
 // BridgeResolver resolves the Bridge type.
@@ -57,6 +60,19 @@ func (r *BridgeResolver) CreatedAt() graphql.Time {
 	return graphql.Time{Time: r.bridge.CreatedAt}
 }
 
+// JobsCount resolves the number of jobs whose pipelines call the bridge.
+//
+// Jobs are counted through a dataloader, so listing many bridges only queries
+// the jobs once.
+func (r *BridgeResolver) JobsCount(ctx context.Context) (int32, error) {
+	jids, err := loader.GetJobIDsByBridgeName(ctx, r.bridge.Name.String())
+	if err != nil {
+		return 0, err
+	}
+
+	return int32(len(jids)), nil
+}
+
 // BridgePayloadResolver resolves a single bridge response
 type BridgePayloadResolver struct {
 	bridge bridges.BridgeType
@@ -90,14 +106,16 @@ func (r *BridgePayloadResolver) ToNotFoundError() (*NotFoundErrorResolver, bool)
 
 // BridgesPayloadResolver resolves a page of bridges
 type BridgesPayloadResolver struct {
-	bridges []bridges.BridgeType
-	total   int32
+	bridges     []bridges.BridgeType
+	total       int32
+	hasNextPage bool
 }
 
-func NewBridgesPayload(bridges []bridges.BridgeType, total int32) *BridgesPayloadResolver {
+func NewBridgesPayload(bridges []bridges.BridgeType, total int32, hasNextPage bool) *BridgesPayloadResolver {
 	return &BridgesPayloadResolver{
-		bridges: bridges,
-		total:   total,
+		bridges:     bridges,
+		total:       total,
+		hasNextPage: hasNextPage,
 	}
 }
 
@@ -111,21 +129,60 @@ func (r *BridgesPayloadResolver) Metadata() *PaginationMetadataResolver {
 	return NewPaginationMetadata(r.total)
 }
 
+// PageInfo returns the cursor pagination info.
+func (r *BridgesPayloadResolver) PageInfo() *PageInfoResolver {
+	var endCursor *string
+	if len(r.bridges) > 0 {
+		cursor := encodeCursor(r.bridges[len(r.bridges)-1].Name.String())
+		endCursor = &cursor
+	}
+
+	return NewPageInfo(endCursor, r.hasNextPage)
+}
+
 // CreateBridgePayloadResolver
 type CreateBridgePayloadResolver struct {
-	bridge        bridges.BridgeType
+	bridge        *bridges.BridgeType
 	incomingToken string
+	// inputErrs maps an input path to its error message
+	inputErrs map[string]string
 }
 
-func NewCreateBridgePayload(bridge bridges.BridgeType, incomingToken string) *CreateBridgePayloadResolver {
+func NewCreateBridgePayload(bridge *bridges.BridgeType, incomingToken string, inputErrs map[string]string) *CreateBridgePayloadResolver {
 	return &CreateBridgePayloadResolver{
 		bridge:        bridge,
 		incomingToken: incomingToken,
+		inputErrs:     inputErrs,
 	}
 }
 
 func (r *CreateBridgePayloadResolver) ToCreateBridgeSuccess() (*CreateBridgeSuccessResolver, bool) {
-	return NewCreateBridgeSuccessResolver(r.bridge, r.incomingToken), true
+	if r.bridge == nil {
+		return nil, false
+	}
+
+	return NewCreateBridgeSuccessResolver(*r.bridge, r.incomingToken), true
+}
+
+// ToInputErrors resolves the input errors, sorted by path so that they are
+// returned in a stable order.
+func (r *CreateBridgePayloadResolver) ToInputErrors() (*InputErrorsResolver, bool) {
+	if r.inputErrs == nil {
+		return nil, false
+	}
+
+	paths := make([]string, 0, len(r.inputErrs))
+	for path := range r.inputErrs {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	errs := []*InputErrorResolver{}
+	for _, path := range paths {
+		errs = append(errs, NewInputError(path, r.inputErrs[path]))
+	}
+
+	return NewInputErrors(errs), true
 }
 
 type CreateBridgeSuccessResolver struct {
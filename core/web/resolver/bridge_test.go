@@ -1,11 +1,13 @@
 package resolver
 
 import (
-	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/url"
 	"testing"
 
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -33,6 +35,33 @@ func Test_Bridges(t *testing.T) {
 					metadata {
 						total
 					}
+					pageInfo {
+						endCursor
+						hasNextPage
+					}
+				}
+			}`
+		cursorQuery = `
+			query GetBridges {
+				bridges(after: "YnJpZGdlMA==", first: 1) {
+					results {
+						name
+					}
+					metadata {
+						total
+					}
+					pageInfo {
+						endCursor
+						hasNextPage
+					}
+				}
+			}`
+		firstQuery = `
+			query GetBridges($first: Int) {
+				bridges(first: $first) {
+					results {
+						name
+					}
 				}
 			}`
 	)
@@ -46,8 +75,9 @@ func Test_Bridges(t *testing.T) {
 			name:          "success",
 			authenticated: true,
 			before: func(f *gqlTestFramework) {
+				f.mockPageLimits(50, 1000)
 				f.App.On("BridgeORM").Return(f.Mocks.bridgeORM)
-				f.Mocks.bridgeORM.On("BridgeTypes", PageDefaultOffset, PageDefaultLimit).Return([]bridges.BridgeType{
+				f.Mocks.bridgeORM.On("BridgeTypes", PageDefaultOffset, 50, bridges.BridgeTypesSort{}).Return([]bridges.BridgeType{
 					{
 						Name:                   "bridge1",
 						URL:                    models.WebURL(*bridgeURL),
@@ -72,15 +102,290 @@ func Test_Bridges(t *testing.T) {
 					}],
 					"metadata": {
 						"total": 1
+					},
+					"pageInfo": {
+						"endCursor": "YnJpZGdlMQ==",
+						"hasNextPage": false
+					}
+				}
+			}`,
+		},
+		{
+			name:          "success with cursor",
+			authenticated: true,
+			before: func(f *gqlTestFramework) {
+				f.App.On("BridgeORM").Return(f.Mocks.bridgeORM)
+				f.Mocks.bridgeORM.On("BridgeTypesAfter", bridges.TaskType("bridge0"), 2).Return([]bridges.BridgeType{
+					{Name: "bridge1"},
+					{Name: "bridge2"},
+				}, 3, nil)
+			},
+			query: cursorQuery,
+			result: `
+			{
+				"bridges": {
+					"results": [{
+						"name": "bridge1"
+					}],
+					"metadata": {
+						"total": 3
+					},
+					"pageInfo": {
+						"endCursor": "YnJpZGdlMQ==",
+						"hasNextPage": true
 					}
 				}
 			}`,
 		},
+		{
+			name:          "zero first",
+			authenticated: true,
+			query:         firstQuery,
+			variables:     map[string]interface{}{"first": 0},
+			result:        `null`,
+			errors: []*gqlerrors.QueryError{{
+				ResolverError: errors.New("first must be at least 1"),
+				Path:          []interface{}{"bridges"},
+				Message:       "first must be at least 1",
+			}},
+		},
+		{
+			name:          "negative first",
+			authenticated: true,
+			query:         firstQuery,
+			variables:     map[string]interface{}{"first": -1},
+			result:        `null`,
+			errors: []*gqlerrors.QueryError{{
+				ResolverError: errors.New("first must be at least 1"),
+				Path:          []interface{}{"bridges"},
+				Message:       "first must be at least 1",
+			}},
+		},
 	}
 
 	RunGQLTests(t, testCases)
 }
 
+func Test_Bridges_JobsCount(t *testing.T) {
+	t.Parallel()
+
+	query := `
+		query GetBridges {
+			bridges {
+				results {
+					name
+					jobsCount
+				}
+			}
+		}`
+
+	testCases := []GQLTestCase{
+		{
+			name:          "success",
+			authenticated: true,
+			before: func(f *gqlTestFramework) {
+				f.mockPageLimits(50, 1000)
+				f.App.On("BridgeORM").Return(f.Mocks.bridgeORM)
+				f.App.On("JobORM").Return(f.Mocks.jobORM)
+				f.Mocks.bridgeORM.On("BridgeTypes", PageDefaultOffset, 50, bridges.BridgeTypesSort{}).Return([]bridges.BridgeType{
+					{Name: "bridge1"},
+					{Name: "bridge2"},
+					{Name: "bridge3"},
+				}, 3, nil)
+				f.Mocks.jobORM.On("FindJobIDsWithBridges", mock.Anything).Return(map[string][]int32{
+					"bridge1": {1},
+					"bridge2": {1, 2, 3},
+				}, nil).Once()
+			},
+			query: query,
+			result: `
+			{
+				"bridges": {
+					"results": [{
+						"name": "bridge1",
+						"jobsCount": 1
+					}, {
+						"name": "bridge2",
+						"jobsCount": 3
+					}, {
+						"name": "bridge3",
+						"jobsCount": 0
+					}]
+				}
+			}`,
+		},
+	}
+
+	RunGQLTests(t, testCases)
+}
+
+func Test_Bridges_Sort(t *testing.T) {
+	t.Parallel()
+
+	query := `
+		query GetBridges($sortBy: BridgeSortColumn, $sortDirection: SortDirection) {
+			bridges(sortBy: $sortBy, sortDirection: $sortDirection) {
+				results {
+					name
+				}
+			}
+		}`
+	result := `
+		{
+			"bridges": {
+				"results": [{
+					"name": "bridge1"
+				}]
+			}
+		}`
+
+	var testCases []GQLTestCase
+	for sortBy, column := range map[string]bridges.BridgeTypesSortColumn{
+		"NAME":                     bridges.BridgeTypesSortName,
+		"CREATED_AT":               bridges.BridgeTypesSortCreatedAt,
+		"MINIMUM_CONTRACT_PAYMENT": bridges.BridgeTypesSortMinimumContractPayment,
+	} {
+		for direction, descending := range map[string]bool{"ASC": false, "DESC": true} {
+			sort := bridges.BridgeTypesSort{Column: column, Descending: descending}
+			testCases = append(testCases, GQLTestCase{
+				name:          fmt.Sprintf("sort by %s %s", sortBy, direction),
+				authenticated: true,
+				before: func(f *gqlTestFramework) {
+					f.mockPageLimits(50, 1000)
+					f.App.On("BridgeORM").Return(f.Mocks.bridgeORM)
+					f.Mocks.bridgeORM.On("BridgeTypes", PageDefaultOffset, 50, sort).
+						Return([]bridges.BridgeType{{Name: "bridge1"}}, 1, nil)
+				},
+				query:     query,
+				variables: map[string]interface{}{"sortBy": sortBy, "sortDirection": direction},
+				result:    result,
+			})
+		}
+	}
+
+	testCases = append(testCases, GQLTestCase{
+		name:          "invalid sort column",
+		authenticated: true,
+		query:         query,
+		variables:     map[string]interface{}{"sortBy": "INCOMING_TOKEN_HASH"},
+		errors: []*gqlerrors.QueryError{{
+			Message:   "Variable \"sortBy\" has invalid value INCOMING_TOKEN_HASH.\nExpected type \"BridgeSortColumn\", found INCOMING_TOKEN_HASH.",
+			Locations: []gqlerrors.Location{{Line: 2, Column: 20}},
+			Rule:      "VariablesOfCorrectType",
+		}},
+	})
+
+	RunGQLTests(t, testCases)
+}
+
+func Test_toBridgeTypesSort(t *testing.T) {
+	t.Parallel()
+
+	str := func(s string) *string { return &s }
+
+	sort, err := toBridgeTypesSort(nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, bridges.BridgeTypesSort{}, sort)
+
+	sort, err = toBridgeTypesSort(str("CREATED_AT"), str("DESC"))
+	require.NoError(t, err)
+	assert.Equal(t, bridges.BridgeTypesSort{Column: bridges.BridgeTypesSortCreatedAt, Descending: true}, sort)
+
+	_, err = toBridgeTypesSort(str("incoming_token_hash"), nil)
+	require.EqualError(t, err, `invalid sort column "incoming_token_hash"`)
+
+	_, err = toBridgeTypesSort(nil, str("SIDEWAYS"))
+	require.EqualError(t, err, `invalid sort direction "SIDEWAYS"`)
+}
+
+func Test_Bridges_WalkCursors(t *testing.T) {
+	t.Parallel()
+
+	query := `
+		query GetBridges($after: String) {
+			bridges(after: $after, first: 10) {
+				results {
+					name
+				}
+				metadata {
+					total
+				}
+				pageInfo {
+					endCursor
+					hasNextPage
+				}
+			}
+		}`
+
+	var all []bridges.BridgeType
+	for i := 0; i < 25; i++ {
+		all = append(all, bridges.BridgeType{Name: bridges.TaskType(fmt.Sprintf("bridge%02d", i))})
+	}
+
+	f := setupFramework(t)
+	f.injectAuthenticatedUser()
+	f.mockPageLimits(50, 1000)
+	f.App.On("BridgeORM").Return(f.Mocks.bridgeORM)
+	f.Mocks.bridgeORM.On("BridgeTypesAfter", mock.Anything, mock.Anything).Return(
+		func(after bridges.TaskType, limit int) (page []bridges.BridgeType) {
+			for _, bt := range all {
+				if bt.Name > after && len(page) < limit {
+					page = append(page, bt)
+				}
+			}
+			return page
+		},
+		len(all),
+		nil,
+	)
+
+	var (
+		seen  []string
+		after *string
+		pages int
+	)
+	for {
+		variables := map[string]interface{}{}
+		if after != nil {
+			variables["after"] = *after
+		}
+		resp := f.RootSchema.Exec(f.Ctx, query, "", variables)
+		require.Empty(t, resp.Errors)
+
+		var data struct {
+			Bridges struct {
+				Results []struct {
+					Name string
+				}
+				Metadata struct {
+					Total int
+				}
+				PageInfo struct {
+					EndCursor   *string
+					HasNextPage bool
+				}
+			}
+		}
+		require.NoError(t, json.Unmarshal(resp.Data, &data))
+		assert.Equal(t, len(all), data.Bridges.Metadata.Total)
+		for _, r := range data.Bridges.Results {
+			seen = append(seen, r.Name)
+		}
+		pages++
+
+		if !data.Bridges.PageInfo.HasNextPage {
+			break
+		}
+		after = data.Bridges.PageInfo.EndCursor
+	}
+
+	assert.Equal(t, 3, pages)
+	require.Len(t, seen, len(all))
+	for i, bt := range all {
+		assert.Equal(t, bt.Name.String(), seen[i])
+	}
+}
+
 func Test_Bridge(t *testing.T) {
 	t.Parallel()
 
@@ -141,7 +446,7 @@ func Test_Bridge(t *testing.T) {
 			authenticated: true,
 			before: func(f *gqlTestFramework) {
 				f.App.On("BridgeORM").Return(f.Mocks.bridgeORM)
-				f.Mocks.bridgeORM.On("FindBridge", name).Return(bridges.BridgeType{}, sql.ErrNoRows)
+				f.Mocks.bridgeORM.On("FindBridge", name).Return(bridges.BridgeType{}, bridges.ErrBridgeNotFound)
 			},
 			query: query,
 			result: `{
@@ -174,6 +479,13 @@ func Test_CreateBridge(t *testing.T) {
 							createdAt
 						}
 					}
+					... on InputErrors {
+						errors {
+							path
+							message
+							code
+						}
+					}
 				}
 			}`
 		variables = map[string]interface{}{
@@ -195,7 +507,7 @@ func Test_CreateBridge(t *testing.T) {
 			authenticated: true,
 			before: func(f *gqlTestFramework) {
 				f.App.On("BridgeORM").Return(f.Mocks.bridgeORM)
-				f.Mocks.bridgeORM.On("FindBridge", name).Return(bridges.BridgeType{}, sql.ErrNoRows)
+				f.Mocks.bridgeORM.On("FindBridge", name).Return(bridges.BridgeType{}, bridges.ErrBridgeNotFound)
 				f.Mocks.bridgeORM.On("CreateBridgeType", mock.IsType(&bridges.BridgeType{})).
 					Run(func(args mock.Arguments) {
 						arg := args.Get(0).(*bridges.BridgeType)
@@ -209,6 +521,9 @@ func Test_CreateBridge(t *testing.T) {
 						}
 					}).
 					Return(nil)
+				f.App.On("GetConfig").Return(f.Mocks.cfg)
+				f.Mocks.cfg.On("BridgeMaxMinimumContractPayment").Return(assets.LinkTotalSupply())
+				f.Mocks.cfg.On("BridgeURLReachabilityCheck").Return(false)
 			},
 			query:     mutation,
 			variables: variables,
@@ -229,6 +544,154 @@ func Test_CreateBridge(t *testing.T) {
 				}
 			`,
 		},
+		{
+			name:          "invalid url scheme",
+			authenticated: true,
+			before: func(f *gqlTestFramework) {
+				f.App.On("BridgeORM").Return(f.Mocks.bridgeORM)
+				f.Mocks.bridgeORM.On("FindBridge", name).Return(bridges.BridgeType{}, bridges.ErrBridgeNotFound)
+				f.App.On("GetConfig").Return(f.Mocks.cfg)
+				f.Mocks.cfg.On("BridgeMaxMinimumContractPayment").Return(assets.LinkTotalSupply())
+			},
+			query: mutation,
+			variables: map[string]interface{}{
+				"input": map[string]interface{}{
+					"name":                   "bridge1",
+					"url":                    "ftp://external.adapter",
+					"confirmations":          1,
+					"minimumContractPayment": "1",
+				},
+			},
+			result: `
+				{
+					"createBridge": {
+						"errors": [{
+							"path": "input/url",
+							"message": "URL scheme must be http or https, got ftp",
+							"code": "INVALID_INPUT"
+						}]
+					}
+				}
+			`,
+		},
+		{
+			name:          "multiple invalid fields",
+			authenticated: true,
+			before: func(f *gqlTestFramework) {
+				f.App.On("BridgeORM").Return(f.Mocks.bridgeORM)
+				f.App.On("GetConfig").Return(f.Mocks.cfg)
+				f.Mocks.cfg.On("BridgeMaxMinimumContractPayment").Return(assets.LinkTotalSupply())
+			},
+			query: mutation,
+			variables: map[string]interface{}{
+				"input": map[string]interface{}{
+					"name":                   "invalid/bridge",
+					"url":                    "",
+					"confirmations":          1,
+					"minimumContractPayment": "-1",
+				},
+			},
+			result: `
+				{
+					"createBridge": {
+						"errors": [{
+							"path": "input/minimumContractPayment",
+							"message": "MinimumContractPayment must be positive",
+							"code": "INVALID_INPUT"
+						}, {
+							"path": "input/name",
+							"message": "invalid bridge name: task type validation: name invalid/bridge contains invalid characters",
+							"code": "INVALID_INPUT"
+						}, {
+							"path": "input/url",
+							"message": "url must be present",
+							"code": "INVALID_INPUT"
+						}]
+					}
+				}
+			`,
+		},
+		{
+			name:          "bridge already exists",
+			authenticated: true,
+			before: func(f *gqlTestFramework) {
+				f.App.On("BridgeORM").Return(f.Mocks.bridgeORM)
+				f.Mocks.bridgeORM.On("FindBridge", name).Return(bridges.BridgeType{Name: name}, nil)
+				f.App.On("GetConfig").Return(f.Mocks.cfg)
+				f.Mocks.cfg.On("BridgeMaxMinimumContractPayment").Return(assets.LinkTotalSupply())
+			},
+			query: mutation,
+			variables: map[string]interface{}{
+				"input": map[string]interface{}{
+					"name":                   "bridge1",
+					"url":                    "https://external.adapter",
+					"confirmations":          1,
+					"minimumContractPayment": "not a number",
+				},
+			},
+			result: `
+				{
+					"createBridge": {
+						"errors": [{
+							"path": "input/minimumContractPayment",
+							"message": "assets: cannot unmarshal \"not a number\" into a *assets.Link",
+							"code": "INVALID_INPUT"
+						}, {
+							"path": "input/name",
+							"message": "bridge type bridge1 already exists",
+							"code": "INVALID_INPUT"
+						}]
+					}
+				}
+			`,
+		},
+		{
+			name:          "bridge already exists but is archived",
+			authenticated: true,
+			before: func(f *gqlTestFramework) {
+				f.App.On("BridgeORM").Return(f.Mocks.bridgeORM)
+				f.Mocks.bridgeORM.On("FindBridge", name).Return(bridges.BridgeType{}, bridges.ErrBridgeNotFound)
+				f.Mocks.bridgeORM.On("CreateBridgeType", mock.IsType(&bridges.BridgeType{})).Return(bridges.ErrBridgeExists)
+				f.App.On("GetConfig").Return(f.Mocks.cfg)
+				f.Mocks.cfg.On("BridgeMaxMinimumContractPayment").Return(assets.LinkTotalSupply())
+			},
+			query:     mutation,
+			variables: variables,
+			result: `
+				{
+					"createBridge": {
+						"errors": [{
+							"path": "input/name",
+							"message": "bridge type bridge1 already exists, but is archived",
+							"code": "INVALID_INPUT"
+						}]
+					}
+				}
+			`,
+		},
+		{
+			name:          "minimum contract payment above the configured maximum",
+			authenticated: true,
+			before: func(f *gqlTestFramework) {
+				f.App.On("BridgeORM").Return(f.Mocks.bridgeORM)
+				f.Mocks.bridgeORM.On("FindBridge", name).Return(bridges.BridgeType{}, bridges.ErrBridgeNotFound)
+				f.App.On("GetConfig").Return(f.Mocks.cfg)
+				f.Mocks.cfg.On("BridgeMaxMinimumContractPayment").Return(assets.NewLinkFromJuels(0))
+			},
+			query:     mutation,
+			variables: variables,
+			result: `
+				{
+					"createBridge": {
+						"errors": [{
+							"path": "input/minimumContractPayment",
+							"message": "MinimumContractPayment must not exceed 0 juels, got 1",
+							"code": "INVALID_INPUT"
+						}]
+					}
+				}
+			`,
+		},
 	}
 
 	RunGQLTests(t, testCases)
@@ -291,6 +754,8 @@ func Test_UpdateBridge(t *testing.T) {
 
 				f.App.On("BridgeORM").Return(f.Mocks.bridgeORM)
 				f.Mocks.bridgeORM.On("FindBridge", name).Return(bridge, nil)
+				f.App.On("GetConfig").Return(f.Mocks.cfg)
+				f.Mocks.cfg.On("BridgeMaxMinimumContractPayment").Return(assets.LinkTotalSupply())
 
 				btr := &bridges.BridgeTypeRequest{
 					Name:                   bridges.TaskType("bridge-updated"),
@@ -333,7 +798,7 @@ func Test_UpdateBridge(t *testing.T) {
 			authenticated: true,
 			before: func(f *gqlTestFramework) {
 				f.App.On("BridgeORM").Return(f.Mocks.bridgeORM)
-				f.Mocks.bridgeORM.On("FindBridge", name).Return(bridges.BridgeType{}, sql.ErrNoRows)
+				f.Mocks.bridgeORM.On("FindBridge", name).Return(bridges.BridgeType{}, bridges.ErrBridgeNotFound)
 			},
 			query:     mutation,
 			variables: variables,
@@ -407,7 +872,7 @@ func Test_DeleteBridgeMutation(t *testing.T) {
 				}
 
 				f.Mocks.bridgeORM.On("FindBridge", name).Return(bridge, nil)
-				f.Mocks.bridgeORM.On("DeleteBridgeType", &bridge).Return(nil)
+				f.Mocks.bridgeORM.On("DeleteBridgeType", &bridge, false).Return(nil)
 				f.Mocks.jobORM.On("FindJobIDsWithBridge", name.String()).Return([]int32{}, nil)
 				f.App.On("JobORM").Return(f.Mocks.jobORM)
 				f.App.On("BridgeORM").Return(f.Mocks.bridgeORM)
@@ -450,7 +915,7 @@ func Test_DeleteBridgeMutation(t *testing.T) {
 				"name": "bridge1",
 			},
 			before: func(f *gqlTestFramework) {
-				f.Mocks.bridgeORM.On("FindBridge", name).Return(bridges.BridgeType{}, sql.ErrNoRows)
+				f.Mocks.bridgeORM.On("FindBridge", name).Return(bridges.BridgeType{}, bridges.ErrBridgeNotFound)
 				f.App.On("BridgeORM").Return(f.Mocks.bridgeORM)
 			},
 			result: `
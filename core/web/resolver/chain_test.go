@@ -37,8 +37,9 @@ func Test_Chains(t *testing.T) {
 			name:          "success",
 			authenticated: true,
 			before: func(f *gqlTestFramework) {
+				f.mockPageLimits(50, 1000)
 				f.App.On("EVMORM").Return(f.Mocks.evmORM)
-				f.Mocks.evmORM.On("Chains", PageDefaultOffset, PageDefaultLimit).Return([]types.Chain{
+				f.Mocks.evmORM.On("Chains", PageDefaultOffset, 50).Return([]types.Chain{
 					{
 						ID:        chainID,
 						Enabled:   true,
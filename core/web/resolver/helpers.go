@@ -1,26 +1,32 @@
 package resolver
 
 import (
-	"database/sql"
+	"encoding/base64"
 	"fmt"
+	"net/url"
 	"strconv"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/graph-gophers/graphql-go"
 	"github.com/pkg/errors"
 
 	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/bridges"
+	"github.com/smartcontractkit/chainlink/core/store/models"
 )
 
 const (
 	// PageDefaultOffset defines the default offset to use if none is provided
 	PageDefaultOffset = 0
-
-	// PageDefaultLimit defines the default limit to use if none is provided
-	PageDefaultLimit = 50
 )
 
+// pageLimitConfig configures the limits of paginated queries
+type pageLimitConfig interface {
+	GraphQLPageDefaultLimit() int
+	GraphQLPageMaxLimit() int
+}
+
 func int32GQLID(i int32) graphql.ID {
 	return graphql.ID(strconv.Itoa(int(i)))
 }
@@ -35,14 +41,82 @@ func pageOffset(offset *int) int {
 	return *offset
 }
 
-// pageLimit returns the default page limit if nil, otherwise it returns the
-// provided limit.
-func pageLimit(limit *int) int {
-	if limit == nil {
-		return PageDefaultLimit
+// pageLimit returns the configured default page limit if nil, otherwise it
+// returns the provided limit. Either is clamped between 1 and the configured
+// maximum.
+func pageLimit(cfg pageLimitConfig, limit *int) int {
+	l := cfg.GraphQLPageDefaultLimit()
+	if limit != nil {
+		l = *limit
+	}
+	if l < 1 {
+		return 1
+	}
+	if max := cfg.GraphQLPageMaxLimit(); l > max {
+		return max
+	}
+
+	return l
+}
+
+// eip55String renders addr in hex with its EIP-55 checksum casing, as wallets
+// display it.
+func eip55String(addr common.Address) string {
+	return addr.Hex()
+}
+
+// eip55Strings renders each of addrs with eip55String.
+func eip55Strings(addrs []common.Address) []string {
+	strs := make([]string, len(addrs))
+	for i, addr := range addrs {
+		strs[i] = eip55String(addr)
+	}
+	return strs
+}
+
+// encodeCursor encodes the sort key of a row into an opaque cursor.
+func encodeCursor(key string) string {
+	return base64.URLEncoding.EncodeToString([]byte(key))
+}
+
+// decodeCursor decodes a cursor created by encodeCursor back into the sort
+// key of a row.
+func decodeCursor(cursor string) (string, error) {
+	key, err := base64.URLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", errors.New("invalid cursor")
+	}
+
+	return string(key), nil
+}
+
+// toBridgeTypesSort converts the sort arguments of a bridges query into a
+// bridges.BridgeTypesSort, defaulting to sorting by name ascending.
+func toBridgeTypesSort(sortBy, direction *string) (sort bridges.BridgeTypesSort, err error) {
+	if sortBy != nil {
+		switch *sortBy {
+		case "NAME":
+			sort.Column = bridges.BridgeTypesSortName
+		case "CREATED_AT":
+			sort.Column = bridges.BridgeTypesSortCreatedAt
+		case "MINIMUM_CONTRACT_PAYMENT":
+			sort.Column = bridges.BridgeTypesSortMinimumContractPayment
+		default:
+			return sort, fmt.Errorf("invalid sort column %q", *sortBy)
+		}
+	}
+
+	if direction != nil {
+		switch *direction {
+		case "ASC":
+		case "DESC":
+			sort.Descending = true
+		default:
+			return sort, fmt.Errorf("invalid sort direction %q", *direction)
+		}
 	}
 
-	return *limit
+	return sort, nil
 }
 
 // ValidateBridgeTypeUniqueness checks that a bridge has not already been created
@@ -53,7 +127,7 @@ func ValidateBridgeTypeUniqueness(bt *bridges.BridgeTypeRequest, orm bridges.ORM
 	if err == nil {
 		return fmt.Errorf("bridge type %v already exists", bt.Name)
 	}
-	if err != nil && err != sql.ErrNoRows {
+	if err != nil && !errors.Is(err, bridges.ErrBridgeNotFound) {
 		return fmt.Errorf("error determining if bridge type %v already exists", bt.Name)
 	}
 
@@ -61,26 +135,86 @@ func ValidateBridgeTypeUniqueness(bt *bridges.BridgeTypeRequest, orm bridges.ORM
 }
 
 // ValidateBridgeType checks that the bridge type doesn't have a duplicate
-// or invalid name or invalid url
+// or invalid name or invalid url, and that its MinimumContractPayment does not
+// exceed maxMinimumContractPayment
 //
 // This validation function should be moved into a bridge service and return
 // multiple errors.
-func ValidateBridgeType(bt *bridges.BridgeTypeRequest, orm bridges.ORM) error {
-	if len(bt.Name.String()) < 1 {
-		return errors.New("No name specified")
+func ValidateBridgeType(bt *bridges.BridgeTypeRequest, orm bridges.ORM, maxMinimumContractPayment *assets.Link) error {
+	if err := validateBridgeName(bt.Name); err != nil {
+		return err
 	}
-	if _, err := bridges.NewTaskType(bt.Name.String()); err != nil {
-		return errors.Wrap(err, "invalid bridge name")
+	if err := validateBridgeURL(bt.URL); err != nil {
+		return err
 	}
-	u := bt.URL.String()
-	if len(strings.TrimSpace(u)) == 0 {
-		return errors.New("url must be present")
+	if err := bridges.ValidateMinimumContractPayment(bt.MinimumContractPayment, maxMinimumContractPayment); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateCreateBridgeInput parses input into a bridge type request, then
+// runs the checks of ValidateBridgeType and ValidateBridgeTypeUniqueness on
+// it. Rather than stopping at the first failure, it returns the first error
+// of every invalid field keyed by its input path, or nil if there are none.
+func validateCreateBridgeInput(input createBridgeInput, orm bridges.ORM, maxMinimumContractPayment *assets.Link) (*bridges.BridgeTypeRequest, map[string]string) {
+	inputErrs := map[string]string{}
+	btr := &bridges.BridgeTypeRequest{
+		Name:          bridges.TaskType(input.Name),
+		Confirmations: uint32(input.Confirmations),
+	}
+
+	if err := validateBridgeName(btr.Name); err != nil {
+		inputErrs["input/name"] = err.Error()
+	} else if err := ValidateBridgeTypeUniqueness(btr, orm); err != nil {
+		inputErrs["input/name"] = err.Error()
+	}
+
+	if len(input.URL) != 0 {
+		u, err := url.ParseRequestURI(input.URL)
+		if err != nil {
+			inputErrs["input/url"] = err.Error()
+		} else {
+			btr.URL = models.WebURL(*u)
+		}
+	}
+	if _, ok := inputErrs["input/url"]; !ok {
+		if err := validateBridgeURL(btr.URL); err != nil {
+			inputErrs["input/url"] = err.Error()
+		}
+	}
+
+	minContractPayment := &assets.Link{}
+	if err := minContractPayment.UnmarshalText([]byte(input.MinimumContractPayment)); err != nil {
+		inputErrs["input/minimumContractPayment"] = err.Error()
+	} else if err := bridges.ValidateMinimumContractPayment(minContractPayment, maxMinimumContractPayment); err != nil {
+		inputErrs["input/minimumContractPayment"] = err.Error()
+	} else {
+		btr.MinimumContractPayment = minContractPayment
+	}
+
+	if len(inputErrs) == 0 {
+		return btr, nil
 	}
-	if bt.MinimumContractPayment != nil &&
-		bt.MinimumContractPayment.Cmp(assets.NewLinkFromJuels(0)) < 0 {
+	return btr, inputErrs
+}
 
-		return errors.New("MinimumContractPayment must be positive")
+func validateBridgeName(name bridges.TaskType) error {
+	if len(name.String()) < 1 {
+		return errors.New("No name specified")
+	}
+	if _, err := bridges.NewTaskType(name.String()); err != nil {
+		return errors.Wrap(err, "invalid bridge name")
 	}
 
 	return nil
 }
+
+func validateBridgeURL(u models.WebURL) error {
+	if len(strings.TrimSpace(u.String())) == 0 {
+		return errors.New("url must be present")
+	}
+
+	return bridges.ValidateURL((*url.URL)(&u))
+}
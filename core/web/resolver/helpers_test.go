@@ -0,0 +1,53 @@
+package resolver
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+
+	configMocks "github.com/smartcontractkit/chainlink/core/config/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/keystore/keys/ethkey"
+)
+
+func TestPageLimit(t *testing.T) {
+	t.Parallel()
+
+	cfg := &configMocks.GeneralConfig{}
+	cfg.On("GraphQLPageDefaultLimit").Return(50)
+	cfg.On("GraphQLPageMaxLimit").Return(100)
+
+	limit := func(l int) *int { return &l }
+
+	assert.Equal(t, 50, pageLimit(cfg, nil))
+	assert.Equal(t, 1, pageLimit(cfg, limit(0)))
+	assert.Equal(t, 1, pageLimit(cfg, limit(-1)))
+	assert.Equal(t, 1, pageLimit(cfg, limit(1)))
+	assert.Equal(t, 100, pageLimit(cfg, limit(100)))
+	assert.Equal(t, 100, pageLimit(cfg, limit(101)))
+	assert.Equal(t, 100, pageLimit(cfg, limit(1000000)))
+}
+
+func TestEIP55String(t *testing.T) {
+	t.Parallel()
+
+	// Test vectors from EIP-55
+	tests := []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+	}
+	for _, expected := range tests {
+		lower := common.HexToAddress(expected)
+		assert.Equal(t, expected, eip55String(lower))
+	}
+
+	// An EIP55Address scanned from a lowercase string is still checksummed
+	var addr ethkey.EIP55Address
+	assert.NoError(t, addr.Scan("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"))
+	assert.Equal(t, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", eip55String(addr.Address()))
+
+	assert.Equal(t, tests[:2], eip55Strings([]common.Address{common.HexToAddress(tests[0]), common.HexToAddress(tests[1])}))
+	assert.Empty(t, eip55Strings(nil))
+}
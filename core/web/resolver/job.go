@@ -9,6 +9,7 @@ import (
 	"github.com/graph-gophers/graphql-go"
 
 	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/core/web/loader"
 )
 
@@ -94,6 +95,37 @@ func (r *JobResolver) Runs(ctx context.Context) ([]*JobRunResolver, error) {
 	return NewJobRuns(runs), nil
 }
 
+// Bridges resolves the bridges called by the job's pipeline.
+//
+// Bridges are fetched through a dataloader, so listing many jobs only queries
+// the bridges once.
+func (r *JobResolver) Bridges(ctx context.Context) ([]*BridgeResolver, error) {
+	p, err := pipeline.Parse(r.j.PipelineSpec.DotDagSource)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	seen := map[string]struct{}{}
+	for _, task := range p.Tasks {
+		if task.Type() != pipeline.TaskTypeBridge {
+			continue
+		}
+		name := task.(*pipeline.BridgeTask).Name
+		if _, ok := seen[name]; !ok {
+			seen[name] = struct{}{}
+			names = append(names, name)
+		}
+	}
+
+	bts, err := loader.GetBridgesByNames(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewBridges(bts), nil
+}
+
 // JobsPayloadResolver resolves a page of jobs
 type JobsPayloadResolver struct {
 	jobs  []job.Job
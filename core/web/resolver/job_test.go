@@ -6,8 +6,11 @@ import (
 	"time"
 
 	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"gopkg.in/guregu/null.v4"
 
+	"github.com/smartcontractkit/chainlink/core/bridges"
 	"github.com/smartcontractkit/chainlink/core/services/job"
 	"github.com/smartcontractkit/chainlink/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/core/store/models"
@@ -55,6 +58,7 @@ func TestResolver_Jobs(t *testing.T) {
 			before: func(f *gqlTestFramework) {
 				plnSpecID := int32(12)
 
+				f.mockPageLimits(50, 1000)
 				f.App.On("JobORM").Return(f.Mocks.jobORM)
 				f.Mocks.jobORM.On("PipelineRunsByJobsIDs", []int32{plnSpecID}).Return([]pipeline.Run{
 					{
@@ -119,6 +123,74 @@ func TestResolver_Jobs(t *testing.T) {
 	RunGQLTests(t, testCases)
 }
 
+func TestResolver_JobsBridges(t *testing.T) {
+	query := `
+		query GetJobs {
+			jobs {
+				results {
+					id
+					bridges {
+						name
+					}
+				}
+			}
+		}`
+
+	testCases := []GQLTestCase{
+		{
+			name:          "loads the bridges of all jobs in one batch",
+			authenticated: true,
+			before: func(f *gqlTestFramework) {
+				f.mockPageLimits(50, 1000)
+				f.App.On("JobORM").Return(f.Mocks.jobORM)
+				f.App.On("BridgeORM").Return(f.Mocks.bridgeORM)
+				f.Mocks.jobORM.On("FindJobs", 0, 50).Return([]job.Job{
+					{
+						ID:                          1,
+						Type:                        job.OffchainReporting,
+						OffchainreportingOracleSpec: &job.OffchainReportingOracleSpec{},
+						PipelineSpec: &pipeline.Spec{
+							DotDagSource: "ds1 [type=bridge name=bridge1]; ds2 [type=bridge name=bridge2]; ds1 -> ds2;",
+						},
+					},
+					{
+						ID:                          2,
+						Type:                        job.OffchainReporting,
+						OffchainreportingOracleSpec: &job.OffchainReportingOracleSpec{},
+						PipelineSpec: &pipeline.Spec{
+							DotDagSource: "ds1 [type=bridge name=bridge2]; ds2 [type=bridge name=archived]; ds1 -> ds2;",
+						},
+					},
+				}, 2, nil)
+				f.Mocks.bridgeORM.On("FindBridges", mock.Anything).
+					Run(func(args mock.Arguments) {
+						assert.ElementsMatch(t, []bridges.TaskType{"bridge1", "bridge2", "archived"}, args.Get(0))
+					}).
+					Return(map[bridges.TaskType]bridges.BridgeType{
+						"bridge1": {Name: "bridge1"},
+						"bridge2": {Name: "bridge2"},
+					}, nil).
+					Once()
+			},
+			query: query,
+			result: `
+				{
+					"jobs": {
+						"results": [{
+							"id": "1",
+							"bridges": [{"name": "bridge1"}, {"name": "bridge2"}]
+						}, {
+							"id": "2",
+							"bridges": [{"name": "bridge2"}]
+						}]
+					}
+				}`,
+		},
+	}
+
+	RunGQLTests(t, testCases)
+}
+
 func TestResolver_Job(t *testing.T) {
 	var (
 		id            = int32(1)
@@ -39,48 +39,35 @@ type createBridgeInput struct {
 	MinimumContractPayment string
 }
 
-// CreateBridge creates a new bridge.
+// CreateBridge creates a new bridge, or returns an error for each invalid
+// field of the input.
 func (r *Resolver) CreateBridge(ctx context.Context, args struct{ Input createBridgeInput }) (*CreateBridgePayloadResolver, error) {
 	if err := authenticateUser(ctx); err != nil {
 		return nil, err
 	}
 
-	var webURL models.WebURL
-	if len(args.Input.URL) != 0 {
-		url, err := url.ParseRequestURI(args.Input.URL)
-		if err != nil {
-			return nil, err
-		}
-		webURL = models.WebURL(*url)
-	}
-	minContractPayment := &assets.Link{}
-	if err := minContractPayment.UnmarshalText([]byte(args.Input.MinimumContractPayment)); err != nil {
-		return nil, err
-	}
-
-	btr := &bridges.BridgeTypeRequest{
-		Name:                   bridges.TaskType(args.Input.Name),
-		URL:                    webURL,
-		Confirmations:          uint32(args.Input.Confirmations),
-		MinimumContractPayment: minContractPayment,
+	orm := r.App.BridgeORM()
+	btr, inputErrs := validateCreateBridgeInput(args.Input, orm, r.App.GetConfig().BridgeMaxMinimumContractPayment())
+	if inputErrs != nil {
+		return NewCreateBridgePayload(nil, "", inputErrs), nil
 	}
 
 	bta, bt, err := bridges.NewBridgeType(btr)
 	if err != nil {
 		return nil, err
 	}
-	orm := r.App.BridgeORM()
-	if err = ValidateBridgeType(btr, orm); err != nil {
-		return nil, err
-	}
-	if err = ValidateBridgeTypeUniqueness(btr, orm); err != nil {
+	if err := orm.CreateBridgeType(bt); errors.Is(err, bridges.ErrBridgeExists) {
+		return NewCreateBridgePayload(nil, "", map[string]string{
+			"input/name": fmt.Sprintf("bridge type %v already exists, but is archived", bt.Name),
+		}), nil
+	} else if err != nil {
 		return nil, err
 	}
-	if err := orm.CreateBridgeType(bt); err != nil {
-		return nil, err
+	if r.App.GetConfig().BridgeURLReachabilityCheck() {
+		bridges.WarnIfURLUnreachable(r.App.GetLogger(), bt.Name, (*url.URL)(&bt.URL))
 	}
 
-	return NewCreateBridgePayload(*bt, bta.IncomingToken), nil
+	return NewCreateBridgePayload(bt, bta.IncomingToken, nil), nil
 }
 
 type createFeedsManagerInput struct {
@@ -212,7 +199,7 @@ func (r *Resolver) UpdateBridge(ctx context.Context, args struct {
 	}
 
 	// Update the bridge
-	if err := ValidateBridgeType(btr, orm); err != nil {
+	if err := ValidateBridgeType(btr, orm, r.App.GetConfig().BridgeMaxMinimumContractPayment()); err != nil {
 		return nil, err
 	}
 
@@ -391,7 +378,10 @@ func (r *Resolver) DeleteBridge(ctx context.Context, args struct {
 		return NewDeleteBridgePayload(nil, fmt.Errorf("bridge has jobs associated with it")), nil
 	}
 
-	if err = orm.DeleteBridgeType(&bt); err != nil {
+	if err = orm.DeleteBridgeType(&bt, false); err != nil {
+		if errors.As(err, &bridges.BridgeInUseError{}) {
+			return NewDeleteBridgePayload(nil, fmt.Errorf("bridge has jobs associated with it")), nil
+		}
 		return nil, err
 	}
 
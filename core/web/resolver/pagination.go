@@ -11,3 +11,26 @@ func NewPaginationMetadata(total int32) *PaginationMetadataResolver {
 func (r *PaginationMetadataResolver) Total() int32 {
 	return r.total
 }
+
+// PageInfoResolver resolves the information needed to fetch the page after
+// the current one with cursor-based pagination.
+type PageInfoResolver struct {
+	endCursor   *string
+	hasNextPage bool
+}
+
+// NewPageInfo creates a PageInfoResolver. endCursor is nil for an empty page.
+func NewPageInfo(endCursor *string, hasNextPage bool) *PageInfoResolver {
+	return &PageInfoResolver{endCursor: endCursor, hasNextPage: hasNextPage}
+}
+
+// EndCursor returns the cursor of the last result in the page, to be passed
+// as the `after` argument to fetch the next page.
+func (r *PageInfoResolver) EndCursor() *string {
+	return r.endCursor
+}
+
+// HasNextPage returns true if there are more results after this page.
+func (r *PageInfoResolver) HasNextPage() bool {
+	return r.hasNextPage
+}
@@ -38,23 +38,76 @@ func (r *Resolver) Bridge(ctx context.Context, args struct{ Name string }) (*Bri
 }
 
 // Bridges retrieves a paginated list of bridges.
+//
+// Pages are selected by offset and limit, or by cursor when after or first are
+// provided. Cursor pagination only supports sorting by name.
 func (r *Resolver) Bridges(ctx context.Context, args struct {
-	Offset *int
-	Limit  *int
+	Offset        *int
+	Limit         *int
+	After         *string
+	First         *int32
+	SortBy        *string
+	SortDirection *string
 }) (*BridgesPayloadResolver, error) {
 	if err := authenticateUser(ctx); err != nil {
 		return nil, err
 	}
 
+	sort, err := toBridgeTypesSort(args.SortBy, args.SortDirection)
+	if err != nil {
+		return nil, err
+	}
+
+	if args.After != nil || args.First != nil {
+		if sort.Descending || (sort.Column != "" && sort.Column != bridges.BridgeTypesSortName) {
+			return nil, errors.New("sorting is not supported with cursor pagination")
+		}
+
+		var first *int
+		if args.First != nil {
+			if *args.First < 1 {
+				return nil, errors.New("first must be at least 1")
+			}
+			f := int(*args.First)
+			first = &f
+		}
+
+		return r.bridgesAfter(args.After, pageLimit(r.App.GetConfig(), first))
+	}
+
 	offset := pageOffset(args.Offset)
-	limit := pageLimit(args.Limit)
+	limit := pageLimit(r.App.GetConfig(), args.Limit)
 
-	bridges, count, err := r.App.BridgeORM().BridgeTypes(offset, limit)
+	page, count, err := r.App.BridgeORM().BridgeTypes(offset, limit, sort)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewBridgesPayload(bridges, int32(count)), nil
+	return NewBridgesPayload(page, int32(count), offset+len(page) < count), nil
+}
+
+// bridgesAfter retrieves up to first bridges after the cursor.
+func (r *Resolver) bridgesAfter(after *string, first int) (*BridgesPayloadResolver, error) {
+	var name string
+	if after != nil {
+		var err error
+		if name, err = decodeCursor(*after); err != nil {
+			return nil, err
+		}
+	}
+
+	// Fetch an extra bridge to find out whether there is a next page
+	page, count, err := r.App.BridgeORM().BridgeTypesAfter(bridges.TaskType(name), first+1)
+	if err != nil {
+		return nil, err
+	}
+
+	hasNextPage := len(page) > first
+	if hasNextPage {
+		page = page[:first]
+	}
+
+	return NewBridgesPayload(page, int32(count), hasNextPage), nil
 }
 
 // Chain retrieves a chain by id.
@@ -91,7 +144,7 @@ func (r *Resolver) Chains(ctx context.Context, args struct {
 	}
 
 	offset := pageOffset(args.Offset)
-	limit := pageLimit(args.Limit)
+	limit := pageLimit(r.App.GetConfig(), args.Limit)
 
 	page, count, err := r.App.EVMORM().Chains(offset, limit)
 	if err != nil {
@@ -171,7 +224,7 @@ func (r *Resolver) Jobs(ctx context.Context, args struct {
 	}
 
 	offset := pageOffset(args.Offset)
-	limit := pageLimit(args.Limit)
+	limit := pageLimit(r.App.GetConfig(), args.Limit)
 
 	jobs, count, err := r.App.JobORM().FindJobs(offset, limit)
 	if err != nil {
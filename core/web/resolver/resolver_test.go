@@ -124,6 +124,16 @@ func (f *gqlTestFramework) Timestamp() time.Time {
 	return time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
 }
 
+// mockPageLimits mocks the configured default and maximum limits of
+// paginated queries.
+func (f *gqlTestFramework) mockPageLimits(defaultLimit, maxLimit int) {
+	f.t.Helper()
+
+	f.App.On("GetConfig").Return(f.Mocks.cfg)
+	f.Mocks.cfg.On("GraphQLPageDefaultLimit").Return(defaultLimit)
+	f.Mocks.cfg.On("GraphQLPageMaxLimit").Return(maxLimit)
+}
+
 // injectAuthenticatedUser injects a session into the request context
 func (f *gqlTestFramework) injectAuthenticatedUser() {
 	f.t.Helper()
@@ -81,7 +81,7 @@ type DirectRequestSpecResolver struct {
 
 // ContractAddress resolves the spec's contract address.
 func (r *DirectRequestSpecResolver) ContractAddress() string {
-	return r.spec.ContractAddress.String()
+	return eip55String(r.spec.ContractAddress.Address())
 }
 
 // CreatedAt resolves the spec's created at timestamp.
@@ -122,7 +122,7 @@ func (r *DirectRequestSpecResolver) MinContractPayment() string {
 
 // Requesters resolves the spec's evm chain id.
 func (r *DirectRequestSpecResolver) Requesters() []string {
-	return r.spec.Requesters.ToStrings()
+	return eip55Strings(r.spec.Requesters)
 }
 
 type FluxMonitorSpecResolver struct {
@@ -136,7 +136,7 @@ func (r *FluxMonitorSpecResolver) AbsoluteThreshold() float64 {
 
 // ContractAddress resolves the spec's contract address.
 func (r *FluxMonitorSpecResolver) ContractAddress() string {
-	return r.spec.ContractAddress.String()
+	return eip55String(r.spec.ContractAddress.Address())
 }
 
 // CreatedAt resolves the spec's created at timestamp.
@@ -221,7 +221,7 @@ type KeeperSpecResolver struct {
 
 // ContractAddress resolves the spec's contract address.
 func (r *KeeperSpecResolver) ContractAddress() string {
-	return r.spec.ContractAddress.String()
+	return eip55String(r.spec.ContractAddress.Address())
 }
 
 // CreatedAt resolves the spec's created at timestamp.
@@ -248,7 +248,7 @@ func (r *KeeperSpecResolver) EVMChainID() *string {
 //
 // http://spec.graphql.org/draft/#sec-Field-Selection-Merging
 func (r *KeeperSpecResolver) FromAddress() *string {
-	addr := r.spec.FromAddress.String()
+	addr := eip55String(r.spec.FromAddress.Address())
 
 	return &addr
 }
@@ -270,7 +270,7 @@ func (r *OCRSpecResolver) BlockchainTimeoutEnv() bool {
 
 // ContractAddress resolves the spec's contract address.
 func (r *OCRSpecResolver) ContractAddress() string {
-	return r.spec.ContractAddress.String()
+	return eip55String(r.spec.ContractAddress.Address())
 }
 
 // ContractConfigConfirmations resolves the spec's confirmations config.
@@ -372,7 +372,7 @@ func (r *OCRSpecResolver) TransmitterAddress() *string {
 		return nil
 	}
 
-	addr := r.spec.TransmitterAddress.String()
+	addr := eip55String(r.spec.TransmitterAddress.Address())
 	return &addr
 }
 
@@ -387,7 +387,7 @@ func (r *VRFSpecResolver) MinIncomingConfirmations() int32 {
 
 // CoordinatorAddress resolves the spec's coordinator address.
 func (r *VRFSpecResolver) CoordinatorAddress() string {
-	return r.spec.CoordinatorAddress.String()
+	return eip55String(r.spec.CoordinatorAddress.Address())
 }
 
 // CreatedAt resolves the spec's created at timestamp.
@@ -412,7 +412,7 @@ func (r *VRFSpecResolver) FromAddress() *string {
 		return nil
 	}
 
-	addr := r.spec.FromAddress.String()
+	addr := eip55String(r.spec.FromAddress.Address())
 	return &addr
 }
 
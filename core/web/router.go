@@ -215,6 +215,7 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 
 	authv2 := r.Group("/v2", auth.Authenticate(app.SessionORM(),
 		auth.AuthenticateByToken,
+		auth.AuthenticateByAPIToken,
 		auth.AuthenticateBySession,
 	))
 	{
@@ -222,6 +223,14 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		authv2.PATCH("/user/password", uc.UpdatePassword)
 		authv2.POST("/user/token", uc.NewAPIToken)
 		authv2.POST("/user/token/delete", uc.DeleteAPIToken)
+		authv2.POST("/user/totp", uc.EnrollTOTP)
+		authv2.POST("/user/totp/confirm", uc.ConfirmTOTP)
+		authv2.POST("/user/totp/delete", uc.DisableTOTP)
+
+		atc := APITokensController{app}
+		authv2.GET("/api_tokens", atc.Index)
+		authv2.POST("/api_tokens", atc.Create)
+		authv2.DELETE("/api_tokens/:ID", atc.Destroy)
 
 		wa := NewWebAuthnController(app)
 		authv2.GET("/enroll_webauthn", wa.BeginRegistration)
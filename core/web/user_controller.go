@@ -28,6 +28,18 @@ type UpdatePasswordRequest struct {
 	NewPassword string `json:"newPassword"`
 }
 
+// TOTPRequest defines the request to enroll the current session's User in,
+// or disable, TOTP two-factor authentication.
+type TOTPRequest struct {
+	Password string `json:"password"`
+}
+
+// TOTPConfirmRequest defines the request to confirm the current session's
+// User's pending TOTP enrollment.
+type TOTPConfirmRequest struct {
+	Code string `json:"code"`
+}
+
 // UpdatePassword changes the password for the current User.
 func (c *UserController) UpdatePassword(ctx *gin.Context) {
 	var request UpdatePasswordRequest
@@ -105,6 +117,81 @@ func (c *UserController) DeleteAPIToken(ctx *gin.Context) {
 	}
 }
 
+// EnrollTOTP generates a pending TOTP secret for the current User and returns
+// the URI that provisions it in an authenticator app. TOTP two-factor
+// authentication is enabled once the secret is confirmed with ConfirmTOTP.
+func (c *UserController) EnrollTOTP(ctx *gin.Context) {
+	user, ok := c.userWithPassword(ctx)
+	if !ok {
+		return
+	}
+	uri, err := c.App.SessionORM().EnrollTOTP(&user)
+	if err != nil {
+		jsonAPIError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponseWithStatus(ctx, presenters.NewTOTPEnrollmentResource(user, uri), "totp enrollment", http.StatusCreated)
+}
+
+// ConfirmTOTP enables TOTP two-factor authentication for the current User if
+// the request holds a valid code for their pending TOTP secret, replacing any
+// previous TOTP secret.
+func (c *UserController) ConfirmTOTP(ctx *gin.Context) {
+	var request TOTPConfirmRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(ctx, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	user, err := c.App.SessionORM().FindUser()
+	if err != nil {
+		jsonAPIError(ctx, http.StatusInternalServerError, fmt.Errorf("failed to obtain current user record: %+v", err))
+		return
+	}
+	if err := c.App.SessionORM().ConfirmTOTP(&user, request.Code); err != nil {
+		jsonAPIError(ctx, http.StatusUnauthorized, err)
+		return
+	}
+
+	jsonAPIResponse(ctx, presenters.NewUserResource(user), "user")
+}
+
+// DisableTOTP disables TOTP two-factor authentication for the current User.
+func (c *UserController) DisableTOTP(ctx *gin.Context) {
+	user, ok := c.userWithPassword(ctx)
+	if !ok {
+		return
+	}
+	if err := c.App.SessionORM().DisableTOTP(&user); err != nil {
+		jsonAPIError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponseWithStatus(ctx, nil, "totp enrollment", http.StatusNoContent)
+}
+
+// userWithPassword returns the current User if the TOTPRequest holds their
+// password, otherwise it responds with an error.
+func (c *UserController) userWithPassword(ctx *gin.Context) (clsession.User, bool) {
+	var request TOTPRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(ctx, http.StatusUnprocessableEntity, err)
+		return clsession.User{}, false
+	}
+
+	user, err := c.App.SessionORM().FindUser()
+	if err != nil {
+		jsonAPIError(ctx, http.StatusInternalServerError, fmt.Errorf("failed to obtain current user record: %+v", err))
+		return clsession.User{}, false
+	}
+	if !utils.CheckPasswordHash(request.Password, user.HashedPassword) {
+		jsonAPIError(ctx, http.StatusUnauthorized, errors.New("incorrect password"))
+		return clsession.User{}, false
+	}
+	return user, true
+}
+
 func (c *UserController) getCurrentSessionID(ctx *gin.Context) (string, error) {
 	session := sessions.Default(ctx)
 	sessionID, ok := session.Get(webauth.SessionIDKey).(string)
@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/smartcontractkit/chainlink/core/auth"
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/sessions"
+	"github.com/smartcontractkit/chainlink/core/web"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -137,3 +140,84 @@ func TestUserController_DeleteAPIKey_unauthorized(t *testing.T) {
 
 	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
 }
+
+func TestUserController_EnrollTOTP(t *testing.T) {
+	t.Parallel()
+
+	app := cltest.NewApplicationEVMDisabled(t)
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+	req, err := json.Marshal(web.TOTPRequest{
+		Password: cltest.Password,
+	})
+	require.NoError(t, err)
+	resp, cleanup := client.Post("/v2/user/totp", bytes.NewBuffer(req))
+	defer cleanup()
+
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var enrollment presenters.TOTPEnrollmentResource
+	err = cltest.ParseJSONAPIResponse(t, resp, &enrollment)
+	require.NoError(t, err)
+	assert.Contains(t, enrollment.ProvisioningURI, "otpauth://totp/")
+
+	user, err := app.SessionORM().FindUser()
+	require.NoError(t, err)
+	require.False(t, user.TOTPSecret.Valid, "TOTP should not be enabled until confirmed")
+	require.True(t, user.TOTPPendingSecret.Valid)
+	assert.Contains(t, enrollment.ProvisioningURI, "secret="+user.TOTPPendingSecret.String)
+
+	confirm := func(code string) *http.Response {
+		req, err := json.Marshal(web.TOTPConfirmRequest{Code: code})
+		require.NoError(t, err)
+		resp, cleanup := client.Post("/v2/user/totp/confirm", bytes.NewBuffer(req))
+		t.Cleanup(cleanup)
+		return resp
+	}
+
+	codeAt := func(at time.Time) string {
+		code, err := sessions.TOTPCode(user.TOTPPendingSecret.String, at)
+		require.NoError(t, err)
+		return code
+	}
+	code := codeAt(time.Now())
+	invalid := "000000"
+	for _, d := range []time.Duration{-30 * time.Second, 0, 30 * time.Second} {
+		if codeAt(time.Now().Add(d)) == invalid {
+			invalid = "111111"
+		}
+	}
+	require.Equal(t, http.StatusUnauthorized, confirm(invalid).StatusCode)
+	require.Equal(t, http.StatusOK, confirm(code).StatusCode)
+
+	confirmed, err := app.SessionORM().FindUser()
+	require.NoError(t, err)
+	require.True(t, confirmed.TOTPSecret.Valid)
+	assert.Equal(t, user.TOTPPendingSecret.String, confirmed.TOTPSecret.String)
+	assert.False(t, confirmed.TOTPPendingSecret.Valid)
+
+	resp, cleanup = client.Post("/v2/user/totp/delete", bytes.NewBuffer(req))
+	defer cleanup()
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	user, err = app.SessionORM().FindUser()
+	require.NoError(t, err)
+	assert.False(t, user.TOTPSecret.Valid)
+}
+
+func TestUserController_EnrollTOTP_unauthorized(t *testing.T) {
+	t.Parallel()
+
+	app := cltest.NewApplicationEVMDisabled(t)
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+	req, err := json.Marshal(web.TOTPRequest{
+		Password: "wrong-password",
+	})
+	require.NoError(t, err)
+	resp, cleanup := client.Post("/v2/user/totp", bytes.NewBuffer(req))
+	defer cleanup()
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}